@@ -0,0 +1,38 @@
+// Package api хранит контракт HTTP API сервиса. Запросы проверяются по нему во время работы,
+// поэтому документ встраивается в бинарник вместе с кодом
+package api
+
+import (
+	"embed"
+	"runtime/debug"
+)
+
+//go:embed openapi.yml
+var OpenAPI []byte
+
+// Docs - собранный Swagger UI (Apache License 2.0), чтобы документация открывалась без доступа в интернет
+//
+//go:embed docs
+var Docs embed.FS
+
+// Version задается при сборке: go build -ldflags "-X tender-management-api/api.Version=1.2.0"
+var Version string
+
+// Без -ldflags версия берется из openapi.yml, а коммит, из которого собран бинарник, добавляется к ней
+func ResolveVersion(specVersion string) string {
+	if Version != "" {
+		return Version
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return specVersion
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" && len(setting.Value) >= 7 {
+			return specVersion + "+" + setting.Value[:7]
+		}
+	}
+
+	return specVersion
+}
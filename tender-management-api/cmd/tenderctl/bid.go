@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"tender-management-api/internal/entity"
+	"tender-management-api/pkg/client"
+)
+
+var bidHeader = []string{"ID", "NAME", "STATUS", "AUTHOR TYPE", "AUTHOR ID", "VERSION", "CREATED AT"}
+
+func (a *app) renderBid(bid *entity.BidOutputModel) error {
+	row := []string{bid.Id, bid.Name, bid.Status, bid.AuthorType, bid.AuthorId, itoa(bid.Version), bid.CreatedAt}
+
+	return render(a.stdout, a.opts.output, bid, bidHeader, [][]string{row})
+}
+
+func runBidCreate(a *app, args []string) error {
+	fs := a.flags("")
+	input := &client.CreateBidRequest{}
+	fs.StringVar(&input.TenderId, "tender-id", "", "tender to propose the bid on (required)")
+	fs.StringVar(&input.Name, "name", "", "bid name (required)")
+	fs.StringVar(&input.Description, "description", "", "bid description (required)")
+	fs.StringVar(&input.AuthorType, "author-type", "User", "User or Organization")
+	fs.StringVar(&input.AuthorId, "author-id", "", "id of the user or organization proposing the bid (required)")
+	fs.StringVar(&input.OrganizationId, "organization-id", "", "organization to propose on behalf of, if the author is in several")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	c, _, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	bid, err := c.CreateBid(a.ctx, input)
+	if err != nil {
+		return err
+	}
+
+	return a.renderBid(bid)
+}
+
+// Решение можно передать и как в API (Approved, Rejected), и глаголом (approve, reject)
+func parseDecision(value string) (string, error) {
+	switch strings.ToLower(value) {
+	case "approve", "approved":
+		return "Approved", nil
+	case "reject", "rejected":
+		return "Rejected", nil
+	}
+
+	return "", fmt.Errorf("--decision should be approve or reject, got %q", value)
+}
+
+func runBidVote(a *app, args []string) error {
+	fs := a.flags("<bidId> ")
+	var decision, onBehalfOf string
+	fs.StringVar(&decision, "decision", "", "approve or reject (required)")
+	fs.StringVar(&onBehalfOf, "on-behalf-of", "", "username of the employee who delegated the vote to you")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	decision, err = parseDecision(decision)
+	if err != nil {
+		return err
+	}
+
+	c, p, err := a.client()
+	if err != nil {
+		return err
+	}
+	if err := requireUsername(p); err != nil {
+		return err
+	}
+
+	bid, err := c.SubmitBidDecision(a.ctx, positional[0], decision, p.Username, onBehalfOf)
+	if err != nil {
+		return err
+	}
+
+	return a.renderBid(bid)
+}
@@ -0,0 +1,321 @@
+// tenderctl - консольный клиент сервиса тендеров. Адрес сервера и пользователь берутся из именованных
+// профилей (tenderctl profile set), флаги команды переопределяют значения профиля
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	"tender-management-api/pkg/client"
+)
+
+const usage = `Usage: tenderctl <group> <command> [arguments] [flags]
+
+Tenders:
+  tender list                  tenders of your organizations
+  tender search                published tenders filtered by service type, tag or attribute
+  tender create                create a tender
+  tender publish <tenderId>    publish a tender
+  tender close <tenderId>      close a tender
+  tender edit <tenderId>       change name, description, service type, tags or attributes
+  tender rollback <tenderId> <version>
+                               make a new version with parameters of an old one
+
+Bids:
+  bid create                   propose a bid on a tender
+  bid vote <bidId>             approve or reject a bid
+
+Reviews:
+  review export <tenderId>     export reviews on previous bids of a bid author
+
+Profiles:
+  profile list                 saved profiles
+  profile set <name>           create or update a profile with --server, --username, --tenant, --language
+  profile use <name>           make a profile current
+  profile delete <name>        delete a profile
+
+Flags accepted by every command:
+  --profile     profile to use instead of the current one (or TENDERCTL_PROFILE)
+  --server      API address including /api, e.g. http://localhost:8080/api
+  --username    user to act as
+  --tenant      tenant id the user must belong to (X-Tenant-Id)
+  --language    language of error messages, en or ru
+  -o, --output  table, json or yaml (default table)
+  --config      path to profiles file (or TENDERCTL_CONFIG)
+
+Run "tenderctl <group> <command> --help" for command flags.
+`
+
+// errUsage означает, что команда вызвана неправильно: вместо ошибки выводится подсказка
+var errUsage = errors.New("usage")
+
+type command struct {
+	run func(a *app, args []string) error
+}
+
+var commands = map[string]map[string]command{
+	"tender": {
+		"list":     {runTenderList},
+		"search":   {runTenderSearch},
+		"create":   {runTenderCreate},
+		"publish":  {runTenderPublish},
+		"close":    {runTenderClose},
+		"edit":     {runTenderEdit},
+		"rollback": {runTenderRollback},
+	},
+	"bid": {
+		"create": {runBidCreate},
+		"vote":   {runBidVote},
+	},
+	"review": {
+		"export": {runReviewExport},
+	},
+	"profile": {
+		"list":   {runProfileList},
+		"set":    {runProfileSet},
+		"use":    {runProfileUse},
+		"delete": {runProfileDelete},
+	},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) < 2 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(stderr, usage)
+
+		return 2
+	}
+
+	group, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "tenderctl: unknown command group %q\n\n%s", args[0], usage)
+
+		return 2
+	}
+	cmd, ok := group[args[1]]
+	if !ok {
+		names := make([]string, 0, len(group))
+		for name := range group {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(stderr, "tenderctl: unknown command %q, %s supports: %s\n", args[1], args[0], strings.Join(names, ", "))
+
+		return 2
+	}
+
+	a := &app{ctx: ctx, name: args[0] + " " + args[1], stdout: stdout, stderr: stderr}
+	err := cmd.run(a, args[2:])
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	}
+	printError(stderr, err)
+
+	return 1
+}
+
+// Ошибки сервера выводятся с кодом и полями, не прошедшими валидацию, чтобы их было удобно разбирать в скриптах
+func printError(w io.Writer, err error) {
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) {
+		fmt.Fprintln(w, "tenderctl: "+err.Error())
+
+		return
+	}
+
+	message := apiErr.Detail
+	if message == "" {
+		message = apiErr.Title
+	}
+	fmt.Fprintf(w, "tenderctl: %s (%d %s)\n", message, apiErr.Status, apiErr.Code)
+	for _, fe := range apiErr.Errors {
+		field := fe.Field
+		if fe.Pointer != "" {
+			field += " " + fe.Pointer
+		}
+		fmt.Fprintf(w, "  %s: %s\n", field, fe.Message)
+	}
+}
+
+// app - состояние одного запуска: общие флаги, выбранный профиль и потоки вывода
+type app struct {
+	ctx    context.Context
+	name   string
+	stdout io.Writer
+	stderr io.Writer
+	opts   options
+}
+
+type options struct {
+	profile  string
+	server   string
+	username string
+	tenant   string
+	language string
+	output   string
+	config   string
+}
+
+// flags создает набор флагов команды, в который уже входят общие флаги
+func (a *app) flags(arguments string) *flag.FlagSet {
+	fs := flag.NewFlagSet(a.name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage: tenderctl %s %s[flags]\n\nFlags:\n", a.name, arguments)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&a.opts.profile, "profile", os.Getenv("TENDERCTL_PROFILE"), "profile to use instead of the current one")
+	fs.StringVar(&a.opts.server, "server", "", "API address including /api")
+	fs.StringVar(&a.opts.username, "username", "", "user to act as")
+	fs.StringVar(&a.opts.tenant, "tenant", "", "tenant id for requests without username")
+	fs.StringVar(&a.opts.language, "language", "", "language of error messages, en or ru")
+	fs.StringVar(&a.opts.output, "output", "table", "output format: table, json or yaml")
+	fs.StringVar(&a.opts.output, "o", "table", "shorthand for --output")
+	fs.StringVar(&a.opts.config, "config", os.Getenv("TENDERCTL_CONFIG"), "path to profiles file")
+
+	return fs
+}
+
+// parse разбирает флаги, стоящие и до, и после позиционных аргументов (flag останавливается на первом из них),
+// и проверяет, что позиционных аргументов ровно count
+func (a *app) parse(fs *flag.FlagSet, args []string, count int) ([]string, error) {
+	positional := make([]string, 0, count)
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+
+			return nil, errUsage
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(positional) != count {
+		fmt.Fprintf(a.stderr, "tenderctl %s: expected %d argument(s), got %d\n", a.name, count, len(positional))
+		fs.Usage()
+
+		return nil, errUsage
+	}
+	switch a.opts.output {
+	case outputTable, outputJSON, outputYAML:
+	default:
+		fmt.Fprintf(a.stderr, "tenderctl %s: unknown output format %q, use table, json or yaml\n", a.name, a.opts.output)
+
+		return nil, errUsage
+	}
+
+	return positional, nil
+}
+
+// settings - профиль с учетом флагов командной строки
+func (a *app) settings() (profile, error) {
+	cfg, err := loadConfig(a.opts.config)
+	if err != nil {
+		return profile{}, err
+	}
+
+	name := a.opts.profile
+	if name == "" {
+		name = cfg.Current
+	}
+	p, ok := cfg.Profiles[name]
+	if a.opts.profile != "" && !ok {
+		return profile{}, fmt.Errorf("profile %q not found, see tenderctl profile list", a.opts.profile)
+	}
+
+	if a.opts.server != "" {
+		p.Server = a.opts.server
+	}
+	if a.opts.username != "" {
+		p.Username = a.opts.username
+	}
+	if a.opts.tenant != "" {
+		p.TenantId = a.opts.tenant
+	}
+	if a.opts.language != "" {
+		p.Language = a.opts.language
+	}
+	if p.Server == "" {
+		return profile{}, errors.New("server address is not set: pass --server or save it with tenderctl profile set <name> --server <url>")
+	}
+
+	return p, nil
+}
+
+func (a *app) client() (*client.Client, profile, error) {
+	p, err := a.settings()
+	if err != nil {
+		return nil, profile{}, err
+	}
+
+	return client.New(client.Config{BaseURL: p.Server, TenantId: p.TenantId, Language: p.Language}), p, nil
+}
+
+// Большинство действий выполняется от имени пользователя, без него сервер ответит 401
+func requireUsername(p profile) error {
+	if p.Username == "" {
+		return errors.New("username is not set: pass --username or save it in the profile")
+	}
+
+	return nil
+}
+
+// stringList - флаг, который можно передать несколько раз: --tag a --tag b
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+
+	return nil
+}
+
+// attributeList - флаг --attribute code=value, который можно передать несколько раз
+type attributeList map[string]string
+
+func (l attributeList) String() string {
+	pairs := make([]string, 0, len(l))
+	for code, value := range l {
+		pairs = append(pairs, code+"="+value)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+func (l attributeList) Set(value string) error {
+	code, attributeValue, ok := strings.Cut(value, "=")
+	if !ok || code == "" {
+		return errors.New("expected code=value")
+	}
+	l[code] = attributeValue
+
+	return nil
+}
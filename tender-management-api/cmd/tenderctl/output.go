@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+
+	maxCellLength = 40
+)
+
+// render выводит value в выбранном формате. Таблица строится из rows, JSON и YAML - из самого value
+// с теми же именами полей, что и в ответах API
+func render(w io.Writer, format string, value any, header []string, rows [][]string) error {
+	switch format {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(value)
+	case outputYAML:
+		return writeYAML(w, value)
+	}
+
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if _, err := io.WriteString(table, strings.Join(header, "\t")+"\n"); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = truncate(cell)
+		}
+		if _, err := io.WriteString(table, strings.Join(cells, "\t")+"\n"); err != nil {
+			return err
+		}
+	}
+
+	return table.Flush()
+}
+
+// yaml.v3 не знает о json-тегах моделей, поэтому значение проходит через JSON: так ключи совпадают
+// с ответами API и сохраняют их порядок
+func writeYAML(w io.Writer, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// JSON разбирается в flow-стиль ({...}, [...]) и строки в кавычках, для чтения удобнее блочный YAML.
+// Строки, которые без кавычек прочитались бы как числа или true, кодировщик все равно возьмет в кавычки
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// В таблице длинные описания обрезаются, полный текст есть в JSON и YAML
+func truncate(cell string) string {
+	cell = strings.Join(strings.Fields(cell), " ")
+	if utf8.RuneCountInString(cell) <= maxCellLength {
+		return cell
+	}
+
+	return string([]rune(cell)[:maxCellLength-1]) + "…"
+}
+
+func itoa(value int) string {
+	return strconv.Itoa(value)
+}
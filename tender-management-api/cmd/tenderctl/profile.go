@@ -0,0 +1,208 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+type profile struct {
+	Server   string `yaml:"server" json:"server"`
+	Username string `yaml:"username,omitempty" json:"username,omitempty"`
+	TenantId string `yaml:"tenantId,omitempty" json:"tenantId,omitempty"`
+	Language string `yaml:"language,omitempty" json:"language,omitempty"`
+}
+
+type config struct {
+	Current  string             `yaml:"current,omitempty"`
+	Profiles map[string]profile `yaml:"profiles"`
+}
+
+// По умолчанию профили хранятся в ~/.config/tenderctl/config.yaml (на macOS и Windows - в их каталоге настроек)
+func configPath(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("find config directory, pass --config: %w", err)
+	}
+
+	return filepath.Join(dir, "tenderctl", "config.yaml"), nil
+}
+
+// Отсутствующий файл - не ошибка: профилей просто еще нет
+func loadConfig(path string) (*config, error) {
+	path, err := configPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &config{Profiles: make(map[string]profile)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]profile)
+	}
+
+	return cfg, nil
+}
+
+// В профиле может храниться пользователь, от имени которого выполняются действия, поэтому файл доступен только владельцу
+func saveConfig(path string, cfg *config) error {
+	path, err := configPath(path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
+
+type profileView struct {
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+	profile
+}
+
+func runProfileList(a *app, args []string) error {
+	fs := a.flags("")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+	cfg, err := loadConfig(a.opts.config)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	views := make([]profileView, 0, len(names))
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		p := cfg.Profiles[name]
+		views = append(views, profileView{Name: name, Current: name == cfg.Current, profile: p})
+		marker := ""
+		if name == cfg.Current {
+			marker = "*"
+		}
+		rows = append(rows, []string{marker, name, p.Server, p.Username, p.TenantId, p.Language})
+	}
+
+	return render(a.stdout, a.opts.output, views, []string{"CURRENT", "NAME", "SERVER", "USERNAME", "TENANT", "LANGUAGE"}, rows)
+}
+
+// Переданные флаги меняют поля профиля, остальные поля остаются прежними. Первый профиль становится текущим
+func runProfileSet(a *app, args []string) error {
+	fs := a.flags("<name> ")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(a.opts.config)
+	if err != nil {
+		return err
+	}
+
+	name := positional[0]
+	p := cfg.Profiles[name]
+	if a.opts.server != "" {
+		p.Server = a.opts.server
+	}
+	if a.opts.username != "" {
+		p.Username = a.opts.username
+	}
+	if a.opts.tenant != "" {
+		p.TenantId = a.opts.tenant
+	}
+	if a.opts.language != "" {
+		p.Language = a.opts.language
+	}
+	if p.Server == "" {
+		return errors.New("profile needs a server address, pass --server")
+	}
+	cfg.Profiles[name] = p
+	if cfg.Current == "" {
+		cfg.Current = name
+	}
+	if err := saveConfig(a.opts.config, cfg); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "Profile %q saved\n", name)
+
+	return nil
+}
+
+func runProfileUse(a *app, args []string) error {
+	fs := a.flags("<name> ")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(a.opts.config)
+	if err != nil {
+		return err
+	}
+
+	name := positional[0]
+	if _, ok := cfg.Profiles[name]; !ok {
+		return fmt.Errorf("profile %q not found, see tenderctl profile list", name)
+	}
+	cfg.Current = name
+	if err := saveConfig(a.opts.config, cfg); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "Using profile %q\n", name)
+
+	return nil
+}
+
+func runProfileDelete(a *app, args []string) error {
+	fs := a.flags("<name> ")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(a.opts.config)
+	if err != nil {
+		return err
+	}
+
+	name := positional[0]
+	if _, ok := cfg.Profiles[name]; !ok {
+		return fmt.Errorf("profile %q not found, see tenderctl profile list", name)
+	}
+	delete(cfg.Profiles, name)
+	if cfg.Current == name {
+		cfg.Current = ""
+	}
+	if err := saveConfig(a.opts.config, cfg); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "Profile %q deleted\n", name)
+
+	return nil
+}
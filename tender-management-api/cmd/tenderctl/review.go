@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"tender-management-api/internal/entity"
+	"tender-management-api/pkg/client"
+)
+
+var reviewHeader = []string{"ID", "BID ID", "AUTHOR", "ORGANIZATION", "QUALITY", "TIMELINESS", "COMMUNICATION", "CREATED AT", "DESCRIPTION"}
+
+func reviewRow(r *entity.ReviewOutputModel) []string {
+	return []string{
+		r.Id, r.BidId, r.AuthorUsername, r.ReviewerOrganizationName,
+		itoa(r.QualityRating), itoa(r.TimelinessRating), itoa(r.CommunicationRating), r.CreatedAt, r.Description,
+	}
+}
+
+// Выгружаются все страницы отзывов: экспорт без --all был бы неполным
+func runReviewExport(a *app, args []string) error {
+	fs := a.flags("<tenderId> ")
+	filter := &client.ReviewFilter{}
+	var file string
+	fs.StringVar(&filter.AuthorUsername, "author", "", "username of the bid author (required)")
+	fs.Float64Var(&filter.MinRating, "min-rating", 0, "minimal average rating, 1-5")
+	fs.Float64Var(&filter.MaxRating, "max-rating", 0, "maximal average rating, 1-5")
+	fs.StringVar(&filter.DateFrom, "from", "", "reviews created on or after the date, 2006-01-02")
+	fs.StringVar(&filter.DateTo, "to", "", "reviews created on or before the date, 2006-01-02")
+	fs.StringVar(&filter.ReviewerOrganizationId, "reviewer-organization-id", "", "only reviews from this organization")
+	fs.BoolVar(&filter.Comparable, "comparable", false, "only reviews on tenders with the same service type")
+	fs.StringVar(&file, "file", "", "write to the file instead of standard output")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if filter.AuthorUsername == "" {
+		return errors.New("pass --author with username of the bid author")
+	}
+
+	c, p, err := a.client()
+	if err != nil {
+		return err
+	}
+	if err := requireUsername(p); err != nil {
+		return err
+	}
+	filter.RequesterUsername = p.Username
+
+	reviews, err := c.ReviewsOnBidAuthorBids(a.ctx, positional[0], filter).All()
+	if err != nil {
+		return err
+	}
+	rows := make([][]string, 0, len(reviews))
+	for i := range reviews {
+		rows = append(rows, reviewRow(&reviews[i]))
+	}
+
+	if file == "" {
+		return render(a.stdout, a.opts.output, reviews, reviewHeader, rows)
+	}
+
+	out, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := render(out, a.opts.output, reviews, reviewHeader, rows); err != nil {
+		out.Close()
+
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stderr, "Exported %d review(s) to %s\n", len(reviews), file)
+
+	return nil
+}
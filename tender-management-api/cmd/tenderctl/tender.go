@@ -0,0 +1,249 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"tender-management-api/internal/entity"
+	"tender-management-api/pkg/client"
+)
+
+var tenderHeader = []string{"ID", "NAME", "STATUS", "SERVICE TYPE", "VERSION", "DEADLINE", "TAGS"}
+
+func tenderRow(t *entity.TenderOutputModel) []string {
+	return []string{t.Id, t.Name, t.Status, t.ServiceType, itoa(t.Version), t.Deadline, strings.Join(t.Tags, ",")}
+}
+
+func (a *app) renderTenders(tenders []entity.TenderOutputModel) error {
+	rows := make([][]string, 0, len(tenders))
+	for i := range tenders {
+		rows = append(rows, tenderRow(&tenders[i]))
+	}
+
+	return render(a.stdout, a.opts.output, tenders, tenderHeader, rows)
+}
+
+func (a *app) renderTender(tender *entity.TenderOutputModel) error {
+	return render(a.stdout, a.opts.output, tender, tenderHeader, [][]string{tenderRow(tender)})
+}
+
+// Флаги выборки списков: одна страница или, с --all, все
+type listFlags struct {
+	limit  int
+	offset int
+	all    bool
+}
+
+func (l *listFlags) register(fs *flag.FlagSet) {
+	fs.IntVar(&l.limit, "limit", 50, "page size, up to 50")
+	fs.IntVar(&l.offset, "offset", 0, "number of objects to skip")
+	fs.BoolVar(&l.all, "all", false, "fetch every page")
+}
+
+func (l *listFlags) page() client.Page {
+	return client.Page{Limit: l.limit, Offset: l.offset}
+}
+
+func registerTenderFilter(fs *flag.FlagSet, filter *client.TenderFilter) {
+	filter.Attributes = make(map[string]string)
+	fs.Var((*stringList)(&filter.ServiceTypes), "service-type", "service type code, can be repeated")
+	fs.Var((*stringList)(&filter.Tags), "tag", "tag, can be repeated")
+	fs.Var(attributeList(filter.Attributes), "attribute", "attribute condition code=value, can be repeated")
+}
+
+func runTenderList(a *app, args []string) error {
+	fs := a.flags("")
+	var list listFlags
+	list.register(fs)
+	filter := &client.UserTenderFilter{}
+	registerTenderFilter(fs, &filter.TenderFilter)
+	fs.StringVar(&filter.OrganizationId, "organization-id", "", "only tenders of this organization")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	c, p, err := a.client()
+	if err != nil {
+		return err
+	}
+	if err := requireUsername(p); err != nil {
+		return err
+	}
+
+	var tenders []entity.TenderOutputModel
+	if list.all {
+		tenders, err = c.UserTenders(a.ctx, p.Username, filter).All()
+	} else {
+		tenders, err = c.GetUserTenders(a.ctx, p.Username, filter, list.page())
+	}
+	if err != nil {
+		return err
+	}
+
+	return a.renderTenders(tenders)
+}
+
+func runTenderSearch(a *app, args []string) error {
+	fs := a.flags("")
+	var list listFlags
+	list.register(fs)
+	filter := &client.TenderFilter{}
+	registerTenderFilter(fs, filter)
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	c, p, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	// без username сервер ищет в арендаторе по умолчанию
+	var tenders []entity.TenderOutputModel
+	if list.all {
+		tenders, err = c.Tenders(a.ctx, p.Username, filter).All()
+	} else {
+		tenders, err = c.GetTenders(a.ctx, p.Username, filter, list.page())
+	}
+	if err != nil {
+		return err
+	}
+
+	return a.renderTenders(tenders)
+}
+
+func runTenderCreate(a *app, args []string) error {
+	fs := a.flags("")
+	input := &client.CreateTenderRequest{Attributes: make(map[string]string)}
+	var deadline string
+	fs.StringVar(&input.Name, "name", "", "tender name (required)")
+	fs.StringVar(&input.Description, "description", "", "tender description (required)")
+	fs.StringVar(&input.ServiceType, "service-type", "", "service type code (required)")
+	fs.StringVar(&input.OrganizationId, "organization-id", "", "organization opening the tender (required)")
+	fs.StringVar(&deadline, "deadline", "", "bids deadline in RFC3339, e.g. 2030-01-02T15:04:05Z")
+	fs.Var((*stringList)(&input.Tags), "tag", "tag, can be repeated")
+	fs.Var(attributeList(input.Attributes), "attribute", "attribute value code=value, can be repeated")
+	fs.BoolVar(&input.RequiresSignature, "requires-signature", false, "accept only signed bids")
+	fs.StringVar(&input.ConflictPolicy, "conflict-policy", "", "Flag or Block bids from related organizations")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	if deadline != "" {
+		parsed, err := time.Parse(time.RFC3339, deadline)
+		if err != nil {
+			return fmt.Errorf("--deadline should be in RFC3339, e.g. 2030-01-02T15:04:05Z: %w", err)
+		}
+		input.Deadline = &parsed
+	}
+
+	c, p, err := a.client()
+	if err != nil {
+		return err
+	}
+	if err := requireUsername(p); err != nil {
+		return err
+	}
+	input.CreatorUsername = p.Username
+
+	tender, err := c.CreateTender(a.ctx, input)
+	if err != nil {
+		return err
+	}
+
+	return a.renderTender(tender)
+}
+
+func runTenderPublish(a *app, args []string) error {
+	return a.changeTenderStatus(args, "Published")
+}
+
+func runTenderClose(a *app, args []string) error {
+	return a.changeTenderStatus(args, "Closed")
+}
+
+func (a *app) changeTenderStatus(args []string, status string) error {
+	fs := a.flags("<tenderId> ")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	c, p, err := a.client()
+	if err != nil {
+		return err
+	}
+	if err := requireUsername(p); err != nil {
+		return err
+	}
+
+	tender, err := c.UpdateTenderStatus(a.ctx, positional[0], status, p.Username)
+	if err != nil {
+		return err
+	}
+
+	return a.renderTender(tender)
+}
+
+func runTenderEdit(a *app, args []string) error {
+	fs := a.flags("<tenderId> ")
+	input := &client.EditTenderRequest{Attributes: make(map[string]string)}
+	fs.StringVar(&input.Name, "name", "", "new name")
+	fs.StringVar(&input.Description, "description", "", "new description")
+	fs.StringVar(&input.ServiceType, "service-type", "", "new service type code")
+	fs.Var((*stringList)(&input.Tags), "tag", "replaces tags, can be repeated")
+	fs.Var(attributeList(input.Attributes), "attribute", "replaces attributes, code=value, can be repeated")
+	positional, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if input.Name == "" && input.Description == "" && input.ServiceType == "" && len(input.Tags) == 0 && len(input.Attributes) == 0 {
+		return errors.New("nothing to change: pass --name, --description, --service-type, --tag or --attribute")
+	}
+
+	c, p, err := a.client()
+	if err != nil {
+		return err
+	}
+	if err := requireUsername(p); err != nil {
+		return err
+	}
+
+	tender, err := c.EditTender(a.ctx, positional[0], p.Username, input)
+	if err != nil {
+		return err
+	}
+
+	return a.renderTender(tender)
+}
+
+func runTenderRollback(a *app, args []string) error {
+	fs := a.flags("<tenderId> <version> ")
+	positional, err := a.parse(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	version, err := strconv.Atoi(positional[1])
+	if err != nil || version < 1 {
+		return fmt.Errorf("version should be a positive number, got %q", positional[1])
+	}
+
+	c, p, err := a.client()
+	if err != nil {
+		return err
+	}
+	if err := requireUsername(p); err != nil {
+		return err
+	}
+
+	tender, err := c.RollbackTender(a.ctx, positional[0], version, p.Username)
+	if err != nil {
+		return err
+	}
+
+	return a.renderTender(tender)
+}
@@ -3,9 +3,66 @@ package common
 const (
 	ApprovedDecision = "Approved"
 	RejectedDecision = "Rejected"
-	Construction     = "Construction"
-	Delivery         = "Delivery"
-	Manufacture      = "Manufacture"
 	Published        = "Published"
 	Created          = "Created"
+	Canceled         = "Canceled"
+)
+
+const (
+	ContractDraft      = "Draft"
+	ContractSigned     = "Signed"
+	ContractInProgress = "InProgress"
+	ContractCompleted  = "Completed"
+	ContractTerminated = "Terminated"
+)
+
+const (
+	CreateBidAction   = "Create"
+	EditBidAction     = "Edit"
+	RollbackBidAction = "Rollback"
+	ResubmitBidAction = "Resubmit"
+	AttachBidAction   = "Attach"
+)
+
+const (
+	ConflictFlag  = "Flag"
+	ConflictBlock = "Block"
+)
+
+const (
+	ParentRelation    = "Parent"
+	AffiliateRelation = "Affiliate"
+)
+
+// Причины конфликта интересов между автором предложения и организацией тендера
+const (
+	SharedResponsibleConflict = "SharedResponsible"
+	ParentConflict            = "Parent"
+	SubsidiaryConflict        = "Subsidiary"
+	AffiliateConflict         = "Affiliate"
+	SameParentConflict        = "SameParent"
+)
+
+const (
+	OrganizationAuthor = "Organization"
+	UserAuthor         = "User"
+)
+
+const (
+	TenderPublishedEvent      = "TenderPublished"
+	TenderStatusChangedEvent  = "TenderStatusChanged"
+	BidStatusChangedEvent     = "BidStatusChanged"
+	BidDecisionChangedEvent   = "BidDecisionChanged"
+	BidFeedbackSubmittedEvent = "BidFeedbackSubmitted"
+	BidVoteRequestedEvent     = "BidVoteRequested"
+	TenderClosingSoonEvent    = "TenderClosingSoon"
+)
+
+// Роли сотрудников в организации
+const (
+	OrgAdminRole      = "OrgAdmin"
+	TenderManagerRole = "TenderManager"
+	ApproverRole      = "Approver"
+	BidderRole        = "Bidder"
+	AuditorRole       = "Auditor"
 )
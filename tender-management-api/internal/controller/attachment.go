@@ -0,0 +1,240 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo"
+)
+
+// Запас сверх лимита на файл под заголовки multipart-формы
+const attachmentFormOverhead = 1 << 20
+
+type attachmentRoutesHandler struct {
+	attachmentService service.Attachments
+	validate          *validator.Validate
+}
+
+func newAttachmentRoutesHandler(outer *echo.Group, services *service.Services, v *validator.Validate) *attachmentRoutesHandler {
+	h := &attachmentRoutesHandler{attachmentService: services.Attachments, validate: v}
+	outer.POST("/tenders/:tenderId/attachments", h.UploadTenderAttachment)
+	outer.GET("/tenders/:tenderId/attachments", h.GetTenderAttachments)
+	outer.GET("/tenders/:tenderId/attachments/:attachmentId", h.DownloadTenderAttachment)
+
+	outer.POST("/bids/:bidId/attachments", h.UploadBidAttachment)
+	outer.GET("/bids/:bidId/attachments", h.GetBidAttachments)
+	outer.GET("/bids/:bidId/attachments/:attachmentId", h.DownloadBidAttachment)
+
+	return h
+}
+
+type uploadAttachmentInput struct {
+	Username string `query:"username" validate:"required"`
+	Checksum string `query:"checksum" validate:"omitempty,len=64,hexadecimal"`
+}
+
+// Файл читается с запасом в один байт, чтобы сервис мог отличить слишком большой файл от файла ровно на лимит.
+// Форма, не уместившаяся в лимит вместе с заголовками, отклоняется здесь же как слишком большой файл
+func readAttachmentFile(c echo.Context) (string, []byte, error) {
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, service.MaxAttachmentSize+attachmentFormOverhead)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return "", nil, service.ErrAttachmentTooLarge.WithDetails(map[string]any{"maxSize": service.MaxAttachmentSize})
+		}
+
+		return "", nil, errAttachmentFileMissing
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", nil, errAttachmentFileMissing
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, service.MaxAttachmentSize+1))
+	if err != nil {
+		return "", nil, errAttachmentFileMissing
+	}
+
+	return fileHeader.Filename, content, nil
+}
+
+func writeAttachmentContent(c echo.Context, attachment *entity.AttachmentContent) error {
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Attachment.FileName})
+	c.Response().Header().Set("Content-Disposition", disposition)
+	c.Response().Header().Set("X-Checksum-Sha256", attachment.Attachment.Checksum)
+
+	return c.Blob(http.StatusOK, attachment.Attachment.ContentType, attachment.Content)
+}
+
+// /tenders/:tenderId/attachments
+func (h *attachmentRoutesHandler) UploadTenderAttachment(c echo.Context) error {
+	input := uploadAttachmentInput{
+		Username: c.QueryParam("username"),
+		Checksum: c.QueryParam("checksum"),
+	}
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	fileName, content, err := readAttachmentFile(c)
+	if err != nil {
+		return err
+	}
+
+	attachment, err := h.attachmentService.UploadTenderAttachment(c.Request().Context(), c.Param("tenderId"), &entity.UploadAttachmentInput{
+		FileName: fileName,
+		Checksum: input.Checksum,
+		Username: input.Username,
+		Content:  content,
+	})
+	if err == nil {
+		if e := c.JSON(http.StatusOK, attachment); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type getTenderAttachmentsInput struct {
+	Username string `query:"username" validate:""`
+	Version  int    `query:"version" validate:"gte=0"`
+}
+
+// /tenders/:tenderId/attachments
+func (h *attachmentRoutesHandler) GetTenderAttachments(c echo.Context) error {
+	var input getTenderAttachmentsInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	usernamePassed := input.Username != defaultUsername
+	attachments, err := h.attachmentService.GetTenderAttachments(c.Request().Context(), c.Param("tenderId"), input.Username, usernamePassed, input.Version)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, attachments); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type downloadTenderAttachmentInput struct {
+	Username string `query:"username" validate:""`
+}
+
+// /tenders/:tenderId/attachments/:attachmentId
+func (h *attachmentRoutesHandler) DownloadTenderAttachment(c echo.Context) error {
+	var input downloadTenderAttachmentInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	usernamePassed := input.Username != defaultUsername
+	attachment, err := h.attachmentService.DownloadTenderAttachment(c.Request().Context(), c.Param("tenderId"), c.Param("attachmentId"), input.Username, usernamePassed)
+	if err == nil {
+		return writeAttachmentContent(c, attachment)
+	}
+
+	return err
+}
+
+// /bids/:bidId/attachments
+func (h *attachmentRoutesHandler) UploadBidAttachment(c echo.Context) error {
+	input := uploadAttachmentInput{
+		Username: c.QueryParam("username"),
+		Checksum: c.QueryParam("checksum"),
+	}
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	fileName, content, err := readAttachmentFile(c)
+	if err != nil {
+		return err
+	}
+
+	attachment, err := h.attachmentService.UploadBidAttachment(c.Request().Context(), c.Param("bidId"), &entity.UploadAttachmentInput{
+		FileName: fileName,
+		Checksum: input.Checksum,
+		Username: input.Username,
+		Content:  content,
+	})
+	if err == nil {
+		if e := c.JSON(http.StatusOK, attachment); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type getBidAttachmentsInput struct {
+	Username string `query:"username" validate:"required"`
+	Version  int    `query:"version" validate:"gte=0"`
+}
+
+// /bids/:bidId/attachments
+func (h *attachmentRoutesHandler) GetBidAttachments(c echo.Context) error {
+	var input getBidAttachmentsInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	attachments, err := h.attachmentService.GetBidAttachments(c.Request().Context(), c.Param("bidId"), input.Username, input.Version)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, attachments); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type downloadBidAttachmentInput struct {
+	Username string `query:"username" validate:"required"`
+}
+
+// /bids/:bidId/attachments/:attachmentId
+func (h *attachmentRoutesHandler) DownloadBidAttachment(c echo.Context) error {
+	var input downloadBidAttachmentInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	attachment, err := h.attachmentService.DownloadBidAttachment(c.Request().Context(), c.Param("bidId"), c.Param("attachmentId"), input.Username)
+	if err == nil {
+		return writeAttachmentContent(c, attachment)
+	}
+
+	return err
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"tender-management-api/internal/service"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func newMultipartRequest(t *testing.T, field string, size int) *http.Request {
+	t.Helper()
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	part, err := form.CreateFormFile(field, "spec.pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = part.Write(bytes.Repeat([]byte{'a'}, size)); err != nil {
+		t.Fatal(err)
+	}
+	if err = form.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/tenders/"+uuid.NewString()+"/attachments?username=user1", body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	return req
+}
+
+// Форма отклоняется до обращения к сервису, поэтому сервис файлов не нужен
+func TestUploadAttachmentRejectsBadForms(t *testing.T) {
+	handler, err := newTestRouter(t, &service.Services{Tenants: tenantServiceStub{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		req    *http.Request
+		status int
+		code   string
+	}{
+		{"oversize form", newMultipartRequest(t, "file", service.MaxAttachmentSize+attachmentFormOverhead), http.StatusRequestEntityTooLarge, "attachment_too_large"},
+		{"no file field", newMultipartRequest(t, "document", 10), http.StatusBadRequest, "attachment_file_missing"},
+	}
+	for _, c := range cases {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, c.req)
+
+		var problem struct {
+			Code string `json:"code"`
+		}
+		if err = json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+			t.Fatalf("%s: decode problem: %v", c.name, err)
+		}
+		if rec.Code != c.status || problem.Code != c.code {
+			t.Errorf("%s: got %d %s, want %d %s", c.name, rec.Code, problem.Code, c.status, c.code)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"tender-management-api/internal/service"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"github.com/labstack/echo"
 )
 
@@ -24,12 +25,17 @@ func newBidRoutesHandler(outer *echo.Group, services *service.Services, v *valid
 
 	outer.GET("/bids/:bidId/status", h.GetBidStatus)
 	outer.PUT("/bids/:bidId/status", h.UpdateBidStatus)
+	outer.GET("/bids/:bidId/versions", h.GetBidVersions)
+	outer.GET("/bids/:bidId/votes", h.GetBidVotes)
 
 	outer.PATCH("/bids/:bidId/edit", h.EditBid)
 	outer.PUT("/bids/:bidId/submit_decision", h.SubmitDecision)
 
 	outer.PUT("/bids/:bidId/feedback", h.SubmitBidFeedback)
 	outer.PUT("/bids/:bidId/rollback/:version", h.RollbackBidVersion)
+	outer.PUT("/bids/:bidId/withdraw", h.WithdrawBid)
+	outer.PUT("/bids/:bidId/withdrawal_consent", h.ConsentBidWithdrawal)
+	outer.PUT("/bids/:bidId/resubmit", h.ResubmitBid)
 	outer.GET("/bids/:tenderId/reviews", h.GetReviewsOnBidAuthorBids)
 
 	return h
@@ -41,6 +47,8 @@ type postBidInput struct {
 	TenderId    string `json:"tenderId" validate:"required,max=100"`
 	AuthorType  string `json:"authorType" validate:"required,oneof=Organization User"`
 	AuthorId    string `json:"authorId" validate:"required,max=100"`
+	// организация, от имени которой подается предложение; обязательна, если автор состоит в нескольких
+	OrganizationId string `json:"organizationId" validate:"omitempty,uuid"`
 }
 
 // в api не хватает bad request (например могут передать неверный тип пользователя)
@@ -49,25 +57,21 @@ type postBidInput struct {
 func (h *bidRoutesHandler) PostBid(c echo.Context) error {
 	var input postBidInput
 	if err := c.Bind(&input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-			return e
-		}
-
-		return err
+		return errMalformedInput
 	}
 
 	if err := h.validate.Struct(input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Not enough values passed or incorrect input value passed"}); e != nil {
-			return e
-		}
-
-		return err
+		return newValidationError(err)
 	}
 
 	model := &entity.CreateBidInput{
 		Name: input.Name, Description: input.Description, TenderId: input.TenderId,
 		AuthorId: input.AuthorId, AuthorType: input.AuthorType,
 	}
+	if input.OrganizationId != "" {
+		organizationId := uuid.MustParse(input.OrganizationId)
+		model.OrganizationId = &organizationId
+	}
 
 	bid, err := h.bidService.CreateBid(c.Request().Context(), model)
 	if err == nil {
@@ -78,36 +82,14 @@ func (h *bidRoutesHandler) PostBid(c echo.Context) error {
 		return nil
 	}
 
-	switch err {
-	case service.ErrTenderNotFound:
-		if e := c.JSON(http.StatusNotFound, errorResponse{"There is no tender with given id"}); e != nil {
-			return e
-		}
-	case service.ErrEmployeeNotFound:
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"There is no employee with given id"}); e != nil {
-			return e
-		}
-	case service.ErrUserHasNoAccessToTender:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"Tender isn't published, so you can't create bid"}); e != nil {
-			return e
-		}
-	case service.ErrBidCanNotBeProposedBySameOrganization:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"Bid can't be proposed on behalf of the organization that owns the tender"}); e != nil {
-			return e
-		}
-	default:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Error"}); e != nil {
-			return e
-		}
-	}
-
 	return err
 }
 
 type getUserBidsInput struct {
-	Limit    int32  `query:"limit" validate:"gte=0,lte=50"`
-	Offset   int32  `query:"offset" validate:"gte=0"`
-	Username string `query:"username" validate:""`
+	Limit          int32  `query:"limit" validate:"gte=0,lte=50"`
+	Offset         int32  `query:"offset" validate:"gte=0"`
+	Username       string `query:"username" validate:""`
+	OrganizationId string `query:"organizationId" validate:"omitempty,uuid"`
 }
 
 func newGetUserBidsInput() getUserBidsInput {
@@ -120,31 +102,19 @@ func newGetUserBidsInput() getUserBidsInput {
 func (h *bidRoutesHandler) GetUserBids(c echo.Context) error {
 	var input = newGetUserBidsInput()
 	if err := c.Bind(&input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-			return e
-		}
-
-		return err
+		return errMalformedInput
 	}
 
 	if err := h.validate.Struct(input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{getAllErrorMessages(err)}); e != nil {
-			return e
-		}
-
-		return err
+		return newValidationError(err)
 	}
 
 	if input.Username == defaultUsername {
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"Please provide your username"}); e != nil {
-			return e
-		}
-
-		return nil
+		return errUsernameRequired
 	}
 
 	pg := entity.NewPaginationInput(int(input.Limit), int(input.Offset))
-	bids, err := h.bidService.GetUserBids(c.Request().Context(), input.Username, pg)
+	bids, err := h.bidService.GetUserBids(c.Request().Context(), input.Username, input.OrganizationId, pg)
 	if err == nil {
 		if e := c.JSON(http.StatusOK, bids); e != nil {
 			return e
@@ -153,17 +123,6 @@ func (h *bidRoutesHandler) GetUserBids(c echo.Context) error {
 		return nil
 	}
 
-	switch err {
-	case service.ErrEmployeeNotFound:
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"There is no employee with given username"}); e != nil {
-			return e
-		}
-	default:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Error"}); e != nil {
-			return e
-		}
-	}
-
 	return err
 }
 
@@ -185,20 +144,12 @@ func newGetTenderBidsInput() getTenderBidsInput {
 func (h *bidRoutesHandler) GetTenderBids(c echo.Context) error {
 	var input = newGetTenderBidsInput()
 	if err := c.Bind(&input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-			return e
-		}
-
-		return err
+		return errMalformedInput
 	}
 
 	input.TenderId = c.Param("tenderId")
 	if err := h.validate.Struct(input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{getAllErrorMessages(err)}); e != nil {
-			return e
-		}
-
-		return err
+		return newValidationError(err)
 	}
 
 	pg := entity.NewPaginationInput(int(input.Limit), int(input.Offset))
@@ -211,25 +162,6 @@ func (h *bidRoutesHandler) GetTenderBids(c echo.Context) error {
 		return nil
 	}
 
-	switch err {
-	case service.ErrTenderNotFound:
-		if e := c.JSON(http.StatusNotFound, errorResponse{"There is no tender with given id"}); e != nil {
-			return e
-		}
-	case service.ErrEmployeeNotFound:
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"There is no employee with given username"}); e != nil {
-			return e
-		}
-	case service.ErrUserHasNoAccessToTender:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"Only responsible for tender's organization can see bids of requested tender"}); e != nil {
-			return e
-		}
-	default:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Error"}); e != nil {
-			return e
-		}
-	}
-
 	return err
 }
 
@@ -245,20 +177,12 @@ type getBidStatusInput struct {
 func (h *bidRoutesHandler) GetBidStatus(c echo.Context) error {
 	var input getBidStatusInput
 	if err := c.Bind(&input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-			return e
-		}
-
-		return err
+		return errMalformedInput
 	}
 
 	input.BidId = c.Param("bidId")
 	if err := h.validate.Struct(input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{getAllErrorMessages(err)}); e != nil {
-			return e
-		}
-
-		return err
+		return newValidationError(err)
 	}
 
 	status, err := h.bidService.GetBidStatusById(c.Request().Context(), input.BidId, input.Username)
@@ -270,23 +194,52 @@ func (h *bidRoutesHandler) GetBidStatus(c echo.Context) error {
 		return nil
 	}
 
-	switch err {
-	case service.ErrBidNotFound:
-		if e := c.JSON(http.StatusNotFound, errorResponse{"There is no tender with given id"}); e != nil {
-			return e
-		}
-	case service.ErrEmployeeNotFound:
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"There is no employee with given username"}); e != nil {
-			return e
-		}
-	case service.ErrUserHasNoAccessToBid:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"Only bid author and responsible for tender's organization can view bid status"}); e != nil {
+	return err
+}
+
+// /bids/:bidId/versions
+func (h *bidRoutesHandler) GetBidVersions(c echo.Context) error {
+	var input getBidStatusInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.BidId = c.Param("bidId")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	versions, err := h.bidService.GetBidVersions(c.Request().Context(), input.BidId, input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, versions); e != nil {
 			return e
 		}
-	default:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Error"}); e != nil {
+
+		return nil
+	}
+
+	return err
+}
+
+// /bids/:bidId/votes
+func (h *bidRoutesHandler) GetBidVotes(c echo.Context) error {
+	var input getBidStatusInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.BidId = c.Param("bidId")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	votes, err := h.bidService.GetBidVotes(c.Request().Context(), input.BidId, input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, votes); e != nil {
 			return e
 		}
+
+		return nil
 	}
 
 	return err
@@ -304,20 +257,12 @@ func (h *bidRoutesHandler) UpdateBidStatus(c echo.Context) error {
 	if err := c.Bind(&input); err != nil {
 		msg := err.Error()
 		if !strings.Contains(msg, "Request body can't be empty") {
-			if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-				return e
-			}
-
-			return err
+			return errMalformedInput
 		}
 	}
 	input.BidId, input.Status, input.Username = c.Param("bidId"), c.QueryParam("status"), c.QueryParam("username")
 	if err := h.validate.Struct(input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{getAllErrorMessages(err)}); e != nil {
-			return e
-		}
-
-		return err
+		return newValidationError(err)
 	}
 
 	bid, err := h.bidService.UpdateBidStatusById(c.Request().Context(), input.BidId, input.Status, input.Username)
@@ -329,25 +274,6 @@ func (h *bidRoutesHandler) UpdateBidStatus(c echo.Context) error {
 		return nil
 	}
 
-	switch err {
-	case service.ErrBidNotFound:
-		if e := c.JSON(http.StatusNotFound, errorResponse{"There is no bid with given id"}); e != nil {
-			return e
-		}
-	case service.ErrEmployeeNotFound:
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"There is no employee with given username"}); e != nil {
-			return e
-		}
-	case service.ErrUserHasNoAccessToBid:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"You have not enough rights to update bid status"}); e != nil {
-			return e
-		}
-	default:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Error"}); e != nil {
-			return e
-		}
-	}
-
 	return err
 }
 
@@ -362,21 +288,13 @@ type editBidInput struct {
 func (h *bidRoutesHandler) EditBid(c echo.Context) error {
 	var input editBidInput
 	if err := c.Bind(&input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-			return e
-		}
-
-		return err
+		return errMalformedInput
 	}
 
 	input.Username = c.QueryParam("username")
 	input.BidId = c.Param("bidId")
 	if input.Name == "" && input.Description == "" {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Bid updates required, set bid's name and/or description"}); e != nil {
-			return e
-		}
-
-		return nil
+		return errBidUpdatesRequired
 	}
 
 	bid, err := h.bidService.EditBidById(c.Request().Context(), input.BidId, input.Username, input.Name, input.Description)
@@ -388,25 +306,6 @@ func (h *bidRoutesHandler) EditBid(c echo.Context) error {
 		return nil
 	}
 
-	switch err {
-	case service.ErrBidNotFound:
-		if e := c.JSON(http.StatusNotFound, errorResponse{"There is no bid with given id"}); e != nil {
-			return e
-		}
-	case service.ErrEmployeeNotFound:
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"There is no employee with given username"}); e != nil {
-			return e
-		}
-	case service.ErrUserHasNoAccessToBid:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"You have not enough rights to edit bid"}); e != nil {
-			return e
-		}
-	default:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Error"}); e != nil {
-			return e
-		}
-	}
-
 	return err
 }
 
@@ -414,6 +313,7 @@ type submitBidDecisionInput struct {
 	BidId       string `param:"bidId" validate:"required"`
 	Username    string `query:"username" validate:"required"`
 	BisDecision string `query:"decision" validate:"required,oneof=Approved Rejected"`
+	OnBehalfOf  string `query:"onBehalfOf"`
 }
 
 // /bids/:bidId/submit_decision
@@ -422,24 +322,17 @@ func (h *bidRoutesHandler) SubmitDecision(c echo.Context) error {
 	if err := c.Bind(&input); err != nil {
 		msg := err.Error()
 		if !strings.Contains(msg, "Request body can't be empty") {
-			if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-				return e
-			}
-
-			return err
+			return errMalformedInput
 		}
 	}
 
 	input.BidId, input.BisDecision, input.Username = c.Param("bidId"), c.QueryParam("decision"), c.QueryParam("username")
+	input.OnBehalfOf = c.QueryParam("onBehalfOf")
 	if err := h.validate.Struct(input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{getAllErrorMessages(err)}); e != nil {
-			return e
-		}
-
-		return err
+		return newValidationError(err)
 	}
 
-	bid, err := h.bidService.SubmitBidDecision(c.Request().Context(), input.BidId, input.BisDecision, input.Username)
+	bid, err := h.bidService.SubmitBidDecision(c.Request().Context(), input.BidId, input.BisDecision, input.Username, input.OnBehalfOf)
 	if err == nil {
 		if e := c.JSON(http.StatusOK, bid); e != nil {
 			return e
@@ -448,37 +341,6 @@ func (h *bidRoutesHandler) SubmitDecision(c echo.Context) error {
 		return nil
 	}
 
-	switch err {
-	case service.ErrBidNotFound:
-		if e := c.JSON(http.StatusNotFound, errorResponse{"There is no bid with given id"}); e != nil {
-			return e
-		}
-	case service.ErrEmployeeNotFound:
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"There is no employee with given username"}); e != nil {
-			return e
-		}
-	case service.ErrTenderNotFound:
-		if e := c.JSON(http.StatusNotFound, errorResponse{"There is no more tender for bid"}); e != nil {
-			return e
-		}
-	case service.ErrBidAuthorCanNotMakeDecisionsOnIt:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"You can't make decision on bid, because you are its author"}); e != nil {
-			return e
-		}
-	case service.ErrUserHasNoAccessToTender:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"You aren't responsible for organization that opened tender, therefore you can't submit decisions connected with given tender"}); e != nil {
-			return e
-		}
-	case service.ErrAlreadyApproveBid:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"You have already approved bid"}); e != nil {
-			return e
-		}
-	default:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Error"}); e != nil {
-			return e
-		}
-	}
-
 	return err
 }
 
@@ -494,22 +356,14 @@ func (h *bidRoutesHandler) RollbackBidVersion(c echo.Context) error {
 	if err := c.Bind(&input); err != nil {
 		msg := err.Error()
 		if !strings.Contains(msg, "Request body can't be empty") {
-			if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-				return e
-			}
-
-			return err
+			return errMalformedInput
 		}
 	}
 
 	v, _ := strconv.Atoi(c.Param("version"))
 	input.BidId, input.Username, input.Version = c.Param("bidId"), c.QueryParam("username"), v
 	if err := h.validate.Struct(input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{getAllErrorMessages(err)}); e != nil {
-			return e
-		}
-
-		return err
+		return newValidationError(err)
 	}
 
 	tender, err := h.bidService.RollbackBidVersion(c.Request().Context(), input.BidId, input.Version, input.Username)
@@ -521,29 +375,6 @@ func (h *bidRoutesHandler) RollbackBidVersion(c echo.Context) error {
 		return nil
 	}
 
-	switch err {
-	case service.ErrTenderNotFound:
-		if e := c.JSON(http.StatusNotFound, errorResponse{"There is no tender with given id"}); e != nil {
-			return e
-		}
-	case service.ErrEmployeeNotFound:
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"There is no employee with given username"}); e != nil {
-			return e
-		}
-	case service.ErrUserHasNoAccessToBid:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"You have no enough rights to edit tender"}); e != nil {
-			return e
-		}
-	case service.ErrNoSuchVersion:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"No such version"}); e != nil {
-			return e
-		}
-	default:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Error"}); e != nil {
-			return e
-		}
-	}
-
 	return err
 }
 
@@ -553,6 +384,13 @@ type getReviewsOnBidAuthorBidsInput struct {
 	RequesterUsername string `query:"requesterUsername" validate:"required"`
 	Limit             int32  `query:"limit" validate:"gte=0,lte=50"`
 	Offset            int32  `query:"offset" validate:"gte=0"`
+
+	MinRating              float64 `query:"minRating" validate:"omitempty,min=1,max=5"`
+	MaxRating              float64 `query:"maxRating" validate:"omitempty,min=1,max=5"`
+	DateFrom               string  `query:"dateFrom" validate:"omitempty,datetime=2006-01-02"`
+	DateTo                 string  `query:"dateTo" validate:"omitempty,datetime=2006-01-02"`
+	ReviewerOrganizationId string  `query:"reviewerOrganizationId" validate:"omitempty,uuid"`
+	Comparable             bool    `query:"comparable"`
 }
 
 func newGetReviewsOnBidAuthorBidsInput() getReviewsOnBidAuthorBidsInput {
@@ -566,25 +404,25 @@ func newGetReviewsOnBidAuthorBidsInput() getReviewsOnBidAuthorBidsInput {
 func (h *bidRoutesHandler) GetReviewsOnBidAuthorBids(c echo.Context) error {
 	var input = newGetReviewsOnBidAuthorBidsInput()
 	if err := c.Bind(&input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-			return e
-		}
-
-		return err
+		return errMalformedInput
 	}
 
 	input.TenderId = c.Param("tenderId")
 	if err := h.validate.Struct(input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{getAllErrorMessages(err)}); e != nil {
-			return e
-		}
-
-		return err
+		return newValidationError(err)
 	}
 
 	pg := entity.NewPaginationInput(int(input.Limit), int(input.Offset))
+	filter := &entity.ReviewFilter{
+		MinRating:              input.MinRating,
+		MaxRating:              input.MaxRating,
+		DateFrom:               input.DateFrom,
+		DateTo:                 input.DateTo,
+		ReviewerOrganizationId: input.ReviewerOrganizationId,
+		Comparable:             input.Comparable,
+	}
 	reviews, err := h.bidService.GetReviewsOnBidAuthorBids(c.Request().Context(),
-		input.TenderId, input.AuthorUsername, input.RequesterUsername, pg)
+		input.TenderId, input.AuthorUsername, input.RequesterUsername, filter, pg)
 	if err == nil {
 		if e := c.JSON(http.StatusOK, reviews); e != nil {
 			return e
@@ -593,29 +431,6 @@ func (h *bidRoutesHandler) GetReviewsOnBidAuthorBids(c echo.Context) error {
 		return nil
 	}
 
-	switch err {
-	case service.ErrBidAuthorNotAnEmployee:
-		if e := c.JSON(http.StatusNotFound, errorResponse{"There is no employee with given username for author of bid"}); e != nil {
-			return e
-		}
-	case service.ErrRequesterNotAnEmployee:
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"There is no employee with given username for review requester"}); e != nil {
-			return e
-		}
-	case service.ErrTenderNotFound:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"There is no tender with given id"}); e != nil {
-			return e
-		}
-	case service.ErrUserHasNoAccessToTender:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"You have no enough rights to access tender => tender bid => reviews on bid author"}); e != nil {
-			return e
-		}
-	default:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Error"}); e != nil {
-			return e
-		}
-	}
-
 	return err
 }
 
@@ -623,6 +438,10 @@ type submitBidFeedbackInput struct {
 	BidId    string `param:"bidId" validate:"required,max=100"`
 	Username string `query:"username" validate:"required"`
 	FeedBack string `query:"bidFeedback" validate:"required,max=1000"`
+
+	QualityRating       int `query:"quality" validate:"required,min=1,max=5"`
+	TimelinessRating    int `query:"timeliness" validate:"required,min=1,max=5"`
+	CommunicationRating int `query:"communication" validate:"required,min=1,max=5"`
 }
 
 // /bids/:bidId/feedback
@@ -631,49 +450,130 @@ func (h *bidRoutesHandler) SubmitBidFeedback(c echo.Context) error {
 	if err := c.Bind(&input); err != nil {
 		msg := err.Error()
 		if !strings.Contains(msg, "Request body can't be empty") {
-			if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-				return e
-			}
-
-			return err
+			return errMalformedInput
 		}
 	}
 
 	input.BidId, input.FeedBack, input.Username = c.Param("bidId"), c.QueryParam("bidFeedback"), c.QueryParam("username")
+	input.QualityRating, _ = strconv.Atoi(c.QueryParam("quality"))
+	input.TimelinessRating, _ = strconv.Atoi(c.QueryParam("timeliness"))
+	input.CommunicationRating, _ = strconv.Atoi(c.QueryParam("communication"))
 	if err := h.validate.Struct(input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{getAllErrorMessages(err)}); e != nil {
+		return newValidationError(err)
+	}
+
+	tender, err := h.bidService.SubmitBidFeedback(c.Request().Context(), input.Username, &entity.CreateReviewInput{
+		BidId:               input.BidId,
+		Description:         input.FeedBack,
+		QualityRating:       input.QualityRating,
+		TimelinessRating:    input.TimelinessRating,
+		CommunicationRating: input.CommunicationRating,
+	})
+	if err == nil {
+		if e := c.JSON(http.StatusOK, tender); e != nil {
 			return e
 		}
 
-		return err
+		return nil
+	}
+
+	return err
+}
+
+type withdrawBidInput struct {
+	BidId    string `param:"bidId" validate:"required,max=100"`
+	Username string `query:"username" validate:"required"`
+	Reason   string `query:"reason" validate:"required,max=1000"`
+}
+
+// /bids/:bidId/withdraw
+func (h *bidRoutesHandler) WithdrawBid(c echo.Context) error {
+	var input withdrawBidInput
+	if err := c.Bind(&input); err != nil {
+		msg := err.Error()
+		if !strings.Contains(msg, "Request body can't be empty") {
+			return errMalformedInput
+		}
+	}
+
+	input.BidId, input.Username, input.Reason = c.Param("bidId"), c.QueryParam("username"), c.QueryParam("reason")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
 	}
 
-	tender, err := h.bidService.SubmitBidFeedback(c.Request().Context(), input.BidId, input.Username, input.FeedBack)
+	bid, err := h.bidService.WithdrawBid(c.Request().Context(), input.BidId, input.Username, input.Reason)
 	if err == nil {
-		if e := c.JSON(http.StatusOK, tender); e != nil {
+		if e := c.JSON(http.StatusOK, bid); e != nil {
 			return e
 		}
 
 		return nil
 	}
 
-	switch err {
-	case service.ErrBidNotFound:
-		if e := c.JSON(http.StatusNotFound, errorResponse{"There is no bid with given id"}); e != nil {
-			return e
+	return err
+}
+
+type consentBidWithdrawalInput struct {
+	BidId    string `param:"bidId" validate:"required,max=100"`
+	Username string `query:"username" validate:"required"`
+}
+
+// /bids/:bidId/withdrawal_consent
+func (h *bidRoutesHandler) ConsentBidWithdrawal(c echo.Context) error {
+	var input consentBidWithdrawalInput
+	if err := c.Bind(&input); err != nil {
+		msg := err.Error()
+		if !strings.Contains(msg, "Request body can't be empty") {
+			return errMalformedInput
 		}
-	case service.ErrEmployeeNotFound:
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"There is no employee with given username"}); e != nil {
+	}
+
+	input.BidId, input.Username = c.Param("bidId"), c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	bid, err := h.bidService.ConsentBidWithdrawal(c.Request().Context(), input.BidId, input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, bid); e != nil {
 			return e
 		}
-	case service.ErrUserHasNoAccessToBid:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"You have no enough rights to sumbit feedback to bid"}); e != nil {
-			return e
+
+		return nil
+	}
+
+	return err
+}
+
+type resubmitBidInput struct {
+	BidId       string `param:"bidId" validate:"required,max=100"`
+	Username    string `query:"username" validate:"required"`
+	Name        string `json:"name" validate:"max=100"`
+	Description string `json:"description" validate:"max=500"`
+}
+
+// /bids/:bidId/resubmit
+func (h *bidRoutesHandler) ResubmitBid(c echo.Context) error {
+	var input resubmitBidInput
+	if err := c.Bind(&input); err != nil {
+		msg := err.Error()
+		if !strings.Contains(msg, "Request body can't be empty") {
+			return errMalformedInput
 		}
-	default:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Error"}); e != nil {
+	}
+
+	input.BidId, input.Username = c.Param("bidId"), c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	bid, err := h.bidService.ResubmitBid(c.Request().Context(), input.BidId, input.Username, input.Name, input.Description)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, bid); e != nil {
 			return e
 		}
+
+		return nil
 	}
 
 	return err
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strings"
 
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -14,61 +15,107 @@ const (
 	defaultUsername = ""
 )
 
-type errorResponse struct {
-	Reason string `json:"reason"`
+// In и Pointer заполняются при проверке по openapi.yml: где передано значение и путь к нему в теле
+type fieldError struct {
+	Field   string `json:"field"`
+	In      string `json:"in,omitempty"`
+	Pointer string `json:"pointer,omitempty"`
+	Message string `json:"message"`
 }
 
-func getAllErrorMessages(err error) string {
-	var builder strings.Builder
+func getFieldErrors(trans ut.Translator, err error) []fieldError {
+	fields := make([]fieldError, 0)
 	for _, fe := range err.(validator.ValidationErrors) {
-		message := fmt.Sprintf("'%s': %s\n", fe.Field(), getMessage(fe))
+		fields = append(fields, fieldError{Field: fe.Field(), Message: getMessage(trans, fe)})
+	}
+
+	return fields
+}
+
+func getAllErrorMessages(trans ut.Translator, err error) string {
+	var builder strings.Builder
+	for _, fe := range getFieldErrors(trans, err) {
+		message := fmt.Sprintf("'%s': %s\n", fe.Field, fe.Message)
 		builder.WriteString(message)
 	}
 
 	return builder.String()
 }
 
-func getMessage(fe validator.FieldError) string {
+func getMessage(trans ut.Translator, fe validator.FieldError) string {
 	s, i := "", int32(0)
 	if fe.Type() == reflect.TypeOf(s) {
-		return getMessageForString(fe)
+		return getMessageForString(trans, fe)
 	}
 
 	if fe.Type() == reflect.TypeOf(i) {
-		return getMessageForInt(fe)
+		return getMessageForInt(trans, fe)
 	}
 
 	if fe.Type() == reflect.TypeOf(0) {
-		return getMessageForInt(fe)
+		return getMessageForInt(trans, fe)
+	}
+
+	if fe.Type() == reflect.TypeOf(0.0) {
+		return getMessageForInt(trans, fe)
+	}
+
+	if fe.Kind() == reflect.Slice || fe.Kind() == reflect.Map {
+		return getMessageForCollection(trans, fe)
+	}
+
+	return translate(trans, "unknown_error", "Unknown error (2)")
+}
+
+func getMessageForCollection(trans ut.Translator, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "lte", "max":
+		return translate(trans, "collection_max", "should contain no more than "+fe.Param()+" elements", fe.Param())
+	case "gte", "min":
+		return translate(trans, "collection_min", "should contain at least "+fe.Param()+" elements", fe.Param())
 	}
 
-	return "Unknown error (2)"
+	return translate(trans, "incorrect_value", "incorrect value passed")
 }
 
-func getMessageForInt(fe validator.FieldError) string {
+func getMessageForInt(trans ut.Translator, fe validator.FieldError) string {
 	switch fe.Tag() {
 	case "required":
-		return "this field is required"
+		return translate(trans, "required", "this field is required")
 	case "lte", "max":
-		return "should be less or equal than " + fe.Param()
+		return translate(trans, "number_max", "should be less or equal than "+fe.Param(), fe.Param())
 	case "gte", "min":
-		return "should be greater or equal than " + fe.Param()
+		return translate(trans, "number_min", "should be greater or equal than "+fe.Param(), fe.Param())
 	}
 
-	return "incorrect value passed"
+	return translate(trans, "incorrect_value", "incorrect value passed")
 }
 
-func getMessageForString(fe validator.FieldError) string {
+func getMessageForString(trans ut.Translator, fe validator.FieldError) string {
 	switch fe.Tag() {
 	case "required":
-		return "this field is required"
+		return translate(trans, "required", "this field is required")
 	case "lte", "max":
-		return "length should be less or equal than " + fe.Param()
+		return translate(trans, "string_max", "length should be less or equal than "+fe.Param(), fe.Param())
 	case "gte", "min":
-		return "length should be greater or equal than " + fe.Param()
+		return translate(trans, "string_min", "length should be greater or equal than "+fe.Param(), fe.Param())
 	case "oneof":
-		return "should have value in: " + fe.Param()
+		return translate(trans, "oneof", "should have value in: "+fe.Param(), fe.Param())
+	case "datetime":
+		return translate(trans, "datetime", "should be a date in format "+fe.Param(), fe.Param())
+	case "len":
+		return translate(trans, "string_len", "length should be equal to "+fe.Param(), fe.Param())
+	case "hexadecimal":
+		return translate(trans, "hexadecimal", "should be a hexadecimal string")
+	case "alphanum":
+		return translate(trans, "alphanum", "should contain only latin letters and digits")
+	case "uuid":
+		return translate(trans, "uuid", "should be a valid uuid")
+	case "contains":
+		return translate(trans, "contains", "should contain '"+fe.Param()+"'", fe.Param())
+	case "base64":
+		return translate(trans, "base64", "should be a base64 encoded string")
 	}
 
-	return "incorrect value passed"
+	return translate(trans, "incorrect_value", "incorrect value passed")
 }
@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo"
+)
+
+type contractRoutesHandler struct {
+	contractService service.Contracts
+	validate        *validator.Validate
+}
+
+func newContractRoutesHandler(outer *echo.Group, services *service.Services, v *validator.Validate) *contractRoutesHandler {
+	h := &contractRoutesHandler{contractService: services.Contracts, validate: v}
+
+	outer.GET("/contracts/my", h.GetUserContracts)
+	outer.GET("/contracts/:contractId", h.GetContract)
+	outer.PUT("/contracts/:contractId/edit", h.EditContract)
+	outer.PUT("/contracts/:contractId/sign", h.SignContract)
+	outer.PUT("/contracts/:contractId/status", h.UpdateContractStatus)
+
+	return h
+}
+
+type getUserContractsInput struct {
+	Username string `query:"username" validate:"required"`
+	TenderId string `query:"tenderId" validate:"omitempty,uuid"`
+	Status   string `query:"status" validate:"omitempty,oneof=Draft Signed InProgress Completed Terminated"`
+	Limit    int32  `query:"limit" validate:"gte=0,lte=50"`
+	Offset   int32  `query:"offset" validate:"gte=0"`
+	// без организации возвращаются и личные контракты, и контракты всех организаций сотрудника
+	OrganizationId string `query:"organizationId" validate:"omitempty,uuid"`
+}
+
+func newGetUserContractsInput() getUserContractsInput {
+	return getUserContractsInput{Limit: defaultLimit, Offset: defaultOffset}
+}
+
+// /contracts/my
+func (h *contractRoutesHandler) GetUserContracts(c echo.Context) error {
+	var input = newGetUserContractsInput()
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	pg := entity.NewPaginationInput(int(input.Limit), int(input.Offset))
+	filter := &entity.ContractFilter{TenderId: input.TenderId, Status: input.Status, OrganizationId: input.OrganizationId}
+	contracts, err := h.contractService.GetUserContracts(c.Request().Context(), input.Username, filter, pg)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, contracts); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type getContractInput struct {
+	ContractId string `param:"contractId" validate:"required,max=100"`
+	Username   string `query:"username" validate:"required"`
+}
+
+// /contracts/:contractId
+func (h *contractRoutesHandler) GetContract(c echo.Context) error {
+	var input getContractInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.ContractId = c.Param("contractId")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	contract, err := h.contractService.GetContract(c.Request().Context(), input.ContractId, input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, contract); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type editContractInput struct {
+	ContractId string  `param:"contractId" validate:"required,max=100"`
+	Username   string  `query:"username" validate:"required"`
+	Price      float64 `json:"price" validate:"required,gt=0"`
+	StartDate  string  `json:"startDate" validate:"required,datetime=2006-01-02"`
+	EndDate    string  `json:"endDate" validate:"required,datetime=2006-01-02"`
+}
+
+// /contracts/:contractId/edit
+func (h *contractRoutesHandler) EditContract(c echo.Context) error {
+	var input editContractInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.ContractId, input.Username = c.Param("contractId"), c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	contract, err := h.contractService.EditContract(c.Request().Context(), input.ContractId, input.Username, &entity.EditContractInput{
+		Price:     input.Price,
+		StartDate: input.StartDate,
+		EndDate:   input.EndDate,
+	})
+	if err == nil {
+		if e := c.JSON(http.StatusOK, contract); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type signContractInput struct {
+	ContractId string `param:"contractId" validate:"required,max=100"`
+	Username   string `query:"username" validate:"required"`
+}
+
+// /contracts/:contractId/sign
+func (h *contractRoutesHandler) SignContract(c echo.Context) error {
+	var input signContractInput
+	if err := c.Bind(&input); err != nil {
+		msg := err.Error()
+		if !strings.Contains(msg, "Request body can't be empty") {
+			return errMalformedInput
+		}
+	}
+
+	input.ContractId, input.Username = c.Param("contractId"), c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	contract, err := h.contractService.SignContract(c.Request().Context(), input.ContractId, input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, contract); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type updateContractStatusInput struct {
+	ContractId string `param:"contractId" validate:"required,max=100"`
+	Username   string `query:"username" validate:"required"`
+	Status     string `query:"status" validate:"required,oneof=InProgress Completed Terminated"`
+	Reason     string `query:"reason" validate:"max=1000"`
+}
+
+// /contracts/:contractId/status
+func (h *contractRoutesHandler) UpdateContractStatus(c echo.Context) error {
+	var input updateContractStatusInput
+	if err := c.Bind(&input); err != nil {
+		msg := err.Error()
+		if !strings.Contains(msg, "Request body can't be empty") {
+			return errMalformedInput
+		}
+	}
+
+	input.ContractId, input.Username = c.Param("contractId"), c.QueryParam("username")
+	input.Status, input.Reason = c.QueryParam("status"), c.QueryParam("reason")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	contract, err := h.contractService.UpdateContractStatus(c.Request().Context(), input.ContractId, input.Username, input.Status, input.Reason)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, contract); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	"tender-management-api/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo"
+)
+
+type delegationRoutesHandler struct {
+	delegationService service.Delegations
+	validate          *validator.Validate
+}
+
+func newDelegationRoutesHandler(outer *echo.Group, services *service.Services, v *validator.Validate) *delegationRoutesHandler {
+	h := &delegationRoutesHandler{delegationService: services.Delegations, validate: v}
+
+	outer.GET("/delegations", h.GetApprovalDelegations)
+	outer.POST("/delegations/new", h.PostApprovalDelegation)
+	outer.DELETE("/delegations/:delegationId", h.DeleteApprovalDelegation)
+
+	return h
+}
+
+type getApprovalDelegationsInput struct {
+	Username string `query:"username" validate:"required"`
+}
+
+// /delegations
+func (h *delegationRoutesHandler) GetApprovalDelegations(c echo.Context) error {
+	var input getApprovalDelegationsInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	delegations, err := h.delegationService.GetApprovalDelegations(c.Request().Context(), input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, delegations); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type postApprovalDelegationInput struct {
+	Username         string `query:"username" validate:"required"`
+	OrganizationId   string `json:"organizationId" validate:"required,uuid"`
+	DelegateUsername string `json:"delegateUsername" validate:"required"`
+	StartsOn         string `json:"startsOn" validate:"required,datetime=2006-01-02"`
+	EndsOn           string `json:"endsOn" validate:"required,datetime=2006-01-02"`
+}
+
+// /delegations/new
+func (h *delegationRoutesHandler) PostApprovalDelegation(c echo.Context) error {
+	var input postApprovalDelegationInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.Username = c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	delegation, err := h.delegationService.CreateApprovalDelegation(c.Request().Context(), input.Username,
+		input.OrganizationId, input.DelegateUsername, input.StartsOn, input.EndsOn)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, delegation); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type deleteApprovalDelegationInput struct {
+	DelegationId string `param:"delegationId" validate:"required,uuid"`
+	Username     string `query:"username" validate:"required"`
+}
+
+// /delegations/:delegationId
+func (h *delegationRoutesHandler) DeleteApprovalDelegation(c echo.Context) error {
+	var input deleteApprovalDelegationInput
+	if err := c.Bind(&input); err != nil {
+		msg := err.Error()
+		if !strings.Contains(msg, "Request body can't be empty") {
+			return errMalformedInput
+		}
+	}
+
+	input.DelegationId = c.Param("delegationId")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	err := h.delegationService.DeleteApprovalDelegation(c.Request().Context(), input.DelegationId, input.Username)
+	if err == nil {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	return err
+}
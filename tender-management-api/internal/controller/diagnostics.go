@@ -27,7 +27,7 @@ func (h *diagnosticRoutesHandler) Ping(c echo.Context) error {
 
 		return err
 	}
-	if e := c.JSON(http.StatusOK, "ok"); e != nil {
+	if e := c.String(http.StatusOK, "ok"); e != nil {
 		return e
 	}
 
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"mime"
+	"net/http"
+	"path"
+	"tender-management-api/api"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo"
+)
+
+type docsRoutesHandler struct {
+	doc     *openapi3.T
+	version string
+}
+
+func newDocsRoutesHandler(outer *echo.Group, doc *openapi3.T) *docsRoutesHandler {
+	h := &docsRoutesHandler{doc: doc, version: api.ResolveVersion(doc.Info.Version)}
+	outer.GET("/openapi.json", h.GetOpenAPI)
+	outer.GET("/docs", h.GetDocs)
+	outer.GET("/docs/*", h.GetDocsAsset)
+
+	return h
+}
+
+// /openapi.json
+func (h *docsRoutesHandler) GetOpenAPI(c echo.Context) error {
+	// общий документ не меняем: им же проверяются запросы
+	spec := *h.doc
+	info := *h.doc.Info
+	info.Version = h.version
+	spec.Info = &info
+	// адрес берется из запроса, чтобы "Try it out" работал и за прокси, и на локальной машине
+	spec.Servers = openapi3.Servers{{URL: c.Scheme() + "://" + c.Request().Host + apiPrefix}}
+
+	return c.JSON(http.StatusOK, &spec)
+}
+
+// /docs
+func (h *docsRoutesHandler) GetDocs(c echo.Context) error {
+	page, err := api.Docs.ReadFile("docs/index.html")
+	if err != nil {
+		return err
+	}
+
+	return c.HTMLBlob(http.StatusOK, page)
+}
+
+// /docs/*
+func (h *docsRoutesHandler) GetDocsAsset(c echo.Context) error {
+	name := c.Param("*")
+	if name == "" {
+		return h.GetDocs(c)
+	}
+
+	// в api.Docs нет ничего, кроме docs, поэтому путь с ".." ничего лишнего не откроет
+	asset, err := api.Docs.ReadFile(path.Join("docs", name))
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	return c.Blob(http.StatusOK, mime.TypeByExtension(path.Ext(name)), asset)
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/service"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo"
+)
+
+const sseHeartbeatInterval = 15 * time.Second
+
+type eventRoutesHandler struct {
+	eventService service.Events
+	validate     *validator.Validate
+}
+
+func newEventRoutesHandler(outer *echo.Group, services *service.Services, v *validator.Validate) *eventRoutesHandler {
+	h := &eventRoutesHandler{eventService: services.Events, validate: v}
+	outer.GET("/events/stream", h.StreamEvents)
+
+	return h
+}
+
+type streamEventsInput struct {
+	Username     string   `query:"username" validate:"required"`
+	ServiceTypes []string `query:"service_type" validate:"dive,max=50"`
+	LastEventId  int64    `validate:"gte=0"`
+}
+
+func newStreamEventsInput() streamEventsInput {
+	return streamEventsInput{Username: defaultUsername, ServiceTypes: make([]string, 0)}
+}
+
+// /events/stream
+func (h *eventRoutesHandler) StreamEvents(c echo.Context) error {
+	var input = newStreamEventsInput()
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	if lastEventId := c.Request().Header.Get("Last-Event-ID"); lastEventId != "" {
+		id, err := strconv.ParseInt(lastEventId, 10, 64)
+		if err != nil {
+			return errInvalidLastEventId
+		}
+		input.LastEventId = id
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	events, err := h.eventService.Subscribe(c.Request().Context(), input.Username, input.ServiceTypes, input.LastEventId)
+	if err != nil {
+		return err
+	}
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, "text/event-stream")
+	response.Header().Set("Cache-Control", "no-cache")
+	response.Header().Set("Connection", "keep-alive")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	// поток завершается, когда клиент отключается или сервер останавливается
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := writeServerSentEvent(response, &event); err != nil {
+				return err
+			}
+		case <-heartbeat.C:
+			if _, err := response.Write([]byte(": heartbeat\n\n")); err != nil {
+				return err
+			}
+			response.Flush()
+		}
+	}
+}
+
+func writeServerSentEvent(response *echo.Response, event *entity.EventOutputModel) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(response, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data); err != nil {
+		return err
+	}
+	response.Flush()
+
+	return nil
+}
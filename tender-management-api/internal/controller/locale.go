@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"bytes"
+	"embed"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ru"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/labstack/echo"
+)
+
+//go:embed locales/*.json
+var localeCatalogs embed.FS
+
+// Каталоги встроены в бинарник, поэтому ошибка в них обнаружится при первом же запуске
+var translations = mustLoadTranslations()
+
+// Английский каталог содержит только сообщения валидации: английский текст ошибок сервисов
+// хранится в самих ошибках и используется, если перевода для кода нет
+func mustLoadTranslations() *ut.UniversalTranslator {
+	uni := ut.New(en.New(), en.New(), ru.New())
+	for _, name := range []string{"locales/en.json", "locales/ru.json"} {
+		catalog, err := localeCatalogs.ReadFile(name)
+		if err != nil {
+			panic("controller: read " + name + ": " + err.Error())
+		}
+		if err := uni.ImportByReader(ut.FormatJSON, bytes.NewReader(catalog)); err != nil {
+			panic("controller: import " + name + ": " + err.Error())
+		}
+	}
+
+	return uni
+}
+
+// Языки упорядочиваются по весу q, при равном весе сохраняется порядок из заголовка.
+// Региональные варианты (ru-RU) сводятся к основному языку
+func parseAcceptLanguage(header string) []string {
+	type weightedLanguage struct {
+		language string
+		weight   float64
+	}
+
+	weighted := make([]weightedLanguage, 0)
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		// вес без параметра или с неразборчивым значением считается максимальным
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				weight = parsed
+			}
+		}
+		// q=0 означает, что язык клиенту не подходит
+		if weight <= 0 {
+			continue
+		}
+
+		language, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+		if language = strings.ToLower(language); language != "" && language != "*" {
+			weighted = append(weighted, weightedLanguage{language: language, weight: weight})
+		}
+	}
+
+	sort.SliceStable(weighted, func(i, j int) bool {
+		return weighted[i].weight > weighted[j].weight
+	})
+
+	languages := make([]string, 0, len(weighted))
+	for _, w := range weighted {
+		languages = append(languages, w.language)
+	}
+
+	return languages
+}
+
+func requestTranslator(c echo.Context) ut.Translator {
+	trans, _ := translations.FindTranslator(parseAcceptLanguage(c.Request().Header.Get("Accept-Language"))...)
+
+	return trans
+}
+
+func translate(trans ut.Translator, key, fallback string, params ...string) string {
+	message, err := trans.T(key, params...)
+	if err != nil {
+		return fallback
+	}
+
+	return message
+}
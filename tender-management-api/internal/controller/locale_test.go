@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"slices"
+	"tender-management-api/internal/service"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	cases := []struct {
+		header string
+		want   []string
+	}{
+		{"", []string{}},
+		{"ru", []string{"ru"}},
+		{"en;q=0.1, ru;q=0.9", []string{"ru", "en"}},
+		{"ru-RU,en;q=0.8", []string{"ru", "en"}},
+		{"en-US;q=0.5, ru;q=0.5", []string{"en", "ru"}},
+		{"fr;q=0, en", []string{"en"}},
+		{"*, ru;q=0.3", []string{"ru"}},
+		{"en;q=abc, ru;q=0.9", []string{"en", "ru"}},
+	}
+	for _, c := range cases {
+		if got := parseAcceptLanguage(c.header); !slices.Equal(got, c.want) {
+			t.Errorf("parseAcceptLanguage(%q) = %v, want %v", c.header, got, c.want)
+		}
+	}
+}
+
+type localeInput struct {
+	Name string `validate:"required"`
+}
+
+func TestProblemTranslation(t *testing.T) {
+	cases := []struct {
+		header   string
+		notFound string
+		required string
+	}{
+		{"en;q=0.1, ru;q=0.9", "Тендер с таким id не найден", "обязательное поле"},
+		{"ru;q=0.2, en", "There is no tender with given id", "this field is required"},
+		{"fr", "There is no tender with given id", "this field is required"},
+	}
+	for _, c := range cases {
+		trans, _ := translations.FindTranslator(parseAcceptLanguage(c.header)...)
+
+		if got := newProblem(trans, service.ErrTenderNotFound).Detail; got != c.notFound {
+			t.Errorf("%q: service error detail = %q, want %q", c.header, got, c.notFound)
+		}
+
+		p := newProblem(trans, newValidationError(validator.New().Struct(&localeInput{})))
+		if len(p.Errors) != 1 || p.Errors[0].Message != c.required {
+			t.Errorf("%q: field errors = %+v, want message %q", c.header, p.Errors, c.required)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo"
+)
+
+type notificationRoutesHandler struct {
+	notificationService service.Notifications
+	validate            *validator.Validate
+}
+
+func newNotificationRoutesHandler(outer *echo.Group, services *service.Services, v *validator.Validate) *notificationRoutesHandler {
+	h := &notificationRoutesHandler{notificationService: services.Notifications, validate: v}
+	outer.GET("/notifications", h.GetNotifications)
+	outer.PUT("/notifications/read_all", h.MarkAllNotificationsRead)
+	outer.PUT("/notifications/:notificationId/read", h.MarkNotificationRead)
+
+	outer.GET("/notifications/preferences", h.GetNotificationPreferences)
+	outer.PUT("/notifications/preferences", h.SetNotificationPreference)
+
+	return h
+}
+
+type getNotificationsInput struct {
+	Username   string `query:"username" validate:"required"`
+	UnreadOnly bool   `query:"unreadOnly"`
+	Limit      int32  `query:"limit" validate:"gte=0,lte=50"`
+	Offset     int32  `query:"offset" validate:"gte=0"`
+}
+
+func newGetNotificationsInput() getNotificationsInput {
+	return getNotificationsInput{Limit: defaultLimit, Offset: defaultOffset, Username: defaultUsername}
+}
+
+// /notifications
+func (h *notificationRoutesHandler) GetNotifications(c echo.Context) error {
+	var input = newGetNotificationsInput()
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	pg := entity.NewPaginationInput(int(input.Limit), int(input.Offset))
+	notifications, err := h.notificationService.GetNotifications(c.Request().Context(), input.Username, input.UnreadOnly, pg)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, notifications); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type markNotificationReadInput struct {
+	NotificationId string `param:"notificationId" validate:"required,max=100"`
+	Username       string `query:"username" validate:"required"`
+}
+
+// /notifications/:notificationId/read
+func (h *notificationRoutesHandler) MarkNotificationRead(c echo.Context) error {
+	var input markNotificationReadInput
+	if err := c.Bind(&input); err != nil {
+		msg := err.Error()
+		if !strings.Contains(msg, "Request body can't be empty") {
+			return errMalformedInput
+		}
+	}
+
+	input.NotificationId, input.Username = c.Param("notificationId"), c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	notification, err := h.notificationService.MarkNotificationRead(c.Request().Context(), input.NotificationId, input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, notification); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type markAllNotificationsReadInput struct {
+	Username string `query:"username" validate:"required"`
+}
+
+// /notifications/read_all
+func (h *notificationRoutesHandler) MarkAllNotificationsRead(c echo.Context) error {
+	var input markAllNotificationsReadInput
+	if err := c.Bind(&input); err != nil {
+		msg := err.Error()
+		if !strings.Contains(msg, "Request body can't be empty") {
+			return errMalformedInput
+		}
+	}
+
+	input.Username = c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	notifications, err := h.notificationService.MarkAllNotificationsRead(c.Request().Context(), input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, notifications); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type getNotificationPreferencesInput struct {
+	Username string `query:"username" validate:"required"`
+}
+
+// /notifications/preferences
+func (h *notificationRoutesHandler) GetNotificationPreferences(c echo.Context) error {
+	var input getNotificationPreferencesInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	preferences, err := h.notificationService.GetNotificationPreferences(c.Request().Context(), input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, preferences); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type setNotificationPreferenceInput struct {
+	Username  string `query:"username" validate:"required"`
+	EventType string `query:"eventType" validate:"required,oneof=BidDecisionChanged BidFeedbackSubmitted BidStatusChanged"`
+	Enabled   string `query:"enabled" validate:"required,oneof=true false"`
+}
+
+// /notifications/preferences
+func (h *notificationRoutesHandler) SetNotificationPreference(c echo.Context) error {
+	var input setNotificationPreferenceInput
+	if err := c.Bind(&input); err != nil {
+		msg := err.Error()
+		if !strings.Contains(msg, "Request body can't be empty") {
+			return errMalformedInput
+		}
+	}
+
+	input.Username, input.EventType, input.Enabled = c.QueryParam("username"), c.QueryParam("eventType"), c.QueryParam("enabled")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	preferences, err := h.notificationService.SetNotificationPreference(c.Request().Context(), input.Username, input.EventType, input.Enabled == "true")
+	if err == nil {
+		if e := c.JSON(http.StatusOK, preferences); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
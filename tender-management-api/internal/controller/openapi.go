@@ -0,0 +1,449 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"tender-management-api/api"
+	"tender-management-api/internal/service"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/labstack/echo"
+)
+
+const apiPrefix = "/api"
+
+var errResponseContractViolation = service.NewError("response_contract_violation", http.StatusInternalServerError, "Response does not match the API contract")
+
+func init() {
+	openapi3.DefineStringFormatValidator("uuid", openapi3.NewRegexpFormatValidator(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`))
+	// без этого в каждую ошибку (и в лог) попадает схема целиком
+	openapi3.SchemaErrorDetailsDisabled = true
+}
+
+func loadOpenAPI() (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(api.OpenAPI)
+	if err != nil {
+		return nil, fmt.Errorf("load openapi.yml: %w", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("validate openapi.yml: %w", err)
+	}
+
+	return doc, nil
+}
+
+// /api/tenders/:tenderId/status -> /tenders/{tenderId}/status
+func specPath(routePath string) string {
+	segments := strings.Split(strings.TrimPrefix(routePath, apiPrefix), "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// Проверяет запросы по openapi.yml до обработчиков, а с validateResponses - и ответы, включая ответы с ошибками.
+// Маршруты, которых нет в контракте (например, обработчик 404 группы), пропускаются
+func openAPIMiddleware(doc *openapi3.T, validateResponses bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			pathItem := doc.Paths.Value(specPath(c.Path()))
+			if pathItem == nil || pathItem.GetOperation(c.Request().Method) == nil {
+				return next(c)
+			}
+
+			pathParams := make(map[string]string, len(c.ParamNames()))
+			for i, name := range c.ParamNames() {
+				pathParams[name] = c.ParamValues()[i]
+			}
+			input := &openapi3filter.RequestValidationInput{
+				Request:    c.Request(),
+				PathParams: pathParams,
+				Route: &routers.Route{
+					Spec:      doc,
+					Path:      specPath(c.Path()),
+					PathItem:  pathItem,
+					Method:    c.Request().Method,
+					Operation: pathItem.GetOperation(c.Request().Method),
+				},
+				Options: &openapi3filter.Options{
+					MultiError:          true,
+					SkipSettingDefaults: true,
+					// файлы до 20 МБ не читаем в память ради проверки, наличие файла проверяет обработчик
+					ExcludeRequestBody: strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm),
+					AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+				},
+			}
+			if err := openapi3filter.ValidateRequest(c.Request().Context(), input); err != nil {
+				return newSpecRequestError(err)
+			}
+
+			if !validateResponses || streamsResponse(input.Route.Operation) {
+				return next(c)
+			}
+
+			return validateResponse(c, next, input)
+		}
+	}
+}
+
+// Поток событий не закончится, пока клиент не отключится, поэтому его не буферизуем
+func streamsResponse(operation *openapi3.Operation) bool {
+	for _, response := range operation.Responses.Map() {
+		if response.Value != nil && response.Value.Content.Get("text/event-stream") != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Ответ собирается в буфер и отправляется клиенту, только если соответствует контракту,
+// иначе клиент получает 500 с перечнем расхождений
+func validateResponse(c echo.Context, next echo.HandlerFunc, input *openapi3filter.RequestValidationInput) error {
+	response := c.Response()
+	writer := response.Writer
+	header := response.Header().Clone()
+	recorder := &responseRecorder{writer: writer, status: http.StatusOK}
+	response.Writer = recorder
+	if err := next(c); err != nil {
+		c.Error(err)
+	}
+	response.Writer = writer
+
+	responseInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 recorder.status,
+		Header:                 response.Header(),
+		Options:                &openapi3filter.Options{MultiError: true},
+	}
+	err := openapi3filter.ValidateResponse(c.Request().Context(), responseInput.SetBodyBytes(recorder.body.Bytes()))
+	if err == nil {
+		writer.WriteHeader(recorder.status)
+		_, err = writer.Write(recorder.body.Bytes())
+
+		return err
+	}
+
+	for key := range response.Header() {
+		delete(response.Header(), key)
+	}
+	for key, values := range header {
+		response.Header()[key] = values
+	}
+	response.Committed = false
+	response.Status = http.StatusOK
+	response.Size = 0
+
+	return &specValidationError{status: errResponseContractViolation.Status, violations: collectSpecViolations(err), err: err}
+}
+
+type responseRecorder struct {
+	writer http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.writer.Header()
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+// Ответ все равно уйдет целиком после проверки
+func (r *responseRecorder) Flush() {}
+
+// specViolation - расхождение с контрактом. Сообщение переводится при формировании ответа, когда известен язык клиента
+type specViolation struct {
+	field    string
+	in       string
+	pointer  string
+	key      string
+	fallback string
+	params   []string
+}
+
+type specValidationError struct {
+	status     int
+	violations []specViolation
+	err        error
+}
+
+func (e *specValidationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *specValidationError) Unwrap() error {
+	return e.err
+}
+
+func (e *specValidationError) fieldErrors(trans ut.Translator) []fieldError {
+	fields := make([]fieldError, 0, len(e.violations))
+	for _, v := range e.violations {
+		message := translate(trans, v.key, v.fallback, v.params...)
+		fields = append(fields, fieldError{Field: v.field, In: v.in, Pointer: v.pointer, Message: message})
+	}
+
+	return fields
+}
+
+// Тело, которое не удалось разобрать, или неподходящий Content-Type - не ошибки валидации полей,
+// для них остаются прежние коды ответа
+func newSpecRequestError(err error) error {
+	var requestErrors []*openapi3filter.RequestError
+	walkSpecErrors(err, func(e error) {
+		if requestErr, ok := e.(*openapi3filter.RequestError); ok && requestErr.RequestBody != nil {
+			requestErrors = append(requestErrors, requestErr)
+		}
+	})
+	for _, requestErr := range requestErrors {
+		var parseErr *openapi3filter.ParseError
+		if requestErr.Err == nil {
+			return echo.ErrUnsupportedMediaType
+		}
+		if errors.As(requestErr.Err, &parseErr) {
+			return errMalformedInput
+		}
+	}
+
+	return &specValidationError{status: http.StatusBadRequest, violations: collectSpecViolations(err), err: err}
+}
+
+func walkSpecErrors(err error, visit func(error)) {
+	if multiErr, ok := err.(openapi3.MultiError); ok {
+		for _, e := range multiErr {
+			walkSpecErrors(e, visit)
+		}
+
+		return
+	}
+	visit(err)
+}
+
+func collectSpecViolations(err error) []specViolation {
+	violations := make([]specViolation, 0)
+	var collect func(err error, field, in string)
+	collect = func(err error, field, in string) {
+		var requestErr *openapi3filter.RequestError
+		var responseErr *openapi3filter.ResponseError
+		var schemaErr *openapi3.SchemaError
+		var parseErr *openapi3filter.ParseError
+		switch {
+		case isMultiError(err):
+			for _, e := range err.(openapi3.MultiError) {
+				collect(e, field, in)
+			}
+		case errors.As(err, &requestErr):
+			field, in = "body", "body"
+			if requestErr.Parameter != nil {
+				field, in = requestErr.Parameter.Name, requestErr.Parameter.In
+			}
+			if requestErr.Err == nil {
+				violations = append(violations, specViolation{field: field, in: in, key: "incorrect_value", fallback: "incorrect value passed"})
+
+				return
+			}
+			collect(requestErr.Err, field, in)
+		case errors.As(err, &responseErr):
+			field, in = "body", "body"
+			if responseErr.Err == nil {
+				violations = append(violations, specViolation{field: field, in: in, key: "incorrect_value", fallback: responseErr.Reason})
+
+				return
+			}
+			collect(responseErr.Err, field, in)
+		case errors.As(err, &schemaErr):
+			violation := schemaViolation(schemaErr)
+			violation.field, violation.in = field, in
+			if pointer := schemaErr.JSONPointer(); len(pointer) > 0 {
+				violation.pointer = jsonPointer(pointer)
+				if in == "body" {
+					violation.field = pointerField(pointer)
+				}
+			}
+			violations = append(violations, violation)
+		case errors.As(err, &parseErr):
+			violation := specViolation{field: field, in: in, key: "incorrect_value", fallback: "incorrect value passed"}
+			if path := parseErr.Path(); len(path) > 0 {
+				pointer := make([]string, 0, len(path))
+				for _, p := range path {
+					pointer = append(pointer, fmt.Sprint(p))
+				}
+				violation.pointer = jsonPointer(pointer)
+			}
+			violations = append(violations, violation)
+		case errors.Is(err, openapi3filter.ErrInvalidRequired), errors.Is(err, openapi3filter.ErrInvalidEmptyValue):
+			violations = append(violations, specViolation{field: field, in: in, key: "required", fallback: "this field is required"})
+		default:
+			violations = append(violations, specViolation{field: field, in: in, key: "incorrect_value", fallback: "incorrect value passed"})
+		}
+	}
+	collect(err, "", "")
+
+	return violations
+}
+
+// errors.As находит MultiError и внутри других ошибок, а разворачивать нужно только саму MultiError
+func isMultiError(err error) bool {
+	_, ok := err.(openapi3.MultiError)
+
+	return ok
+}
+
+// Для элемента массива (/tags/0) полем считается сам массив
+func pointerField(tokens []string) string {
+	for i := len(tokens) - 1; i >= 0; i-- {
+		if _, err := strconv.Atoi(tokens[i]); err != nil {
+			return tokens[i]
+		}
+	}
+
+	return "body"
+}
+
+// RFC 6901: ~ и / внутри ключей экранируются
+func jsonPointer(tokens []string) string {
+	var builder strings.Builder
+	for _, token := range tokens {
+		builder.WriteString("/")
+		builder.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+
+	return builder.String()
+}
+
+// Сообщения те же, что и у проверок тегов validate, чтобы клиенты видели одинаковые тексты
+func schemaViolation(err *openapi3.SchemaError) specViolation {
+	schema := err.Schema
+	switch err.SchemaField {
+	case "required":
+		return specViolation{key: "required", fallback: "this field is required"}
+	case "maxLength":
+		limit := strconv.FormatUint(*schema.MaxLength, 10)
+
+		return specViolation{key: "string_max", fallback: "length should be less or equal than " + limit, params: []string{limit}}
+	case "minLength":
+		limit := strconv.FormatUint(schema.MinLength, 10)
+
+		return specViolation{key: "string_min", fallback: "length should be greater or equal than " + limit, params: []string{limit}}
+	case "maximum":
+		limit := strconv.FormatFloat(*schema.Max, 'f', -1, 64)
+
+		return specViolation{key: "number_max", fallback: "should be less or equal than " + limit, params: []string{limit}}
+	case "minimum":
+		limit := strconv.FormatFloat(*schema.Min, 'f', -1, 64)
+
+		return specViolation{key: "number_min", fallback: "should be greater or equal than " + limit, params: []string{limit}}
+	case "exclusiveMaximum":
+		limit := strconv.FormatFloat(*schema.Max, 'f', -1, 64)
+
+		return specViolation{key: "number_lt", fallback: "should be less than " + limit, params: []string{limit}}
+	case "exclusiveMinimum":
+		limit := strconv.FormatFloat(*schema.Min, 'f', -1, 64)
+
+		return specViolation{key: "number_gt", fallback: "should be greater than " + limit, params: []string{limit}}
+	case "maxItems":
+		limit := strconv.FormatUint(*schema.MaxItems, 10)
+
+		return specViolation{key: "collection_max", fallback: "should contain no more than " + limit + " elements", params: []string{limit}}
+	case "minItems":
+		limit := strconv.FormatUint(schema.MinItems, 10)
+
+		return specViolation{key: "collection_min", fallback: "should contain at least " + limit + " elements", params: []string{limit}}
+	case "enum":
+		values := make([]string, 0, len(schema.Enum))
+		for _, value := range schema.Enum {
+			values = append(values, fmt.Sprint(value))
+		}
+		allowed := strings.Join(values, " ")
+
+		return specViolation{key: "oneof", fallback: "should have value in: " + allowed, params: []string{allowed}}
+	case "pattern":
+		return specViolation{key: "pattern", fallback: "should match pattern " + schema.Pattern, params: []string{schema.Pattern}}
+	case "type":
+		types := strings.Join(schema.Type.Slice(), ", ")
+
+		return specViolation{key: "type", fallback: "should be of type " + types, params: []string{types}}
+	case "format":
+		switch schema.Format {
+		case "date":
+			return specViolation{key: "datetime", fallback: "should be a date in format " + time.DateOnly, params: []string{time.DateOnly}}
+		case "date-time":
+			return specViolation{key: "datetime", fallback: "should be a date in format " + time.RFC3339, params: []string{time.RFC3339}}
+		case "uuid":
+			return specViolation{key: "uuid", fallback: "should be a valid uuid"}
+		case "byte":
+			return specViolation{key: "base64", fallback: "should be a base64 encoded string"}
+		}
+	}
+
+	return specViolation{key: "incorrect_value", fallback: "incorrect value passed"}
+}
+
+// Операции в виде "GET /tenders/{tenderId}/status", как они записаны в контракте
+func registeredOperations(routes []*echo.Route) map[string]bool {
+	registered := make(map[string]bool)
+	for _, route := range routes {
+		// /api и /api/* echo добавляет сам для middleware группы
+		if !strings.HasPrefix(route.Path, apiPrefix+"/") || strings.HasSuffix(route.Path, "/*") {
+			continue
+		}
+		registered[route.Method+" "+specPath(route.Path)] = true
+	}
+
+	return registered
+}
+
+func documentedOperations(doc *openapi3.T) map[string]bool {
+	documented := make(map[string]bool)
+	for path, pathItem := range doc.Paths.Map() {
+		for method := range pathItem.Operations() {
+			documented[method+" "+path] = true
+		}
+	}
+
+	return documented
+}
+
+// Маршрут, забытый в контракте, не проверялся бы вовсе, а описанный, но не зарегистрированный, вводил бы клиентов
+// в заблуждение, поэтому при расхождении сервер не запускается
+func checkSpecCoverage(routes []*echo.Route, doc *openapi3.T) error {
+	registered, documented := registeredOperations(routes), documentedOperations(doc)
+
+	mismatches := make([]string, 0)
+	for operation := range documented {
+		if !registered[operation] {
+			mismatches = append(mismatches, operation+" is described in openapi.yml but not registered")
+		}
+	}
+	for operation := range registered {
+		if !documented[operation] {
+			mismatches = append(mismatches, operation+" is registered but missing from openapi.yml")
+		}
+	}
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	sort.Strings(mismatches)
+
+	return errors.New("routes do not match openapi.yml: " + strings.Join(mismatches, "; "))
+}
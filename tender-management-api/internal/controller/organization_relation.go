@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	"tender-management-api/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo"
+)
+
+type organizationRelationRoutesHandler struct {
+	relationService service.OrganizationRelations
+	validate        *validator.Validate
+}
+
+func newOrganizationRelationRoutesHandler(outer *echo.Group, services *service.Services, v *validator.Validate) *organizationRelationRoutesHandler {
+	h := &organizationRelationRoutesHandler{relationService: services.Relations, validate: v}
+
+	outer.POST("/organizations/relations/new", h.PostOrganizationRelation)
+	outer.DELETE("/organizations/relations/:relationId", h.DeleteOrganizationRelation)
+	outer.GET("/organizations/:organizationId/relations", h.GetOrganizationRelations)
+	outer.GET("/tenders/:tenderId/conflicts", h.GetTenderConflictReport)
+
+	return h
+}
+
+type postOrganizationRelationInput struct {
+	Username              string `query:"username" validate:"required"`
+	OrganizationId        string `json:"organizationId" validate:"required,uuid"`
+	RelatedOrganizationId string `json:"relatedOrganizationId" validate:"required,uuid"`
+	RelationType          string `json:"relationType" validate:"required,oneof=Parent Affiliate"`
+}
+
+// /organizations/relations/new
+func (h *organizationRelationRoutesHandler) PostOrganizationRelation(c echo.Context) error {
+	var input postOrganizationRelationInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.Username = c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	relation, err := h.relationService.CreateOrganizationRelation(c.Request().Context(), input.Username,
+		input.OrganizationId, input.RelatedOrganizationId, input.RelationType)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, relation); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type deleteOrganizationRelationInput struct {
+	RelationId string `param:"relationId" validate:"required,uuid"`
+	Username   string `query:"username" validate:"required"`
+}
+
+// /organizations/relations/:relationId
+func (h *organizationRelationRoutesHandler) DeleteOrganizationRelation(c echo.Context) error {
+	var input deleteOrganizationRelationInput
+	if err := c.Bind(&input); err != nil {
+		msg := err.Error()
+		if !strings.Contains(msg, "Request body can't be empty") {
+			return errMalformedInput
+		}
+	}
+
+	input.RelationId = c.Param("relationId")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	err := h.relationService.DeleteOrganizationRelation(c.Request().Context(), input.RelationId, input.Username)
+	if err == nil {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	return err
+}
+
+type getOrganizationRelationsInput struct {
+	OrganizationId string `param:"organizationId" validate:"required,uuid"`
+	Username       string `query:"username" validate:"required"`
+}
+
+// /organizations/:organizationId/relations
+func (h *organizationRelationRoutesHandler) GetOrganizationRelations(c echo.Context) error {
+	var input getOrganizationRelationsInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.OrganizationId = c.Param("organizationId")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	relations, err := h.relationService.GetOrganizationRelations(c.Request().Context(), input.OrganizationId, input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, relations); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type getTenderConflictReportInput struct {
+	TenderId string `param:"tenderId" validate:"required,uuid"`
+	Username string `query:"username" validate:"required"`
+}
+
+// /tenders/:tenderId/conflicts
+func (h *organizationRelationRoutesHandler) GetTenderConflictReport(c echo.Context) error {
+	var input getTenderConflictReportInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.TenderId = c.Param("tenderId")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	report, err := h.relationService.GetTenderConflictReport(c.Request().Context(), input.TenderId, input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, report); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
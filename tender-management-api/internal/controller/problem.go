@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"tender-management-api/internal/service"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo"
+)
+
+const problemContentType = "application/problem+json"
+
+// Ошибки, которые находит сам контроллер до обращения к сервисам
+var (
+	errMalformedInput        = service.NewError("malformed_input", http.StatusBadRequest, "Input data is not formed correctly")
+	errUsernameRequired      = service.NewError("username_required", http.StatusUnauthorized, "Please provide your username")
+	errAttachmentFileMissing = service.NewError("attachment_file_missing", http.StatusBadRequest, "Pass file in multipart form field 'file' no larger than 20 MB")
+	errBidUpdatesRequired    = service.NewError("bid_updates_required", http.StatusBadRequest, "Bid updates required, set bid's name and/or description")
+	errInvalidLastEventId    = service.NewError("invalid_last_event_id", http.StatusBadRequest, "Last-Event-ID header should contain id of the last received event")
+	errInternal              = service.NewError("internal_error", http.StatusInternalServerError, "Internal server error")
+)
+
+// problem - тело ответа об ошибке по RFC 7807. code, details и errors - расширения:
+// стабильный код ошибки, подробности конкретного случая и поля, не прошедшие валидацию
+type problem struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Code     string         `json:"code"`
+	Details  map[string]any `json:"details,omitempty"`
+	Errors   []fieldError   `json:"errors,omitempty"`
+}
+
+type validationError struct {
+	err validator.ValidationErrors
+}
+
+func newValidationError(err error) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return errMalformedInput
+	}
+
+	return &validationError{err: validationErrors}
+}
+
+func (e *validationError) Error() string {
+	return getAllErrorMessages(translations.GetFallback(), e.err)
+}
+
+// Пояснения переводятся на язык клиента по коду ошибки, без перевода остается английский текст ошибки
+func newProblem(trans ut.Translator, err error) *problem {
+	var validationErr *validationError
+	if errors.As(err, &validationErr) {
+		return &problem{
+			Status: http.StatusBadRequest,
+			Code:   "validation_failed",
+			Detail: translate(trans, "validation_failed", "Some of passed values are incorrect"),
+			Errors: getFieldErrors(trans, validationErr.err),
+		}
+	}
+
+	var specErr *specValidationError
+	if errors.As(err, &specErr) {
+		if specErr.status == errResponseContractViolation.Status {
+			detail := translate(trans, errResponseContractViolation.Code, errResponseContractViolation.Message)
+
+			return &problem{Status: specErr.status, Code: errResponseContractViolation.Code, Detail: detail, Errors: specErr.fieldErrors(trans)}
+		}
+
+		return &problem{
+			Status: http.StatusBadRequest,
+			Code:   "validation_failed",
+			Detail: translate(trans, "validation_failed", "Some of passed values are incorrect"),
+			Errors: specErr.fieldErrors(trans),
+		}
+	}
+
+	var serviceErr *service.Error
+	if errors.As(err, &serviceErr) {
+		detail := translate(trans, serviceErr.Code, serviceErr.Message)
+
+		return &problem{Status: serviceErr.Status, Code: serviceErr.Code, Detail: detail, Details: serviceErr.Details}
+	}
+
+	// маршрут не найден, метод не поддерживается и другие ошибки самого echo
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		title := http.StatusText(httpErr.Code)
+		code := strings.ToLower(strings.ReplaceAll(title, " ", "_"))
+		detail, _ := httpErr.Message.(string)
+
+		return &problem{Status: httpErr.Code, Code: code, Detail: translate(trans, code, detail)}
+	}
+
+	return &problem{Status: errInternal.Status, Code: errInternal.Code, Detail: translate(trans, errInternal.Code, errInternal.Message)}
+}
+
+// Единственное место, где ошибки обработчиков и middleware превращаются в ответ клиенту
+func problemErrorHandler(err error, c echo.Context) {
+	// поток событий или файл уже начали отправлять, статус ответа не изменить
+	if c.Response().Committed {
+		return
+	}
+
+	trans := requestTranslator(c)
+	p := newProblem(trans, err)
+	if p.Status >= http.StatusInternalServerError {
+		log.Println("Request " + c.Request().Method + " " + c.Request().URL.Path + " failed: " + err.Error())
+	}
+	p.Type = "about:blank"
+	p.Title = http.StatusText(p.Status)
+	p.Instance = c.Request().URL.Path
+	c.Response().Header().Set("Content-Language", trans.Locale())
+
+	if c.Request().Method == http.MethodHead {
+		if e := c.NoContent(p.Status); e != nil {
+			log.Println("Failed to send error response: " + e.Error())
+		}
+
+		return
+	}
+
+	body, e := json.Marshal(p)
+	if e == nil {
+		e = c.Blob(p.Status, problemContentType, body)
+	}
+	if e != nil {
+		log.Println("Failed to send error response: " + e.Error())
+	}
+}
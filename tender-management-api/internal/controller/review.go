@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"net/http"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo"
+)
+
+type reviewRoutesHandler struct {
+	bidService service.Bid
+	validate   *validator.Validate
+}
+
+func newReviewRoutesHandler(outer *echo.Group, services *service.Services, v *validator.Validate) *reviewRoutesHandler {
+	h := &reviewRoutesHandler{bidService: services.Bid, validate: v}
+
+	outer.GET("/bids/:bidId/feedback", h.GetBidReviews)
+	outer.PUT("/reviews/:reviewId/edit", h.EditReview)
+	outer.GET("/reviews/:reviewId/history", h.GetReviewHistory)
+	outer.PUT("/reviews/:reviewId/reply", h.ReplyToReview)
+	outer.PUT("/reviews/:reviewId/hide", h.HideReview)
+
+	return h
+}
+
+type getBidReviewsInput struct {
+	BidId    string `param:"bidId" validate:"required,max=100"`
+	Username string `query:"username" validate:"required"`
+}
+
+// /bids/:bidId/feedback
+func (h *reviewRoutesHandler) GetBidReviews(c echo.Context) error {
+	var input getBidReviewsInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.BidId = c.Param("bidId")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	reviews, err := h.bidService.GetBidReviews(c.Request().Context(), input.BidId, input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, reviews); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type editReviewInput struct {
+	ReviewId            string `param:"reviewId" validate:"required,max=100"`
+	Username            string `query:"username" validate:"required"`
+	Description         string `json:"description" validate:"required,max=1000"`
+	QualityRating       int    `json:"qualityRating" validate:"required,min=1,max=5"`
+	TimelinessRating    int    `json:"timelinessRating" validate:"required,min=1,max=5"`
+	CommunicationRating int    `json:"communicationRating" validate:"required,min=1,max=5"`
+}
+
+// /reviews/:reviewId/edit
+func (h *reviewRoutesHandler) EditReview(c echo.Context) error {
+	var input editReviewInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.ReviewId, input.Username = c.Param("reviewId"), c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	review, err := h.bidService.EditReview(c.Request().Context(), input.ReviewId, input.Username, &entity.EditReviewInput{
+		Description:         input.Description,
+		QualityRating:       input.QualityRating,
+		TimelinessRating:    input.TimelinessRating,
+		CommunicationRating: input.CommunicationRating,
+	})
+	if err == nil {
+		if e := c.JSON(http.StatusOK, review); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type getReviewHistoryInput struct {
+	ReviewId string `param:"reviewId" validate:"required,max=100"`
+	Username string `query:"username" validate:"required"`
+}
+
+// /reviews/:reviewId/history
+func (h *reviewRoutesHandler) GetReviewHistory(c echo.Context) error {
+	var input getReviewHistoryInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.ReviewId = c.Param("reviewId")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	versions, err := h.bidService.GetReviewHistory(c.Request().Context(), input.ReviewId, input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, versions); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type replyToReviewInput struct {
+	ReviewId string `param:"reviewId" validate:"required,max=100"`
+	Username string `query:"username" validate:"required"`
+	Reply    string `json:"reply" validate:"required,max=1000"`
+}
+
+// /reviews/:reviewId/reply
+func (h *reviewRoutesHandler) ReplyToReview(c echo.Context) error {
+	var input replyToReviewInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.ReviewId, input.Username = c.Param("reviewId"), c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	review, err := h.bidService.ReplyToReview(c.Request().Context(), input.ReviewId, input.Username, input.Reply)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, review); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type hideReviewInput struct {
+	ReviewId string `param:"reviewId" validate:"required,max=100"`
+	Username string `query:"username" validate:"required"`
+	Reason   string `json:"reason" validate:"required,max=500"`
+}
+
+// /reviews/:reviewId/hide
+func (h *reviewRoutesHandler) HideReview(c echo.Context) error {
+	var input hideReviewInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.ReviewId, input.Username = c.Param("reviewId"), c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	review, err := h.bidService.HideReview(c.Request().Context(), input.ReviewId, input.Username, input.Reason)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, review); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	"tender-management-api/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo"
+)
+
+type roleRoutesHandler struct {
+	roleService service.Roles
+	validate    *validator.Validate
+}
+
+func newRoleRoutesHandler(outer *echo.Group, services *service.Services, v *validator.Validate) *roleRoutesHandler {
+	h := &roleRoutesHandler{roleService: services.Roles, validate: v}
+
+	outer.GET("/organizations/my", h.GetEmployeeOrganizations)
+	outer.GET("/organizations/:organizationId/roles", h.GetOrganizationRoles)
+	outer.POST("/organizations/:organizationId/roles/new", h.PostRoleAssignment)
+	outer.DELETE("/organizations/:organizationId/roles/:assignmentId", h.DeleteRoleAssignment)
+
+	return h
+}
+
+type getOrganizationRolesInput struct {
+	OrganizationId string `param:"organizationId" validate:"required,uuid"`
+	Username       string `query:"username" validate:"required"`
+}
+
+// /organizations/:organizationId/roles
+func (h *roleRoutesHandler) GetOrganizationRoles(c echo.Context) error {
+	var input getOrganizationRolesInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.OrganizationId = c.Param("organizationId")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	roles, err := h.roleService.GetOrganizationRoles(c.Request().Context(), input.OrganizationId, input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, roles); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type postRoleAssignmentInput struct {
+	OrganizationId string `param:"organizationId" validate:"required,uuid"`
+	Username       string `query:"username" validate:"required"`
+	Assignee       string `json:"employeeUsername" validate:"required"`
+	Role           string `json:"role" validate:"required,oneof=OrgAdmin TenderManager Approver Bidder Auditor"`
+}
+
+// /organizations/:organizationId/roles/new
+func (h *roleRoutesHandler) PostRoleAssignment(c echo.Context) error {
+	var input postRoleAssignmentInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.OrganizationId = c.Param("organizationId")
+	input.Username = c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	assignment, err := h.roleService.AssignRole(c.Request().Context(), input.OrganizationId, input.Username, input.Assignee, input.Role)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, assignment); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type deleteRoleAssignmentInput struct {
+	OrganizationId string `param:"organizationId" validate:"required,uuid"`
+	AssignmentId   string `param:"assignmentId" validate:"required,uuid"`
+	Username       string `query:"username" validate:"required"`
+}
+
+// /organizations/:organizationId/roles/:assignmentId
+func (h *roleRoutesHandler) DeleteRoleAssignment(c echo.Context) error {
+	var input deleteRoleAssignmentInput
+	if err := c.Bind(&input); err != nil {
+		msg := err.Error()
+		if !strings.Contains(msg, "Request body can't be empty") {
+			return errMalformedInput
+		}
+	}
+
+	input.OrganizationId = c.Param("organizationId")
+	input.AssignmentId = c.Param("assignmentId")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	err := h.roleService.RevokeRole(c.Request().Context(), input.OrganizationId, input.AssignmentId, input.Username)
+	if err == nil {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	return err
+}
+
+type getEmployeeOrganizationsInput struct {
+	Username string `query:"username" validate:"required"`
+}
+
+// /organizations/my
+func (h *roleRoutesHandler) GetEmployeeOrganizations(c echo.Context) error {
+	var input getEmployeeOrganizationsInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	organizations, err := h.roleService.GetEmployeeOrganizations(c.Request().Context(), input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, organizations); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
@@ -7,10 +7,36 @@ import (
 	"github.com/labstack/echo"
 )
 
-func SetupRoutesHandlers(handler *echo.Echo, services *service.Services) {
+// validateResponses включает проверку ответов по openapi.yml, она нужна при тестах и замедляет работу.
+// Ошибка означает, что маршруты разошлись с контрактом
+func SetupRoutesHandlers(handler *echo.Echo, services *service.Services, validateResponses bool) error {
+	doc, err := loadOpenAPI()
+	if err != nil {
+		return err
+	}
+
+	handler.HTTPErrorHandler = problemErrorHandler
 	validate := validator.New(validator.WithRequiredStructEnabled())
-	api := handler.Group("/api")
+	api := handler.Group(apiPrefix)
+	api.Use(openAPIMiddleware(doc, validateResponses))
 	newDiagnosticRoutesHandler(api, services)
+	newDocsRoutesHandler(api, doc)
+	// ping и документация не обращаются к данным арендаторов, поэтому регистрируются до выбора арендатора
+	api.Use(tenantMiddleware(services.Tenants))
 	newBidRoutesHandler(api, services, validate)
+	newReviewRoutesHandler(api, services, validate)
+	newContractRoutesHandler(api, services, validate)
+	newSigningRoutesHandler(api, services, validate)
+	newOrganizationRelationRoutesHandler(api, services, validate)
+	newRoleRoutesHandler(api, services, validate)
+	newDelegationRoutesHandler(api, services, validate)
 	newTenderRoutesHandler(api, services, validate)
+	newTenderTemplateRoutesHandler(api, services, validate)
+	newTenderAttributeRoutesHandler(api, services, validate)
+	newServiceTypeRoutesHandler(api, services, validate)
+	newEventRoutesHandler(api, services, validate)
+	newNotificationRoutesHandler(api, services, validate)
+	newAttachmentRoutesHandler(api, services, validate)
+
+	return checkSpecCoverage(handler.Routes(), doc)
 }
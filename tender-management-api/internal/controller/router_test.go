@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/service"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
+	"github.com/labstack/echo"
+)
+
+// Обработчики обращаются к сервисам только при запросе, поэтому для сборки маршрутов хватает пустых заглушек
+func newTestRouter(t *testing.T, services *service.Services) (*echo.Echo, error) {
+	t.Helper()
+	handler := echo.New()
+	err := SetupRoutesHandlers(handler, services, true)
+
+	return handler, err
+}
+
+func missing(from, in map[string]bool) []string {
+	result := make([]string, 0)
+	for operation := range from {
+		if !in[operation] {
+			result = append(result, operation)
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+func TestRoutesMatchSpec(t *testing.T) {
+	handler, setupErr := newTestRouter(t, &service.Services{})
+	doc, err := loadOpenAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registered, documented := registeredOperations(handler.Routes()), documentedOperations(doc)
+	if len(registered) == 0 {
+		t.Fatal("no routes registered")
+	}
+	for _, operation := range missing(registered, documented) {
+		t.Errorf("%s is registered in SetupRoutesHandlers but missing from openapi.yml", operation)
+	}
+	for _, operation := range missing(documented, registered) {
+		t.Errorf("%s is described in openapi.yml but not registered in SetupRoutesHandlers", operation)
+	}
+	if setupErr != nil && !t.Failed() {
+		t.Errorf("SetupRoutesHandlers: %v", setupErr)
+	}
+}
+
+const coverageSpec = `
+openapi: "3.0.1"
+info:
+  title: coverage
+  version: "1.0"
+paths:
+  /tenders:
+    get:
+      responses:
+        "200":
+          description: ok
+  /tenders/{tenderId}/status:
+    get:
+      parameters:
+        - in: path
+          name: tenderId
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ok
+`
+
+// Проверка при запуске должна замечать расхождение в обе стороны
+func TestCheckSpecCoverageReportsBothDirections(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(coverageSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = doc.Validate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	routes := []*echo.Route{
+		{Method: "GET", Path: "/api/tenders"},
+		{Method: "GET", Path: "/api/tenders/:tenderId/status"},
+		// служебные маршруты группы не сверяются с контрактом
+		{Method: "POST", Path: "/api/*"},
+	}
+	if err = checkSpecCoverage(routes, doc); err != nil {
+		t.Fatalf("matching routes: %v", err)
+	}
+
+	undocumented := append(routes, &echo.Route{Method: "POST", Path: "/api/tenders/new"})
+	if err = checkSpecCoverage(undocumented, doc); err == nil || !strings.Contains(err.Error(), "POST /tenders/new is registered but missing") {
+		t.Errorf("undocumented route: got %v", err)
+	}
+
+	unregistered := routes[1:]
+	if err = checkSpecCoverage(unregistered, doc); err == nil || !strings.Contains(err.Error(), "GET /tenders is described in openapi.yml but not registered") {
+		t.Errorf("unregistered operation: got %v", err)
+	}
+}
+
+type tenantServiceStub struct {
+	service.Tenants
+}
+
+func (tenantServiceStub) ResolveTenant(_ context.Context, _ service.Principal, _ string) (uuid.UUID, error) {
+	return uuid.Nil, nil
+}
+
+type bidServiceStub struct {
+	service.Bid
+	bids []entity.BidOutputModel
+}
+
+func (s *bidServiceStub) GetUserBids(_ context.Context, _ string, _ string, _ *entity.PaginationInput) ([]entity.BidOutputModel, error) {
+	return s.bids, nil
+}
+
+// Ответы сверяются с контрактом, поэтому модель предложения не может молча потерять обязательное поле
+func TestBidResponseMatchesSpec(t *testing.T) {
+	bid := entity.BidOutputModel{
+		Id:          uuid.NewString(),
+		Name:        "Delivery",
+		Description: "Delivery in three days",
+		Status:      "Created",
+		TenderId:    uuid.NewString(),
+		AuthorType:  "User",
+		AuthorId:    uuid.NewString(),
+		Version:     1,
+		CreatedAt:   "2006-01-02T15:04:05Z",
+	}
+	handler, err := newTestRouter(t, &service.Services{Tenants: tenantServiceStub{}, Bid: &bidServiceStub{bids: []entity.BidOutputModel{bid}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/bids/my?username=user1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body: %s", rec.Code, rec.Body.String())
+	}
+	for _, field := range []string{`"description":`, `"tenderId":`} {
+		if !strings.Contains(rec.Body.String(), field) {
+			t.Errorf("response has no %s: %s", field, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo"
+)
+
+type serviceTypeRoutesHandler struct {
+	serviceTypeService service.ServiceTypes
+	validate           *validator.Validate
+}
+
+func newServiceTypeRoutesHandler(outer *echo.Group, services *service.Services, v *validator.Validate) *serviceTypeRoutesHandler {
+	h := &serviceTypeRoutesHandler{serviceTypeService: services.ServiceTypes, validate: v}
+
+	outer.GET("/service_types", h.GetServiceTypes)
+	outer.POST("/service_types/new", h.PostServiceType)
+	outer.PUT("/service_types/:code", h.EditServiceType)
+	outer.DELETE("/service_types/:code", h.DeleteServiceType)
+
+	return h
+}
+
+// /service_types
+func (h *serviceTypeRoutesHandler) GetServiceTypes(c echo.Context) error {
+	serviceTypes, err := h.serviceTypeService.GetServiceTypes(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	if e := c.JSON(http.StatusOK, serviceTypes); e != nil {
+		return e
+	}
+
+	return nil
+}
+
+type postServiceTypeInput struct {
+	Username   string `query:"username" validate:"required"`
+	Code       string `json:"code" validate:"required,max=50,alphanum"`
+	Name       string `json:"name" validate:"required,max=100"`
+	ParentCode string `json:"parentCode" validate:"max=50"`
+}
+
+// /service_types/new
+func (h *serviceTypeRoutesHandler) PostServiceType(c echo.Context) error {
+	var input postServiceTypeInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.Username = c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	serviceType, err := h.serviceTypeService.CreateServiceType(c.Request().Context(), &entity.ServiceTypeInput{
+		Code:       input.Code,
+		Name:       input.Name,
+		ParentCode: input.ParentCode,
+		Username:   input.Username,
+	})
+	if err == nil {
+		if e := c.JSON(http.StatusOK, serviceType); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type editServiceTypeInput struct {
+	Code       string `param:"code" validate:"required,max=50"`
+	Username   string `query:"username" validate:"required"`
+	Name       string `json:"name" validate:"required,max=100"`
+	ParentCode string `json:"parentCode" validate:"max=50"`
+}
+
+// /service_types/:code
+func (h *serviceTypeRoutesHandler) EditServiceType(c echo.Context) error {
+	var input editServiceTypeInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.Code, input.Username = c.Param("code"), c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	serviceType, err := h.serviceTypeService.EditServiceType(c.Request().Context(), input.Code, &entity.ServiceTypeInput{
+		Name:       input.Name,
+		ParentCode: input.ParentCode,
+		Username:   input.Username,
+	})
+	if err == nil {
+		if e := c.JSON(http.StatusOK, serviceType); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type deleteServiceTypeInput struct {
+	Code     string `param:"code" validate:"required,max=50"`
+	Username string `query:"username" validate:"required"`
+}
+
+// /service_types/:code
+func (h *serviceTypeRoutesHandler) DeleteServiceType(c echo.Context) error {
+	var input deleteServiceTypeInput
+	if err := c.Bind(&input); err != nil {
+		msg := err.Error()
+		if !strings.Contains(msg, "Request body can't be empty") {
+			return errMalformedInput
+		}
+	}
+
+	input.Code, input.Username = c.Param("code"), c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	err := h.serviceTypeService.DeleteServiceType(c.Request().Context(), input.Code, input.Username)
+	if err == nil {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	return err
+}
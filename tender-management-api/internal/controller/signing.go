@@ -0,0 +1,206 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"tender-management-api/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo"
+)
+
+type signingRoutesHandler struct {
+	signingService service.Signing
+	validate       *validator.Validate
+}
+
+func newSigningRoutesHandler(outer *echo.Group, services *service.Services, v *validator.Validate) *signingRoutesHandler {
+	h := &signingRoutesHandler{signingService: services.Signing, validate: v}
+
+	outer.GET("/employees/keys", h.GetKeys)
+	outer.POST("/employees/keys/new", h.RegisterKey)
+	outer.DELETE("/employees/keys/:keyId", h.RevokeKey)
+	outer.GET("/bids/:bidId/versions/:version/payload", h.GetBidVersionPayload)
+	outer.PUT("/bids/:bidId/versions/:version/sign", h.SignBidVersion)
+	outer.GET("/bids/:bidId/versions/:version/verify", h.VerifyBidVersion)
+
+	return h
+}
+
+type getKeysInput struct {
+	Username string `query:"username" validate:"required"`
+}
+
+// /employees/keys
+func (h *signingRoutesHandler) GetKeys(c echo.Context) error {
+	var input getKeysInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	keys, err := h.signingService.GetKeys(c.Request().Context(), input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, keys); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type registerKeyInput struct {
+	Username  string `query:"username" validate:"required"`
+	PublicKey string `json:"publicKey" validate:"required,base64"`
+}
+
+// /employees/keys/new
+func (h *signingRoutesHandler) RegisterKey(c echo.Context) error {
+	var input registerKeyInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.Username = c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	key, err := h.signingService.RegisterKey(c.Request().Context(), input.Username, input.PublicKey)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, key); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type revokeKeyInput struct {
+	KeyId    string `param:"keyId" validate:"required,uuid"`
+	Username string `query:"username" validate:"required"`
+}
+
+// /employees/keys/:keyId
+func (h *signingRoutesHandler) RevokeKey(c echo.Context) error {
+	var input revokeKeyInput
+	if err := c.Bind(&input); err != nil {
+		msg := err.Error()
+		if !strings.Contains(msg, "Request body can't be empty") {
+			return errMalformedInput
+		}
+	}
+
+	input.KeyId = c.Param("keyId")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	key, err := h.signingService.RevokeKey(c.Request().Context(), input.KeyId, input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, key); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type bidVersionInput struct {
+	BidId    string `param:"bidId" validate:"required,max=100"`
+	Version  int    `param:"version" validate:"required,min=1"`
+	Username string `query:"username" validate:"required"`
+}
+
+// /bids/:bidId/versions/:version/payload
+func (h *signingRoutesHandler) GetBidVersionPayload(c echo.Context) error {
+	var input bidVersionInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.BidId = c.Param("bidId")
+	input.Version, _ = strconv.Atoi(c.Param("version"))
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	payload, err := h.signingService.GetBidVersionPayload(c.Request().Context(), input.BidId, input.Version, input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, payload); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type signBidVersionInput struct {
+	BidId     string `param:"bidId" validate:"required,max=100"`
+	Version   int    `param:"version" validate:"required,min=1"`
+	Username  string `query:"username" validate:"required"`
+	KeyId     string `json:"keyId" validate:"required,uuid"`
+	Signature string `json:"signature" validate:"required,base64"`
+}
+
+// /bids/:bidId/versions/:version/sign
+func (h *signingRoutesHandler) SignBidVersion(c echo.Context) error {
+	var input signBidVersionInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.BidId, input.Username = c.Param("bidId"), c.QueryParam("username")
+	input.Version, _ = strconv.Atoi(c.Param("version"))
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	verification, err := h.signingService.SignBidVersion(c.Request().Context(), input.BidId, input.Version, input.Username, input.KeyId, input.Signature)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, verification); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+// /bids/:bidId/versions/:version/verify
+func (h *signingRoutesHandler) VerifyBidVersion(c echo.Context) error {
+	var input bidVersionInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.BidId = c.Param("bidId")
+	input.Version, _ = strconv.Atoi(c.Param("version"))
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	verification, err := h.signingService.VerifyBidVersion(c.Request().Context(), input.BidId, input.Version, input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, verification); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"tender-management-api/internal/service"
+	"tender-management-api/pkg/tenant"
+
+	"github.com/labstack/echo"
+)
+
+// Заголовок только подтверждает арендатора, ожидаемого клиентом: выбирается всегда арендатор сотрудника
+const tenantHeader = "X-Tenant-Id"
+
+// Сотрудник запроса - username в query, а в запросах на создание тендера, шаблона и предложения,
+// где его нет, - автор из тела: creatorUsername или authorId
+func requestPrincipal(c echo.Context) (service.Principal, error) {
+	principal := service.Principal{Username: c.QueryParam("username")}
+	if principal.Username == "" {
+		principal.Username = c.QueryParam("requesterUsername")
+	}
+
+	req := c.Request()
+	if principal.Username != "" || req.Method != http.MethodPost ||
+		!strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return principal, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return principal, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	var author struct {
+		CreatorUsername string `json:"creatorUsername"`
+		AuthorId        string `json:"authorId"`
+	}
+	// некорректное тело отклонит обработчик, а запрос без автора выполнится в арендаторе по умолчанию
+	_ = json.Unmarshal(body, &author)
+	principal.Username, principal.EmployeeId = author.CreatorUsername, author.AuthorId
+
+	return principal, nil
+}
+
+// Все запросы к базе внутри обработчика выполняются от имени арендатора, выбранного здесь
+func tenantMiddleware(tenants service.Tenants) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, err := requestPrincipal(c)
+			if err != nil {
+				return err
+			}
+
+			ctx := c.Request().Context()
+			tenantId, err := tenants.ResolveTenant(ctx, principal, c.Request().Header.Get(tenantHeader))
+			if err != nil {
+				return err
+			}
+			c.SetRequest(c.Request().WithContext(tenant.WithId(ctx, tenantId)))
+
+			return next(c)
+		}
+	}
+}
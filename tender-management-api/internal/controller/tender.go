@@ -4,9 +4,9 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
-	"tender-management-api/internal/common"
 	"tender-management-api/internal/entity"
 	"tender-management-api/internal/service"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo"
@@ -27,6 +27,7 @@ func newTenderRoutesHandler(outer *echo.Group, services *service.Services, v *va
 	outer.PUT("/tenders/:tenderId/status", h.UpdateTenderStatus)
 	outer.PATCH("/tenders/:tenderId/edit", h.EditTender)
 	outer.PUT("/tenders/:tenderId/rollback/:version", h.RollbackTenderVersion)
+	outer.POST("/tenders/:tenderId/clone", h.CloneTender)
 
 	return h
 }
@@ -34,39 +35,41 @@ func newTenderRoutesHandler(outer *echo.Group, services *service.Services, v *va
 type getTenderInput struct {
 	Limit        int32    `query:"limit" validate:"gte=0,lte=50"`
 	Offset       int32    `query:"offset" validate:"gte=0"`
-	ServiceTypes []string `query:"service_type" validate:"dive,oneof=Construction Delivery Manufacture"`
+	ServiceTypes []string `query:"service_type" validate:"dive,max=50"`
+	Tags         []string `query:"tag" validate:"dive,max=50"`
+	Attributes   []string `query:"attribute" validate:"dive,max=250,contains=:"`
 }
 
 func newGetTenderInput() getTenderInput {
 	return getTenderInput{Limit: defaultLimit, Offset: defaultOffset, ServiceTypes: make([]string, 0)}
 }
 
+// Фильтр по атрибутам передается как attribute=code:value, каждое условие должно выполняться
+func parseAttributeFilter(attributes []string) map[string]string {
+	parsed := make(map[string]string, len(attributes))
+	for _, attribute := range attributes {
+		code, value, _ := strings.Cut(attribute, ":")
+		parsed[code] = value
+	}
+
+	return parsed
+}
+
 // /tenders
 func (h *tenderRoutesHandler) GetTenders(c echo.Context) error {
 	var input = newGetTenderInput()
 	if err := c.Bind(&input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-			return e
-		}
-
-		return err
+		return errMalformedInput
 	}
 
 	if err := h.validate.Struct(input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{getAllErrorMessages(err)}); e != nil {
-			return e
-		}
-
-		return err
+		return newValidationError(err)
 	}
 
 	pg := entity.NewPaginationInput(int(input.Limit), int(input.Offset))
-	tenders, err := h.tenderService.GetPublishedTenders(c.Request().Context(), input.ServiceTypes, pg)
+	filter := &entity.TenderFilter{ServiceTypes: input.ServiceTypes, Tags: input.Tags, Attributes: parseAttributeFilter(input.Attributes)}
+	tenders, err := h.tenderService.GetPublishedTenders(c.Request().Context(), filter, pg)
 	if err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{err.Error()}); e != nil {
-			return e
-		}
-
 		return err
 	}
 	if e := c.JSON(http.StatusOK, tenders); e != nil {
@@ -77,35 +80,38 @@ func (h *tenderRoutesHandler) GetTenders(c echo.Context) error {
 }
 
 type postTenderInput struct {
-	Name            string `json:"name" validate:"required,max=100"`
-	Description     string `json:"description" validate:"required,max=500"`
-	ServiceType     string `json:"serviceType" validate:"required,oneof=Construction Delivery Manufacture"`
-	OrganizationId  string `json:"organizationId" validate:"required,max=100"`
-	CreatorUsername string `json:"creatorUsername" validate:"required"`
+	Name              string            `json:"name" validate:"required,max=100"`
+	Description       string            `json:"description" validate:"required,max=500"`
+	ServiceType       string            `json:"serviceType" validate:"required,max=50"`
+	OrganizationId    string            `json:"organizationId" validate:"required,max=100"`
+	CreatorUsername   string            `json:"creatorUsername" validate:"required"`
+	Deadline          string            `json:"deadline" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Tags              []string          `json:"tags" validate:"max=20,dive,required,max=50"`
+	Attributes        map[string]string `json:"attributes" validate:"dive,keys,required,max=50,endkeys,max=200"`
+	RequiresSignature bool              `json:"requiresSignature"`
+	ConflictPolicy    string            `json:"conflictPolicy" validate:"omitempty,oneof=Flag Block"`
 }
 
 // /tenders/new
 func (h *tenderRoutesHandler) PostTender(c echo.Context) error {
 	var input postTenderInput
 	if err := c.Bind(&input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-			return e
-		}
-
-		return err
+		return errMalformedInput
 	}
 
 	if err := h.validate.Struct(input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{getAllErrorMessages(err)}); e != nil {
-			return e
-		}
-
-		return err
+		return newValidationError(err)
 	}
 
 	model := &entity.CreateTenderInput{
 		Name: input.Name, Description: input.Description, ServiceType: input.ServiceType,
 		OrganizationId: input.OrganizationId, CreatorUsername: input.CreatorUsername,
+		Tags: input.Tags, Attributes: input.Attributes, RequiresSignature: input.RequiresSignature,
+		ConflictPolicy: input.ConflictPolicy,
+	}
+	if input.Deadline != "" {
+		deadline, _ := time.Parse(time.RFC3339, input.Deadline)
+		model.Deadline = &deadline
 	}
 
 	tender, err := h.tenderService.CreateTender(c.Request().Context(), model)
@@ -117,32 +123,18 @@ func (h *tenderRoutesHandler) PostTender(c echo.Context) error {
 		return err
 	}
 
-	switch err {
-	case service.ErrEmployeeNotFound:
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"There is no employee with given username"}); e != nil {
-			return e
-		}
-	case service.ErrOrganizationNotFound:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"There is no organization with given id"}); e != nil {
-			return e
-		}
-	case service.ErrUserIsNotOrganizationResponsible:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"You can't create tender from given organization, because you are not responsible for it"}); e != nil {
-			return e
-		}
-	default:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Error"}); e != nil {
-			return e
-		}
-	}
-
-	return nil
+	return err
 }
 
 type getUserTendersInput struct {
-	Limit    int32  `query:"limit" validate:"gte=0,lte=50"`
-	Offset   int32  `query:"offset" validate:"gte=0"`
-	Username string `query:"username" validate:""`
+	Limit        int32    `query:"limit" validate:"gte=0,lte=50"`
+	Offset       int32    `query:"offset" validate:"gte=0"`
+	Username     string   `query:"username" validate:""`
+	ServiceTypes []string `query:"service_type" validate:"dive,max=50"`
+	Tags         []string `query:"tag" validate:"dive,max=50"`
+	Attributes   []string `query:"attribute" validate:"dive,max=250,contains=:"`
+	// без организации возвращаются тендеры всех организаций сотрудника
+	OrganizationId string `query:"organizationId" validate:"omitempty,uuid"`
 }
 
 func newGetUserTendersInput() getUserTendersInput {
@@ -153,24 +145,17 @@ func newGetUserTendersInput() getUserTendersInput {
 func (h *tenderRoutesHandler) GetUserTenders(c echo.Context) error {
 	var input = newGetUserTendersInput()
 	if err := c.Bind(&input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-			return e
-		}
-
-		return err
+		return errMalformedInput
 	}
 
 	if err := h.validate.Struct(input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{getAllErrorMessages(err)}); e != nil {
-			return e
-		}
-
-		return err
+		return newValidationError(err)
 	}
 
 	pg := entity.NewPaginationInput(int(input.Limit), int(input.Offset))
 	usernamePassed := input.Username != defaultUsername
-	tenders, err := h.tenderService.GetUserTenders(c.Request().Context(), input.Username, usernamePassed, pg)
+	filter := &entity.TenderFilter{ServiceTypes: input.ServiceTypes, Tags: input.Tags, Attributes: parseAttributeFilter(input.Attributes)}
+	tenders, err := h.tenderService.GetUserTenders(c.Request().Context(), input.Username, usernamePassed, input.OrganizationId, filter, pg)
 	if err == nil {
 		if e := c.JSON(http.StatusOK, tenders); e != nil {
 			return e
@@ -179,17 +164,6 @@ func (h *tenderRoutesHandler) GetUserTenders(c echo.Context) error {
 		return nil
 	}
 
-	switch err {
-	case service.ErrEmployeeNotFound:
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"There is no employee with given username"}); e != nil {
-			return e
-		}
-	default:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{err.Error()}); e != nil {
-			return e
-		}
-	}
-
 	return err
 }
 
@@ -202,20 +176,12 @@ type getTenderStatusInput struct {
 func (h *tenderRoutesHandler) GetTenderStatus(c echo.Context) error {
 	var input getTenderStatusInput
 	if err := c.Bind(&input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-			return e
-		}
-
-		return err
+		return errMalformedInput
 	}
 
 	input.TenderId = c.Param("tenderId")
 	if err := h.validate.Struct(input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{getAllErrorMessages(err)}); e != nil {
-			return e
-		}
-
-		return err
+		return newValidationError(err)
 	}
 
 	usernamePassed := input.Username != defaultUsername
@@ -228,29 +194,6 @@ func (h *tenderRoutesHandler) GetTenderStatus(c echo.Context) error {
 		return nil
 	}
 
-	switch err {
-	case service.ErrTenderNotFound:
-		if e := c.JSON(http.StatusNotFound, errorResponse{"There is no tender with given id"}); e != nil {
-			return e
-		}
-	case service.ErrUserHasNoAccessToTender:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"Only responsible for tender's organization can see tender's status"}); e != nil {
-			return e
-		}
-	case service.ErrEmployeeNotFound:
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"There is no employee with given username"}); e != nil {
-			return e
-		}
-	case service.ErrUnauthorizedTryToAccessWithEmployeeRights:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"Try to pass username"}); e != nil {
-			return e
-		}
-	default:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Error"}); e != nil {
-			return e
-		}
-	}
-
 	return err
 }
 
@@ -266,21 +209,13 @@ func (h *tenderRoutesHandler) UpdateTenderStatus(c echo.Context) error {
 	if err := c.Bind(&input); err != nil {
 		msg := err.Error()
 		if !strings.Contains(msg, "Request body can't be empty") {
-			if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-				return e
-			}
-
-			return err
+			return errMalformedInput
 		}
 	}
 
 	input.TenderId, input.Status, input.Username = c.Param("tenderId"), c.QueryParam("status"), c.QueryParam("username")
 	if err := h.validate.Struct(input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{getAllErrorMessages(err)}); e != nil {
-			return e
-		}
-
-		return err
+		return newValidationError(err)
 	}
 
 	tender, err := h.tenderService.UpdateTenderStatusById(c.Request().Context(), input.TenderId, input.Status, input.Username)
@@ -292,61 +227,35 @@ func (h *tenderRoutesHandler) UpdateTenderStatus(c echo.Context) error {
 		return nil
 	}
 
-	switch err {
-	case service.ErrTenderNotFound:
-		if e := c.JSON(http.StatusNotFound, errorResponse{"There is no tender with given id"}); e != nil {
-			return e
-		}
-	case service.ErrEmployeeNotFound:
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"There is no employee with given username"}); e != nil {
-			return e
-		}
-	case service.ErrUserHasNoAccessToTender:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"You have no enough rights to update tender status"}); e != nil {
-			return e
-		}
-	default:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Error"}); e != nil {
-			return e
-		}
-	}
-
 	return err
 }
 
 type editTenderInput struct {
-	TenderId    string `param:"tenderId" validate:"required,max=100"`
-	Username    string `query:"username" validate:"required"`
-	Name        string `json:"name" validate:"max=100"`
-	Description string `json:"description" validate:"max=500"`
-	ServiceType string `json:"serviceType" validate:"oneof=Construction Delivery Manufacture"`
+	TenderId    string            `param:"tenderId" validate:"required,max=100"`
+	Username    string            `query:"username" validate:"required"`
+	Name        string            `json:"name" validate:"max=100"`
+	Description string            `json:"description" validate:"max=500"`
+	ServiceType string            `json:"serviceType" validate:"max=50"`
+	Tags        []string          `json:"tags" validate:"max=20,dive,required,max=50"`
+	Attributes  map[string]string `json:"attributes" validate:"dive,keys,required,max=50,endkeys,max=200"`
 }
 
 // /tenders/:tenderId/edit
 func (h *tenderRoutesHandler) EditTender(c echo.Context) error {
 	var input editTenderInput
 	if err := c.Bind(&input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-			return e
-		}
-
-		return err
+		return errMalformedInput
 	}
 
 	input.Username = c.QueryParam("username")
 	input.TenderId = c.Param("tenderId")
 	if err := h.validate.Struct(input); err != nil {
-		m := getAllErrorMessages(err)
-		if input.ServiceType != "" || input.ServiceType == common.Construction || input.ServiceType == common.Delivery || input.ServiceType == common.Manufacture {
-			if e := c.JSON(http.StatusBadRequest, errorResponse{m}); e != nil {
-				return e
-			}
-
-			return err
-		}
+		return newValidationError(err)
 	}
 
-	tender, err := h.tenderService.EditTenderById(c.Request().Context(), input.TenderId, input.Username, input.Name, input.Description, input.ServiceType)
+	tender, err := h.tenderService.EditTenderById(c.Request().Context(), input.TenderId, input.Username, &entity.EditTenderInput{
+		Name: input.Name, Description: input.Description, ServiceType: input.ServiceType, Tags: input.Tags, Attributes: input.Attributes,
+	})
 	if err == nil {
 		if e := c.JSON(http.StatusOK, tender); e != nil {
 			return e
@@ -355,25 +264,6 @@ func (h *tenderRoutesHandler) EditTender(c echo.Context) error {
 		return nil
 	}
 
-	switch err {
-	case service.ErrTenderNotFound:
-		if e := c.JSON(http.StatusNotFound, errorResponse{"There is no tender with given id"}); e != nil {
-			return e
-		}
-	case service.ErrEmployeeNotFound:
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"There is no employee with given username"}); e != nil {
-			return e
-		}
-	case service.ErrUserHasNoAccessToTender:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"You have no enough rights to edit tender"}); e != nil {
-			return e
-		}
-	default:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Error"}); e != nil {
-			return e
-		}
-	}
-
 	return err
 }
 
@@ -389,22 +279,14 @@ func (h *tenderRoutesHandler) RollbackTenderVersion(c echo.Context) error {
 	if err := c.Bind(&input); err != nil {
 		msg := err.Error()
 		if !strings.Contains(msg, "Request body can't be empty") {
-			if e := c.JSON(http.StatusBadRequest, errorResponse{"Input data is not formed correctly"}); e != nil {
-				return e
-			}
-
-			return err
+			return errMalformedInput
 		}
 	}
 
 	v, _ := strconv.Atoi(c.Param("version"))
 	input.TenderId, input.Username, input.Version = c.Param("tenderId"), c.QueryParam("username"), v
 	if err := h.validate.Struct(input); err != nil {
-		if e := c.JSON(http.StatusBadRequest, errorResponse{getAllErrorMessages(err)}); e != nil {
-			return e
-		}
-
-		return err
+		return newValidationError(err)
 	}
 
 	tender, err := h.tenderService.RollbackTenderVersion(c.Request().Context(), input.TenderId, input.Version, input.Username)
@@ -416,27 +298,36 @@ func (h *tenderRoutesHandler) RollbackTenderVersion(c echo.Context) error {
 		return nil
 	}
 
-	switch err {
-	case service.ErrTenderNotFound:
-		if e := c.JSON(http.StatusNotFound, errorResponse{"There is no tender with given id"}); e != nil {
-			return e
-		}
-	case service.ErrEmployeeNotFound:
-		if e := c.JSON(http.StatusUnauthorized, errorResponse{"There is no employee with given username"}); e != nil {
-			return e
-		}
-	case service.ErrUserHasNoAccessToTender:
-		if e := c.JSON(http.StatusForbidden, errorResponse{"You have no enough rights to rollaback / edit tender"}); e != nil {
-			return e
-		}
-	case service.ErrNoSuchVersion:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"No such version"}); e != nil {
-			return e
+	return err
+}
+
+type cloneTenderInput struct {
+	TenderId string `param:"tenderId" validate:"required,max=100"`
+	Username string `query:"username" validate:"required"`
+}
+
+// /tenders/:tenderId/clone
+func (h *tenderRoutesHandler) CloneTender(c echo.Context) error {
+	var input cloneTenderInput
+	if err := c.Bind(&input); err != nil {
+		msg := err.Error()
+		if !strings.Contains(msg, "Request body can't be empty") {
+			return errMalformedInput
 		}
-	default:
-		if e := c.JSON(http.StatusBadRequest, errorResponse{"Error"}); e != nil {
+	}
+
+	input.TenderId, input.Username = c.Param("tenderId"), c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	tender, err := h.tenderService.CloneTender(c.Request().Context(), input.TenderId, input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, tender); e != nil {
 			return e
 		}
+
+		return nil
 	}
 
 	return err
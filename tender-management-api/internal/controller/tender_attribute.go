@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo"
+)
+
+type tenderAttributeRoutesHandler struct {
+	attributeService service.TenderAttributes
+	validate         *validator.Validate
+}
+
+func newTenderAttributeRoutesHandler(outer *echo.Group, services *service.Services, v *validator.Validate) *tenderAttributeRoutesHandler {
+	h := &tenderAttributeRoutesHandler{attributeService: services.TenderAttributes, validate: v}
+
+	outer.GET("/tenders/attributes", h.GetTenderAttributes)
+	outer.PUT("/tenders/attributes/:code", h.SetTenderAttribute)
+	outer.DELETE("/tenders/attributes/:code", h.DeleteTenderAttribute)
+
+	return h
+}
+
+type getTenderAttributesInput struct {
+	OrganizationId string `query:"organizationId" validate:"required,max=100"`
+	Username       string `query:"username" validate:"required"`
+}
+
+// /tenders/attributes
+func (h *tenderAttributeRoutesHandler) GetTenderAttributes(c echo.Context) error {
+	var input getTenderAttributesInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	definitions, err := h.attributeService.GetTenderAttributeDefinitions(c.Request().Context(), input.OrganizationId, input.Username)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, definitions); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type setTenderAttributeInput struct {
+	Code           string   `param:"code" validate:"required,max=50,alphanum"`
+	OrganizationId string   `query:"organizationId" validate:"required,max=100"`
+	Username       string   `query:"username" validate:"required"`
+	Name           string   `json:"name" validate:"required,max=100"`
+	Type           string   `json:"type" validate:"required,oneof=string number boolean date enum"`
+	Required       bool     `json:"required"`
+	AllowedValues  []string `json:"allowedValues" validate:"max=50,dive,required,max=200"`
+}
+
+// /tenders/attributes/:code
+func (h *tenderAttributeRoutesHandler) SetTenderAttribute(c echo.Context) error {
+	var input setTenderAttributeInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.Code, input.OrganizationId, input.Username = c.Param("code"), c.QueryParam("organizationId"), c.QueryParam("username")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	definitions, err := h.attributeService.SetTenderAttributeDefinition(c.Request().Context(), &entity.TenderAttributeDefinitionInput{
+		OrganizationId: input.OrganizationId,
+		Code:           input.Code,
+		Name:           input.Name,
+		Type:           input.Type,
+		Required:       input.Required,
+		AllowedValues:  input.AllowedValues,
+		Username:       input.Username,
+	})
+	if err == nil {
+		if e := c.JSON(http.StatusOK, definitions); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type deleteTenderAttributeInput struct {
+	Code           string `param:"code" validate:"required,max=50"`
+	OrganizationId string `query:"organizationId" validate:"required,max=100"`
+	Username       string `query:"username" validate:"required"`
+}
+
+// /tenders/attributes/:code
+func (h *tenderAttributeRoutesHandler) DeleteTenderAttribute(c echo.Context) error {
+	var input deleteTenderAttributeInput
+	if err := c.Bind(&input); err != nil {
+		msg := err.Error()
+		if !strings.Contains(msg, "Request body can't be empty") {
+			return errMalformedInput
+		}
+	}
+
+	input.Code = c.Param("code")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	err := h.attributeService.DeleteTenderAttributeDefinition(c.Request().Context(), input.OrganizationId, input.Code, input.Username)
+	if err == nil {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	return err
+}
@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"net/http"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/service"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo"
+)
+
+type tenderTemplateRoutesHandler struct {
+	templateService service.TenderTemplates
+	validate        *validator.Validate
+}
+
+func newTenderTemplateRoutesHandler(outer *echo.Group, services *service.Services, v *validator.Validate) *tenderTemplateRoutesHandler {
+	h := &tenderTemplateRoutesHandler{templateService: services.TenderTemplates, validate: v}
+
+	outer.GET("/tenders/templates", h.GetTenderTemplates)
+	outer.POST("/tenders/templates/new", h.PostTenderTemplate)
+	outer.DELETE("/tenders/templates/:templateId", h.DeleteTenderTemplate)
+	outer.POST("/tenders/templates/:templateId/new", h.PostTenderFromTemplate)
+
+	return h
+}
+
+type getTenderTemplatesInput struct {
+	OrganizationId string `query:"organizationId" validate:"required,max=100"`
+	Username       string `query:"username" validate:"required"`
+	Limit          int32  `query:"limit" validate:"gte=0,lte=50"`
+	Offset         int32  `query:"offset" validate:"gte=0"`
+}
+
+func newGetTenderTemplatesInput() getTenderTemplatesInput {
+	return getTenderTemplatesInput{Limit: defaultLimit, Offset: defaultOffset, Username: defaultUsername}
+}
+
+// /tenders/templates
+func (h *tenderTemplateRoutesHandler) GetTenderTemplates(c echo.Context) error {
+	var input = newGetTenderTemplatesInput()
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	pg := entity.NewPaginationInput(int(input.Limit), int(input.Offset))
+	templates, err := h.templateService.GetTenderTemplates(c.Request().Context(), input.OrganizationId, input.Username, pg)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, templates); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type postTenderTemplateInput struct {
+	Name              string `json:"name" validate:"required,max=100"`
+	TenderName        string `json:"tenderName" validate:"required,max=100"`
+	TenderDescription string `json:"tenderDescription" validate:"required,max=500"`
+	ServiceType       string `json:"serviceType" validate:"required,max=50"`
+	OrganizationId    string `json:"organizationId" validate:"required,max=100"`
+	CreatorUsername   string `json:"creatorUsername" validate:"required"`
+}
+
+// /tenders/templates/new
+func (h *tenderTemplateRoutesHandler) PostTenderTemplate(c echo.Context) error {
+	var input postTenderTemplateInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	template, err := h.templateService.CreateTenderTemplate(c.Request().Context(), &entity.CreateTenderTemplateInput{
+		Name:              input.Name,
+		TenderName:        input.TenderName,
+		TenderDescription: input.TenderDescription,
+		ServiceType:       input.ServiceType,
+		OrganizationId:    input.OrganizationId,
+		CreatorUsername:   input.CreatorUsername,
+	})
+	if err == nil {
+		if e := c.JSON(http.StatusOK, template); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+type deleteTenderTemplateInput struct {
+	TemplateId string `param:"templateId" validate:"required,max=100"`
+	Username   string `query:"username" validate:"required"`
+}
+
+// /tenders/templates/:templateId
+func (h *tenderTemplateRoutesHandler) DeleteTenderTemplate(c echo.Context) error {
+	var input deleteTenderTemplateInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	input.TemplateId = c.Param("templateId")
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	err := h.templateService.DeleteTenderTemplate(c.Request().Context(), input.TemplateId, input.Username)
+	if err == nil {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	return err
+}
+
+type postTenderFromTemplateInput struct {
+	CreatorUsername string            `json:"creatorUsername" validate:"required"`
+	Values          map[string]string `json:"values" validate:"dive,max=500"`
+	Deadline        string            `json:"deadline" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Tags            []string          `json:"tags" validate:"max=20,dive,required,max=50"`
+	Attributes      map[string]string `json:"attributes" validate:"dive,keys,required,max=50,endkeys,max=200"`
+}
+
+// /tenders/templates/:templateId/new
+func (h *tenderTemplateRoutesHandler) PostTenderFromTemplate(c echo.Context) error {
+	var input postTenderFromTemplateInput
+	if err := c.Bind(&input); err != nil {
+		return errMalformedInput
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		return newValidationError(err)
+	}
+
+	model := &entity.CreateTenderFromTemplateInput{
+		TemplateId: c.Param("templateId"), CreatorUsername: input.CreatorUsername, Values: input.Values,
+		Tags: input.Tags, Attributes: input.Attributes,
+	}
+	if input.Deadline != "" {
+		deadline, _ := time.Parse(time.RFC3339, input.Deadline)
+		model.Deadline = &deadline
+	}
+
+	tender, err := h.templateService.CreateTenderFromTemplate(c.Request().Context(), model)
+	if err == nil {
+		if e := c.JSON(http.StatusOK, tender); e != nil {
+			return e
+		}
+
+		return nil
+	}
+
+	return err
+}
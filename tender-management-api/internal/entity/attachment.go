@@ -0,0 +1,39 @@
+package entity
+
+import "github.com/google/uuid"
+
+// db model
+type Attachment struct {
+	Id          uuid.UUID
+	FileName    string
+	ContentType string
+	Size        int64
+	Checksum    string
+	StorageKey  string
+	AuthorId    uuid.UUID
+	CreatedAt   string
+}
+
+// service input model
+type UploadAttachmentInput struct {
+	FileName string // given
+	Checksum string // given, optional: sha256 in hex, which the client computed
+	Username string // given
+	Content  []byte // given
+}
+
+// controller model
+type AttachmentOutputModel struct {
+	Id          string `json:"id"`
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	Checksum    string `json:"sha256"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+// controller model
+type AttachmentContent struct {
+	Attachment AttachmentOutputModel
+	Content    []byte
+}
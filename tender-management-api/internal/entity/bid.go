@@ -12,31 +12,77 @@ type Bid struct {
 	TenderId    uuid.UUID `json:"tenderId" db:"tender_id"`
 	AuthorType  string    `json:"authorType" db:"author_type"`
 	AuthorId    uuid.UUID `json:"authorId" db:"author_id"`
-	Version     int       `json:"version" db:"version"`
-	CreatedAt   string    `json:"createdAt" db:"created_at"`
-	Decision    string    `json:"decision" db:"decision"`
+	// организация, от имени которой подано предложение; ее ответственные тоже управляют им
+	OrganizationId *uuid.UUID `json:"organizationId" db:"organization_id"`
+	Version        int        `json:"version" db:"version"`
+	CreatedAt      string     `json:"createdAt" db:"created_at"`
+	Decision       string     `json:"decision" db:"decision"`
+	Signed         bool       `json:"signed" db:"signed"` // подписана ли текущая версия
+
+	WithdrawalReason    string `json:"withdrawalReason" db:"withdrawal_reason"`
+	WithdrawnAt         string `json:"withdrawnAt" db:"withdrawn_at"`
+	WithdrawalConsented bool   `json:"withdrawalConsented" db:"withdrawal_consented_by"` // владелец тендера разрешил отзыв при наличии голосов
+
+	ConflictOfInterest bool `json:"conflictOfInterest" db:"conflict_of_interest"` // автор связан с организацией тендера
 }
 
 // service + repo input model
 type CreateBidInput struct {
-	Name        string // given
-	Description string // given
-	TenderId    string // given
-	AuthorId    string // given
-	AuthorType  string // given
-	Status      string // should be set: "Created"
-	Version     int    // should be set: 1
+	Name               string     // given
+	Description        string     // given
+	TenderId           string     // given
+	AuthorId           string     // given
+	AuthorType         string     // given
+	OrganizationId     *uuid.UUID // given or should be set for "Organization" bids
+	ConflictOfInterest bool       // should be set: автор связан с организацией тендера
+	Status             string     // should be set: "Created"
+	Version            int        // should be set: 1
 	// Id UUID sets automatically
 	// Created_at sets automatically
 }
 
 // controller model
 type BidOutputModel struct {
-	Id         string `json:"id"`
-	Name       string `json:"name"`
-	Status     string `json:"status"`
-	AuthorType string `json:"authorType"`
-	AuthorId   string `json:"authorId"`
-	Version    int    `json:"version"`
-	CreatedAt  string `json:"createdAt,"`
+	Id             string `json:"id"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	Status         string `json:"status"`
+	TenderId       string `json:"tenderId"`
+	AuthorType     string `json:"authorType"`
+	AuthorId       string `json:"authorId"`
+	OrganizationId string `json:"organizationId,omitempty"`
+	Version        int    `json:"version"`
+	CreatedAt      string `json:"createdAt,"`
+	Signed         bool   `json:"signed"`
+
+	WithdrawalReason string `json:"withdrawalReason,omitempty"`
+	WithdrawnAt      string `json:"withdrawnAt,omitempty"`
+
+	ConflictOfInterest bool `json:"conflictOfInterest"`
+
+	// заполняются только в списке предложений по тендеру
+	AuthorReputation       *ReputationOutputModel `json:"authorReputation,omitempty"`
+	OrganizationReputation *ReputationOutputModel `json:"organizationReputation,omitempty"`
+}
+
+// Версия предложения с тем, кто и каким действием ее создал
+type BidVersion struct {
+	Version           int
+	Name              string
+	Description       string
+	CreatedBy         uuid.NullUUID
+	CreatedByUsername string
+	Action            string
+	CreatedAt         string
+	Signed            bool
+}
+
+type BidVersionOutputModel struct {
+	Version     int    `json:"version"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CreatedBy   string `json:"createdBy,omitempty"`
+	Action      string `json:"action"`
+	CreatedAt   string `json:"createdAt"`
+	Signed      bool   `json:"signed"`
 }
@@ -0,0 +1,57 @@
+package entity
+
+import "github.com/google/uuid"
+
+// db model
+type Contract struct {
+	Id                     uuid.UUID
+	TenderId               uuid.UUID
+	BidId                  uuid.UUID
+	BidVersion             int
+	CustomerOrganizationId uuid.UUID
+	SupplierId             uuid.UUID
+	SupplierOrganizationId *uuid.UUID // только для предложений от имени организации
+	Price                  *float64   // nil, пока заказчик не заполнил черновик
+	StartDate              string
+	EndDate                string
+	Status                 string
+	CustomerSignedAt       string
+	SupplierSignedAt       string
+	TerminationReason      string
+	CreatedAt              string
+	UpdatedAt              string
+}
+
+// service + repo input model, черновик перезаписывается целиком
+type EditContractInput struct {
+	Price     float64 // given
+	StartDate string  // given: 2006-01-02
+	EndDate   string  // given: 2006-01-02
+}
+
+// service + repo input model, пустые поля не фильтруют
+type ContractFilter struct {
+	TenderId       string
+	Status         string
+	OrganizationId string
+}
+
+// controller model
+type ContractOutputModel struct {
+	Id                     string   `json:"id"`
+	TenderId               string   `json:"tenderId"`
+	BidId                  string   `json:"bidId"`
+	BidVersion             int      `json:"bidVersion"`
+	CustomerOrganizationId string   `json:"customerOrganizationId"`
+	SupplierId             string   `json:"supplierId"`
+	SupplierOrganizationId string   `json:"supplierOrganizationId,omitempty"`
+	Price                  *float64 `json:"price"`
+	StartDate              string   `json:"startDate,omitempty"`
+	EndDate                string   `json:"endDate,omitempty"`
+	Status                 string   `json:"status"`
+	CustomerSignedAt       string   `json:"customerSignedAt,omitempty"`
+	SupplierSignedAt       string   `json:"supplierSignedAt,omitempty"`
+	TerminationReason      string   `json:"terminationReason,omitempty"`
+	CreatedAt              string   `json:"createdAt"`
+	UpdatedAt              string   `json:"updatedAt"`
+}
@@ -0,0 +1,57 @@
+package entity
+
+import "github.com/google/uuid"
+
+// db model
+type ApprovalDelegation struct {
+	Id                uuid.UUID
+	OrganizationId    uuid.UUID
+	DelegatorId       uuid.UUID
+	DelegatorUsername string
+	DelegateId        uuid.UUID
+	DelegateUsername  string
+	StartsOn          string // 2006-01-02
+	EndsOn            string // 2006-01-02, включительно
+	CreatedAt         string
+}
+
+// service + repo input model
+type CreateApprovalDelegationInput struct {
+	OrganizationId uuid.UUID
+	DelegatorId    uuid.UUID
+	DelegateId     uuid.UUID
+	StartsOn       string // given: 2006-01-02
+	EndsOn         string // given: 2006-01-02
+}
+
+// controller model
+type ApprovalDelegationOutputModel struct {
+	Id                string `json:"id"`
+	OrganizationId    string `json:"organizationId"`
+	DelegatorId       string `json:"delegatorId"`
+	DelegatorUsername string `json:"delegatorUsername"`
+	DelegateId        string `json:"delegateId"`
+	DelegateUsername  string `json:"delegateUsername"`
+	StartsOn          string `json:"startsOn"`
+	EndsOn            string `json:"endsOn"`
+	CreatedAt         string `json:"createdAt"`
+}
+
+// Голос по предложению. Если голосовал делегат, голос все равно принадлежит делегирующему
+type BidVote struct {
+	EmployeeId       uuid.UUID
+	EmployeeUsername string
+	DelegateId       uuid.NullUUID
+	DelegateUsername string
+	Decision         string
+	CreatedAt        string
+}
+
+type BidVoteOutputModel struct {
+	EmployeeId       string `json:"employeeId"`
+	EmployeeUsername string `json:"employeeUsername"`
+	DelegateId       string `json:"delegateId,omitempty"`
+	DelegateUsername string `json:"delegateUsername,omitempty"`
+	Decision         string `json:"decision"`
+	CreatedAt        string `json:"createdAt"`
+}
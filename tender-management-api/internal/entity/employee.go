@@ -0,0 +1,24 @@
+package entity
+
+import "github.com/google/uuid"
+
+// db model
+type Employee struct {
+	Id       uuid.UUID
+	Username string
+	Email    string
+	Locale   string
+}
+
+// Организация, в которой состоит сотрудник
+type Membership struct {
+	OrganizationId   uuid.UUID
+	OrganizationName string
+}
+
+// controller model
+type MembershipOutputModel struct {
+	OrganizationId   string   `json:"organizationId"`
+	OrganizationName string   `json:"organizationName"`
+	Roles            []string `json:"roles"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "github.com/google/uuid"
+
+// db model
+type Event struct {
+	Id             int64
+	TenantId       uuid.UUID
+	Type           string
+	TenderId       uuid.UUID
+	BidId          uuid.NullUUID
+	OrganizationId uuid.UUID
+	BidAuthorId    uuid.NullUUID
+	ServiceType    string
+	Status         string
+	Decision       string
+	CreatedAt      string
+}
+
+// controller model
+type EventOutputModel struct {
+	Id          int64  `json:"id"`
+	Type        string `json:"type"`
+	TenderId    string `json:"tenderId"`
+	BidId       string `json:"bidId,omitempty"`
+	ServiceType string `json:"serviceType,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Decision    string `json:"decision,omitempty"`
+	CreatedAt   string `json:"createdAt"`
+}
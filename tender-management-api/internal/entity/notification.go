@@ -0,0 +1,41 @@
+package entity
+
+import "github.com/google/uuid"
+
+// db model
+type Notification struct {
+	Id         uuid.UUID
+	EmployeeId uuid.UUID
+	IsRead     bool
+	CreatedAt  string
+	Event      Event
+}
+
+type NotificationPreference struct {
+	EventType string
+	Enabled   bool
+}
+
+// controller model
+type NotificationOutputModel struct {
+	Id        string `json:"id"`
+	Type      string `json:"type"`
+	TenderId  string `json:"tenderId"`
+	BidId     string `json:"bidId,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Decision  string `json:"decision,omitempty"`
+	IsRead    bool   `json:"isRead"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// controller model
+type NotificationsOutputModel struct {
+	UnreadCount   int                       `json:"unreadCount"`
+	Notifications []NotificationOutputModel `json:"notifications"`
+}
+
+// controller model
+type NotificationPreferenceOutputModel struct {
+	EventType string `json:"eventType"`
+	Enabled   bool   `json:"enabled"`
+}
@@ -0,0 +1,58 @@
+package entity
+
+import "github.com/google/uuid"
+
+// db model
+type OrganizationRelation struct {
+	Id                      uuid.UUID
+	OrganizationId          uuid.UUID
+	OrganizationName        string
+	RelatedOrganizationId   uuid.UUID
+	RelatedOrganizationName string
+	Type                    string // Parent: организация владеет связанной, Affiliate: связь без направления
+	CreatedAt               string
+}
+
+// Связь организации автора предложения с организацией тендера
+type OrganizationConflict struct {
+	BidId            uuid.UUID // uuid.Nil при проверке еще не созданного предложения
+	OrganizationId   uuid.UUID // организация автора предложения
+	OrganizationName string
+	Reason           string
+}
+
+// service + repo input model
+type CreateOrganizationRelationInput struct {
+	OrganizationId        uuid.UUID
+	RelatedOrganizationId uuid.UUID
+	Type                  string
+	CreatedBy             uuid.UUID
+}
+
+// controller model
+type OrganizationRelationOutputModel struct {
+	Id                      string `json:"id"`
+	OrganizationId          string `json:"organizationId"`
+	OrganizationName        string `json:"organizationName"`
+	RelatedOrganizationId   string `json:"relatedOrganizationId"`
+	RelatedOrganizationName string `json:"relatedOrganizationName"`
+	Type                    string `json:"type"`
+	CreatedAt               string `json:"createdAt"`
+}
+
+type OrganizationConflictOutputModel struct {
+	OrganizationId   string `json:"organizationId"`
+	OrganizationName string `json:"organizationName"`
+	Reason           string `json:"reason"`
+}
+
+type BidConflictOutputModel struct {
+	Bid       BidOutputModel                    `json:"bid"`
+	Conflicts []OrganizationConflictOutputModel `json:"conflicts"`
+}
+
+type TenderConflictReportOutputModel struct {
+	TenderId       string                   `json:"tenderId"`
+	ConflictPolicy string                   `json:"conflictPolicy"`
+	Bids           []BidConflictOutputModel `json:"bids"`
+}
@@ -3,16 +3,116 @@ package entity
 import "github.com/google/uuid"
 
 type Review struct {
-	Id          uuid.UUID
-	Description string
-	CreatedAt   string
-	AuthorId    uuid.UUID
-	ReceiverId  uuid.UUID
-	BidId       string
+	Id                   uuid.UUID
+	Description          string
+	CreatedAt            string
+	AuthorId             uuid.UUID
+	ReceiverId           uuid.UUID
+	BidId                string
+	QualityRating        int // 0, если отзыв оставлен до появления оценок
+	TimelinessRating     int
+	CommunicationRating  int
+	ReceiverOrganization *uuid.UUID
+	Version              int
+	UpdatedAt            string // пусто, если отзыв не редактировали
+	Reply                string
+	RepliedAt            string
+	HiddenAt             string // пусто, если отзыв не скрыт модератором
+	HiddenBy             *uuid.UUID
+	HiddenReason         string
+	ContractId           *uuid.UUID // nil для отзывов, оставленных до появления контрактов
+
+	TenderId                 uuid.UUID
+	ReviewerOrganizationId   uuid.UUID // организация тендера, от имени которой оставлен отзыв
+	ReviewerOrganizationName string
+	AuthorUsername           string
+}
+
+// Предыдущее содержимое отзыва, сохраняемое при редактировании
+type ReviewVersion struct {
+	Version             int
+	Description         string
+	QualityRating       int
+	TimelinessRating    int
+	CommunicationRating int
+	CreatedAt           string
+}
+
+// service + repo input model
+type CreateReviewInput struct {
+	BidId                string     // given
+	ContractId           uuid.UUID  // should be set: завершенный контракт по предложению
+	AuthorId             uuid.UUID  // given
+	ReceiverId           uuid.UUID  // should be set: bid author
+	ReceiverOrganization *uuid.UUID // should be set for organization bids
+	Description          string     // given
+	QualityRating        int        // given: 1-5
+	TimelinessRating     int        // given: 1-5
+	CommunicationRating  int        // given: 1-5
+}
+
+// service + repo input model, пустые поля не фильтруют
+type ReviewFilter struct {
+	MinRating              float64  // средняя из трех оценок
+	MaxRating              float64  // средняя из трех оценок
+	DateFrom               string   // 2006-01-02, включительно
+	DateTo                 string   // 2006-01-02, включительно
+	ReviewerOrganizationId string   // организация, оставившая отзыв
+	Comparable             bool     // только тендеры того же типа услуг, что и текущий
+	ServiceTypes           []string // should be set, если Comparable
+}
+
+// service + repo input model, отзыв перезаписывается целиком
+type EditReviewInput struct {
+	Description         string // given
+	QualityRating       int    // given: 1-5
+	TimelinessRating    int    // given: 1-5
+	CommunicationRating int    // given: 1-5
 }
 
 type ReviewOutputModel struct {
-	Id          string `json:"id"`
-	Description string `json:"description"`
-	CreatedAt   string `json:"createdAt"`
+	Id                       string `json:"id"`
+	BidId                    string `json:"bidId"`
+	ContractId               string `json:"contractId,omitempty"`
+	TenderId                 string `json:"tenderId"`
+	AuthorUsername           string `json:"authorUsername"`
+	ReviewerOrganizationId   string `json:"reviewerOrganizationId"`
+	ReviewerOrganizationName string `json:"reviewerOrganizationName"`
+	Description              string `json:"description"`
+	QualityRating            int    `json:"qualityRating,omitempty"`
+	TimelinessRating         int    `json:"timelinessRating,omitempty"`
+	CommunicationRating      int    `json:"communicationRating,omitempty"`
+	Version                  int    `json:"version"`
+	CreatedAt                string `json:"createdAt"`
+	UpdatedAt                string `json:"updatedAt,omitempty"`
+	Reply                    string `json:"reply,omitempty"`
+	RepliedAt                string `json:"repliedAt,omitempty"`
+	Hidden                   bool   `json:"hidden,omitempty"`
+	HiddenReason             string `json:"hiddenReason,omitempty"`
+}
+
+type ReviewVersionOutputModel struct {
+	Version             int    `json:"version"`
+	Description         string `json:"description"`
+	QualityRating       int    `json:"qualityRating,omitempty"`
+	TimelinessRating    int    `json:"timelinessRating,omitempty"`
+	CommunicationRating int    `json:"communicationRating,omitempty"`
+	CreatedAt           string `json:"createdAt"`
+}
+
+// Взвешенные по давности средние оценки сотрудника или организации
+type Reputation struct {
+	SubjectId     uuid.UUID
+	Quality       float64
+	Timeliness    float64
+	Communication float64
+	ReviewsCount  int
+}
+
+type ReputationOutputModel struct {
+	Score         float64 `json:"score"`
+	Quality       float64 `json:"quality"`
+	Timeliness    float64 `json:"timeliness"`
+	Communication float64 `json:"communication"`
+	ReviewsCount  int     `json:"reviewsCount"`
 }
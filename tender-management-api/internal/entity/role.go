@@ -0,0 +1,33 @@
+package entity
+
+import "github.com/google/uuid"
+
+// db model
+type RoleAssignment struct {
+	Id               uuid.UUID
+	OrganizationId   uuid.UUID
+	EmployeeId       uuid.UUID
+	EmployeeUsername string
+	Role             string
+	GrantedBy        uuid.NullUUID
+	CreatedAt        string
+}
+
+// service + repo input model
+type CreateRoleAssignmentInput struct {
+	OrganizationId uuid.UUID
+	EmployeeId     uuid.UUID
+	Role           string
+	GrantedBy      uuid.UUID
+}
+
+// controller model
+type RoleAssignmentOutputModel struct {
+	Id               string `json:"id"`
+	OrganizationId   string `json:"organizationId"`
+	EmployeeId       string `json:"employeeId"`
+	EmployeeUsername string `json:"employeeUsername"`
+	Role             string `json:"role"`
+	GrantedBy        string `json:"grantedBy,omitempty"`
+	CreatedAt        string `json:"createdAt"`
+}
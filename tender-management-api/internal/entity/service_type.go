@@ -0,0 +1,25 @@
+package entity
+
+// db model
+type ServiceType struct {
+	Code       string
+	Name       string
+	ParentCode string // пустая строка, если категория верхнего уровня
+	CreatedAt  string
+}
+
+// service input model
+type ServiceTypeInput struct {
+	Code       string // given
+	Name       string // given
+	ParentCode string // given, optional
+	Username   string // given
+}
+
+// controller model
+type ServiceTypeOutputModel struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	ParentCode string `json:"parentCode,omitempty"`
+	CreatedAt  string `json:"createdAt"`
+}
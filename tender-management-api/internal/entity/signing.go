@@ -0,0 +1,52 @@
+package entity
+
+import "github.com/google/uuid"
+
+// db model
+type EmployeeKey struct {
+	Id         uuid.UUID
+	EmployeeId uuid.UUID
+	PublicKey  []byte
+	CreatedAt  string
+	RevokedAt  string // пусто, пока ключ действует
+}
+
+// Содержимое версии предложения вместе с ее подписью
+type BidVersionSignature struct {
+	BidId        uuid.UUID
+	Version      int
+	Name         string
+	Description  string
+	Signature    []byte     // nil, если версия не подписана
+	SigningKeyId *uuid.UUID // nil, если версия не подписана
+	SignedAt     string
+	Attachments  []Attachment // вложения версии, входят в подписываемые данные
+}
+
+// controller model
+type EmployeeKeyOutputModel struct {
+	Id        string `json:"id"`
+	PublicKey string `json:"publicKey"`
+	CreatedAt string `json:"createdAt"`
+	RevokedAt string `json:"revokedAt,omitempty"`
+}
+
+type BidVersionPayloadOutputModel struct {
+	BidId   string `json:"bidId"`
+	Version int    `json:"version"`
+	Payload string `json:"payload"` // каноничный JSON, который подписывается как есть
+}
+
+type BidSignatureVerificationOutputModel struct {
+	BidId      string `json:"bidId"`
+	Version    int    `json:"version"`
+	Payload    string `json:"payload"`
+	Signed     bool   `json:"signed"`
+	Valid      bool   `json:"valid"`
+	SignerId   string `json:"signerId,omitempty"`
+	KeyId      string `json:"keyId,omitempty"`
+	PublicKey  string `json:"publicKey,omitempty"`
+	KeyRevoked bool   `json:"keyRevoked"`
+	Signature  string `json:"signature,omitempty"`
+	SignedAt   string `json:"signedAt,omitempty"`
+}
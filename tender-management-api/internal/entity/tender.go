@@ -1,42 +1,75 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
 // db model
 type Tender struct {
-	Id             uuid.UUID `json:"id" db:"id"`
-	Name           string    `json:"name" db:"name"`
-	Description    string    `json:"description" db:"description"`
-	ServiceType    string    `json:"serviceType" db:"service_type"`
-	Status         string    `json:"status" db:"status"`
-	OrganizationId uuid.UUID `json:"organizationId" db:"organization_id"`
-	Version        int       `json:"version" db:"version"`
-	CreatedAt      string    `json:"createdAt" db:"created_at"`
+	Id                uuid.UUID         `json:"id" db:"id"`
+	Name              string            `json:"name" db:"name"`
+	Description       string            `json:"description" db:"description"`
+	ServiceType       string            `json:"serviceType" db:"service_type"`
+	Status            string            `json:"status" db:"status"`
+	OrganizationId    uuid.UUID         `json:"organizationId" db:"organization_id"`
+	Version           int               `json:"version" db:"version"`
+	CreatedAt         string            `json:"createdAt" db:"created_at"`
+	Deadline          string            `json:"deadline" db:"deadline"`
+	Tags              []string          `json:"tags" db:"tags"`
+	Attributes        map[string]string `json:"attributes" db:"attributes"`
+	RequiresSignature bool              `json:"requiresSignature" db:"requires_signature"`
+	ConflictPolicy    string            `json:"conflictPolicy" db:"conflict_policy"`
 }
 
 // service + repo input model
 type CreateTenderInput struct {
-	Name            string // given
-	Description     string // given
-	ServiceType     string // given
-	OrganizationId  string // given
-	CreatorUsername string // given
-	Status          string // should be set: "Created"
-	Version         int    // should be set: 1
+	Name              string            // given
+	Description       string            // given
+	ServiceType       string            // given
+	OrganizationId    string            // given
+	CreatorUsername   string            // given
+	Deadline          *time.Time        // given, optional
+	Tags              []string          // given, optional
+	Attributes        map[string]string // given, validated against organization attribute definitions
+	RequiresSignature bool              // given: предложения должны быть подписаны
+	ConflictPolicy    string            // given, optional: "Flag" by default
+	Status            string            // should be set: "Created"
+	Version           int               // should be set: 1
 	// Id UUID sets automatically
 	// CreatedAt sets automatically
 }
 
 // controller model
 type TenderOutputModel struct {
-	Id             string `json:"id"`
-	Name           string `json:"name"`
-	Description    string `json:"description"`
-	ServiceType    string `json:"serviceType"`
-	Status         string `json:"status"`
-	OrganizationId string `json:"organizationId"`
-	Version        int    `json:"version"`
-	CreatedAt      string `json:"createdAt"`
+	Id                string            `json:"id"`
+	Name              string            `json:"name"`
+	Description       string            `json:"description"`
+	ServiceType       string            `json:"serviceType"`
+	Status            string            `json:"status"`
+	OrganizationId    string            `json:"organizationId"`
+	Version           int               `json:"version"`
+	CreatedAt         string            `json:"createdAt"`
+	Deadline          string            `json:"deadline,omitempty"`
+	Tags              []string          `json:"tags"`
+	Attributes        map[string]string `json:"attributes"`
+	RequiresSignature bool              `json:"requiresSignature"`
+	ConflictPolicy    string            `json:"conflictPolicy"`
+}
+
+// service + repo input model
+type EditTenderInput struct {
+	Name        string            // given, optional
+	Description string            // given, optional
+	ServiceType string            // given, optional
+	Tags        []string          // given, optional: nil keeps previous tags
+	Attributes  map[string]string // given, optional: merged into previous attributes, empty value removes attribute
+}
+
+// repo input model
+type TenderFilter struct {
+	ServiceTypes []string
+	Tags         []string          // tender should have all given tags
+	Attributes   map[string]string // tender should have all given attribute values
 }
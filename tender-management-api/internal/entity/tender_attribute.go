@@ -0,0 +1,33 @@
+package entity
+
+// db model
+type TenderAttributeDefinition struct {
+	OrganizationId string
+	Code           string
+	Name           string
+	Type           string
+	Required       bool
+	AllowedValues  []string
+	CreatedAt      string
+}
+
+// service input model
+type TenderAttributeDefinitionInput struct {
+	OrganizationId string   // given
+	Code           string   // given
+	Name           string   // given
+	Type           string   // given: string, number, boolean, date or enum
+	Required       bool     // given
+	AllowedValues  []string // given, required for enum
+	Username       string   // given
+}
+
+// controller model
+type TenderAttributeDefinitionOutputModel struct {
+	Code          string   `json:"code"`
+	Name          string   `json:"name"`
+	Type          string   `json:"type"`
+	Required      bool     `json:"required"`
+	AllowedValues []string `json:"allowedValues"`
+	CreatedAt     string   `json:"createdAt"`
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// db model
+type TenderTemplate struct {
+	Id                uuid.UUID
+	OrganizationId    uuid.UUID
+	Name              string
+	TenderName        string
+	TenderDescription string
+	ServiceType       string
+	CreatedAt         string
+}
+
+// service + repo input model
+type CreateTenderTemplateInput struct {
+	Name              string // given
+	TenderName        string // given, may contain placeholders like {{month}}
+	TenderDescription string // given, may contain placeholders like {{month}}
+	ServiceType       string // given
+	OrganizationId    string // given
+	CreatorUsername   string // given
+}
+
+// service input model
+type CreateTenderFromTemplateInput struct {
+	TemplateId      string            // given
+	CreatorUsername string            // given
+	Values          map[string]string // given: values for placeholders
+	Deadline        *time.Time        // given, optional
+	Tags            []string          // given, optional
+	Attributes      map[string]string // given, optional
+}
+
+// controller model
+type TenderTemplateOutputModel struct {
+	Id                string   `json:"id"`
+	Name              string   `json:"name"`
+	OrganizationId    string   `json:"organizationId"`
+	TenderName        string   `json:"tenderName"`
+	TenderDescription string   `json:"tenderDescription"`
+	ServiceType       string   `json:"serviceType"`
+	Placeholders      []string `json:"placeholders"`
+	CreatedAt         string   `json:"createdAt"`
+}
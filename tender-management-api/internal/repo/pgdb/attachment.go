@@ -0,0 +1,287 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"tender-management-api/internal/common"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo/repo_errors"
+	"tender-management-api/pkg/postgres"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+type AttachmentRepo struct {
+	*postgres.Postgres
+}
+
+func NewAttachmentRepo(pgdb *postgres.Postgres) *AttachmentRepo {
+	return &AttachmentRepo{pgdb}
+}
+
+// Вложения привязаны к строке версии, а не к её номеру: при откате версии строка получает новый номер,
+// и вложения переезжают вместе с ней
+type attachmentOwner struct {
+	table          string
+	versionTable   string
+	ownerColumn    string
+	versionColumn  string
+	contentColumns []string
+	// действие, которым загрузка создает версию, если в версиях хранится, кто и каким действием их создал
+	versionAction string
+	// вызывается в транзакции после создания новой версии, если владельцу нужно что-то обновить вслед за ней
+	afterNewVersion func(tx *sql.Tx, builder squirrel.StatementBuilderType, ownerId uuid.UUID, actorId uuid.UUID) error
+}
+
+func tenderAttachmentOwner() *attachmentOwner {
+	return &attachmentOwner{
+		table:          "tender",
+		versionTable:   "tender_version",
+		ownerColumn:    "tender_id",
+		versionColumn:  "tender_version_id",
+		contentColumns: []string{"name", "description", "service_type", "tags", "attributes"},
+	}
+}
+
+func bidAttachmentOwner() *attachmentOwner {
+	return &attachmentOwner{
+		table:           "bid",
+		versionTable:    "bid_version",
+		ownerColumn:     "bid_id",
+		versionColumn:   "bid_version_id",
+		contentColumns:  []string{"name", "description"},
+		versionAction:   common.AttachBidAction,
+		afterNewVersion: returnUnsignedBidToCreated,
+	}
+}
+
+const attachmentColumns = "attachment.id, attachment.file_name, attachment.content_type, attachment.size, " +
+	"attachment.checksum, attachment.storage_key, attachment.author_id, attachment.created_at"
+
+func scanAttachment(row rowScanner) (*entity.Attachment, error) {
+	var attachment entity.Attachment
+	var authorId uuid.NullUUID
+	var createdAt time.Time
+	err := row.Scan(&attachment.Id, &attachment.FileName, &attachment.ContentType, &attachment.Size,
+		&attachment.Checksum, &attachment.StorageKey, &authorId, &createdAt)
+	attachment.AuthorId = authorId.UUID
+	attachment.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return &attachment, err
+}
+
+// Вложения предыдущей версии переносятся в новую, ссылаясь на те же объекты в хранилище
+func copyVersionAttachments(tx *sql.Tx, builder squirrel.StatementBuilderType, versionColumn string, fromVersionId, toVersionId uuid.UUID) error {
+	selectReq := squirrel.
+		Select().
+		Column("?", toVersionId).
+		Columns("file_name", "content_type", "size", "checksum", "storage_key", "author_id", "created_at").
+		From("attachment").
+		Where(versionColumn+" = ?", fromVersionId)
+
+	copySql, args, _ := builder.
+		Insert("attachment").
+		Columns(versionColumn, "file_name", "content_type", "size", "checksum", "storage_key", "author_id", "created_at").
+		Select(selectReq).
+		ToSql()
+
+	_, err := tx.Exec(copySql, args...)
+
+	return err
+}
+
+// Загрузка вложения создаёт новую версию тендера или бида с тем же содержимым и дополненным списком вложений
+func (r *AttachmentRepo) addAttachment(ctx context.Context, owner *attachmentOwner, ownerId uuid.UUID, actorId uuid.UUID, attachment *entity.Attachment) error {
+	tx, err := r.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	updateVersionSql, args, _ := r.SqlBuilder.
+		Update(owner.table).
+		Set("current_version", squirrel.Expr("current_version + ?", 1)).
+		Where("id = ?", ownerId).
+		Suffix("RETURNING current_version").
+		ToSql()
+
+	var currentVersion int
+	if err = tx.QueryRow(updateVersionSql, args...).Scan(&currentVersion); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return repo_errors.ErrNotFound
+		}
+
+		return err
+	}
+
+	prevVersionSql, args, _ := r.SqlBuilder.
+		Select("id").
+		From(owner.versionTable).
+		Where(owner.ownerColumn+" = ?", ownerId).
+		Where("version = ?", currentVersion-1).
+		ToSql()
+
+	var prevVersionId uuid.UUID
+	if err = tx.QueryRow(prevVersionSql, args...).Scan(&prevVersionId); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
+	versionColumns := append(owner.contentColumns, "version", owner.ownerColumn)
+	copyContentReq := squirrel.
+		Select(owner.contentColumns...).
+		Column("?", currentVersion).
+		Column(owner.ownerColumn).
+		From(owner.versionTable).
+		Where("id = ?", prevVersionId)
+	if owner.versionAction != "" {
+		versionColumns = append(versionColumns, "created_by", "action")
+		copyContentReq = copyContentReq.Column("?", actorId).Column("?", owner.versionAction)
+	}
+
+	createVersionSql, args, _ := r.SqlBuilder.
+		Insert(owner.versionTable).
+		Columns(versionColumns...).
+		Select(copyContentReq).
+		Suffix("RETURNING id").
+		ToSql()
+
+	var newVersionId uuid.UUID
+	if err = tx.QueryRow(createVersionSql, args...).Scan(&newVersionId); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
+	if err = copyVersionAttachments(tx, r.SqlBuilder, owner.versionColumn, prevVersionId, newVersionId); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
+	createAttachmentSql, args, _ := r.SqlBuilder.
+		Insert("attachment").
+		Columns("id", owner.versionColumn, "file_name", "content_type", "size", "checksum", "storage_key", "author_id").
+		Values(attachment.Id, newVersionId, attachment.FileName, attachment.ContentType, attachment.Size,
+			attachment.Checksum, attachment.StorageKey, attachment.AuthorId).
+		ToSql()
+
+	if _, err = tx.Exec(createAttachmentSql, args...); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
+	if owner.afterNewVersion != nil {
+		if err = owner.afterNewVersion(tx, r.SqlBuilder, ownerId, actorId); err != nil {
+			if e := tx.Rollback(); e != nil {
+				return e
+			}
+
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *AttachmentRepo) getAttachments(ctx context.Context, owner *attachmentOwner, ownerId uuid.UUID, version int) ([]entity.Attachment, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select(attachmentColumns).
+		From("attachment").
+		InnerJoin(owner.versionTable+" on attachment."+owner.versionColumn+" = "+owner.versionTable+".id").
+		Where(owner.versionTable+"."+owner.ownerColumn+" = ?", ownerId).
+		Where(owner.versionTable+".version = ?", version).
+		OrderBy("attachment.created_at ASC").
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := make([]entity.Attachment, 0)
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return attachments, err
+		}
+		attachments = append(attachments, *attachment)
+	}
+	if err = rows.Err(); err != nil {
+		return attachments, err
+	}
+
+	return attachments, nil
+}
+
+// Вложение ищется среди всех версий, чтобы по ссылке можно было скачать файл и из старой версии
+func (r *AttachmentRepo) getAttachmentById(ctx context.Context, owner *attachmentOwner, ownerId uuid.UUID, attachmentId string) (*entity.Attachment, error) {
+	uuidForm, err := uuid.Parse(attachmentId)
+	if err != nil {
+		return nil, repo_errors.ErrNotFound
+	}
+
+	sqlReq, args, _ := r.SqlBuilder.
+		Select(attachmentColumns).
+		From("attachment").
+		InnerJoin(owner.versionTable+" on attachment."+owner.versionColumn+" = "+owner.versionTable+".id").
+		Where(owner.versionTable+"."+owner.ownerColumn+" = ?", ownerId).
+		Where("attachment.id = ?", uuidForm).
+		ToSql()
+
+	attachment, err := scanAttachment(r.Database.QueryRowContext(ctx, sqlReq, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return attachment, repo_errors.ErrNotFound
+		}
+
+		return attachment, err
+	}
+
+	return attachment, nil
+}
+
+func (r *AttachmentRepo) AddTenderAttachment(ctx context.Context, tenderId uuid.UUID, actorId uuid.UUID, attachment *entity.Attachment) error {
+	return r.addAttachment(ctx, tenderAttachmentOwner(), tenderId, actorId, attachment)
+}
+
+// Новая версия бида приписывается actorId с действием Attach
+func (r *AttachmentRepo) AddBidAttachment(ctx context.Context, bidId uuid.UUID, actorId uuid.UUID, attachment *entity.Attachment) error {
+	return r.addAttachment(ctx, bidAttachmentOwner(), bidId, actorId, attachment)
+}
+
+func (r *AttachmentRepo) GetTenderAttachments(ctx context.Context, tenderId uuid.UUID, version int) ([]entity.Attachment, error) {
+	return r.getAttachments(ctx, tenderAttachmentOwner(), tenderId, version)
+}
+
+func (r *AttachmentRepo) GetBidAttachments(ctx context.Context, bidId uuid.UUID, version int) ([]entity.Attachment, error) {
+	return r.getAttachments(ctx, bidAttachmentOwner(), bidId, version)
+}
+
+func (r *AttachmentRepo) GetTenderAttachmentById(ctx context.Context, tenderId uuid.UUID, attachmentId string) (*entity.Attachment, error) {
+	return r.getAttachmentById(ctx, tenderAttachmentOwner(), tenderId, attachmentId)
+}
+
+func (r *AttachmentRepo) GetBidAttachmentById(ctx context.Context, bidId uuid.UUID, attachmentId string) (*entity.Attachment, error) {
+	return r.getAttachmentById(ctx, bidAttachmentOwner(), bidId, attachmentId)
+}
@@ -22,8 +22,62 @@ func NewBidRepo(pgdb *postgres.Postgres) *BidRepo {
 	return &BidRepo{pgdb}
 }
 
+const bidColumns = "bid.id, bid_version.name, bid_version.description, bid.status, bid.decision, bid.tender_id, " +
+	"bid.author_id, bid.author_type, bid.organization_id, bid.created_at, bid.current_version, bid_version.signature IS NOT NULL, " +
+	"coalesce(bid.withdrawal_reason, ''), bid.withdrawn_at, bid.withdrawal_consented_by IS NOT NULL, bid.conflict_of_interest"
+
+func scanBid(row rowScanner) (*entity.Bid, error) {
+	var bid entity.Bid
+	var organizationId uuid.NullUUID
+	var createdAt time.Time
+	var withdrawnAt sql.NullTime
+	err := row.Scan(&bid.Id, &bid.Name, &bid.Description, &bid.Status, &bid.Decision, &bid.TenderId,
+		&bid.AuthorId, &bid.AuthorType, &organizationId, &createdAt, &bid.Version, &bid.Signed,
+		&bid.WithdrawalReason, &withdrawnAt, &bid.WithdrawalConsented, &bid.ConflictOfInterest)
+	if err != nil {
+		return nil, err
+	}
+
+	if organizationId.Valid {
+		bid.OrganizationId = &organizationId.UUID
+	}
+	bid.CreatedAt = createdAt.Format(time.RFC3339)
+	bid.WithdrawnAt = formatNullTime(withdrawnAt)
+
+	return &bid, nil
+}
+
+func (r *BidRepo) selectBids() squirrel.SelectBuilder {
+	return r.SqlBuilder.
+		Select(bidColumns).
+		From("bid").
+		InnerJoin("bid_version on bid.id = bid_version.bid_id and bid.current_version = bid_version.version")
+}
+
+func (r *BidRepo) queryBids(ctx context.Context, sqlReq string, args []any) ([]entity.Bid, error) {
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bids := make([]entity.Bid, 0)
+	for rows.Next() {
+		bid, err := scanBid(rows)
+		if err != nil {
+			return bids, err
+		}
+		bids = append(bids, *bid)
+	}
+	if err = rows.Err(); err != nil {
+		return bids, err
+	}
+
+	return bids, nil
+}
+
 func (r *BidRepo) CreateBid(ctx context.Context, input *entity.CreateBidInput) (uuid.UUID, error) {
-	tx, err := r.Database.Begin()
+	tx, err := r.Database.BeginTx(ctx, nil)
 	if err != nil {
 		if e := tx.Rollback(); e != nil {
 			return uuid.Nil, e
@@ -34,8 +88,8 @@ func (r *BidRepo) CreateBid(ctx context.Context, input *entity.CreateBidInput) (
 
 	createBidReq, args, _ := r.SqlBuilder.
 		Insert("bid").
-		Columns("status", "tender_id", "author_id", "author_type", "current_version").
-		Values(common.Created, input.TenderId, input.AuthorId, input.AuthorType, 1).
+		Columns("status", "tender_id", "author_id", "author_type", "organization_id", "current_version", "conflict_of_interest").
+		Values(common.Created, input.TenderId, input.AuthorId, input.AuthorType, input.OrganizationId, 1, input.ConflictOfInterest).
 		Suffix("RETURNING id").
 		RunWith(tx).
 		ToSql()
@@ -52,8 +106,8 @@ func (r *BidRepo) CreateBid(ctx context.Context, input *entity.CreateBidInput) (
 
 	createVersionReq, args, _ := r.SqlBuilder.
 		Insert("bid_version").
-		Columns("name", "description", "version", "bid_id").
-		Values(input.Name, input.Description, 1, bidId).
+		Columns("name", "description", "version", "bid_id", "created_by", "action").
+		Values(input.Name, input.Description, 1, bidId, input.AuthorId, common.CreateBidAction).
 		RunWith(tx).
 		ToSql()
 
@@ -79,32 +133,23 @@ func (r *BidRepo) GetBidById(ctx context.Context, id string) (*entity.Bid, error
 		return nil, err
 	}
 
-	getBidReq, args, _ := r.SqlBuilder.
-		Select("bid.id, bid_version.name, bid_version.description, bid.status, bid.decision, bid.tender_id, bid.author_id, bid.author_type, bid.created_at, bid.current_version").
-		From("bid").
-		InnerJoin("bid_version on bid.id = bid_version.bid_id and bid.current_version = bid_version.version").
+	getBidReq, args, _ := r.selectBids().
 		Where("bid.id = ?", uuidForm).
 		ToSql()
 
-	var bid entity.Bid
-	var createdAt time.Time
-	row := r.Database.QueryRow(getBidReq, args...)
-	err = row.Scan(&bid.Id, &bid.Name, &bid.Description, &bid.Status, &bid.Decision,
-		&bid.TenderId, &bid.AuthorId, &bid.AuthorType, &createdAt, &bid.Version)
-	bid.CreatedAt = createdAt.Format(time.RFC3339)
-
+	bid, err := scanBid(r.Database.QueryRowContext(ctx, getBidReq, args...))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return &bid, repo_errors.ErrNotFound
+			return &entity.Bid{}, repo_errors.ErrNotFound
 		}
 
-		return &bid, err
+		return &entity.Bid{}, err
 	}
 
-	return &bid, nil
+	return bid, nil
 }
 
-func (r *BidRepo) EditBidById(ctx context.Context, id string, name string, description string) error {
+func (r *BidRepo) EditBidById(ctx context.Context, id string, name string, description string, editorId uuid.UUID) error {
 	uuidForm, err := uuid.Parse(id)
 	if err != nil {
 		return err
@@ -119,55 +164,7 @@ func (r *BidRepo) EditBidById(ctx context.Context, id string, name string, descr
 		return err
 	}
 
-	updateVersionReq, args, _ := r.SqlBuilder.
-		Update("bid").
-		Set("current_version", squirrel.Expr("current_version + ?", 1)).
-		Where("id = ?", uuidForm).
-		Suffix("RETURNING current_version").
-		RunWith(tx).
-		ToSql()
-
-	var current_version int
-	err = tx.QueryRow(updateVersionReq, args...).Scan(&current_version)
-	if err != nil {
-		if e := tx.Rollback(); e != nil {
-			return e
-		}
-
-		return err
-	}
-
-	getOldValuesReq, args, _ := r.SqlBuilder.
-		Select("name", "description").
-		From("bid_version").
-		Where("bid_id = ?", uuidForm).
-		Where("version = ?", current_version-1).
-		RunWith(tx).
-		ToSql()
-
-	var prevName, prevDescription string
-	if err = tx.QueryRow(getOldValuesReq, args...).
-		Scan(&prevName, &prevDescription); err != nil {
-		return err
-	}
-
-	if name == "" {
-		name = prevName
-	}
-
-	if description == "" {
-		description = prevDescription
-	}
-
-	createVersionReq, args, _ := r.SqlBuilder.
-		Insert("bid_version").
-		Columns("name", "description", "version", "bid_id").
-		Values(name, description, current_version, uuidForm).
-		RunWith(tx).
-		ToSql()
-
-	_, err = tx.Exec(createVersionReq, args...)
-	if err != nil {
+	if err = createNextBidVersion(tx, r.SqlBuilder, uuidForm, name, description, editorId, common.EditBidAction); err != nil {
 		if e := tx.Rollback(); e != nil {
 			return e
 		}
@@ -182,7 +179,7 @@ func (r *BidRepo) EditBidById(ctx context.Context, id string, name string, descr
 	return nil
 }
 
-func (r *BidRepo) UpdateBidStatusById(ctx context.Context, id string, newStatus string) error {
+func (r *BidRepo) UpdateBidStatusById(ctx context.Context, id string, newStatus string, changedBy uuid.UUID) error {
 	uuidForm, err := uuid.Parse(id)
 	if err != nil {
 		return err
@@ -191,10 +188,11 @@ func (r *BidRepo) UpdateBidStatusById(ctx context.Context, id string, newStatus
 	updateStatusSql, args, _ := r.SqlBuilder.
 		Update("bid").
 		Set("status", newStatus).
+		Set("status_changed_by", changedBy).
 		Where("id = ?", uuidForm).
 		ToSql()
 
-	_, err = r.Database.Exec(updateStatusSql, args...)
+	_, err = r.Database.ExecContext(ctx, updateStatusSql, args...)
 	if err != nil {
 		return err
 	}
@@ -202,44 +200,21 @@ func (r *BidRepo) UpdateBidStatusById(ctx context.Context, id string, newStatus
 	return nil
 }
 
-func (r *BidRepo) GetUserBids(ctx context.Context, employeeId string, pg *entity.PaginationInput) ([]entity.Bid, error) {
-	uuidForm, err := uuid.Parse(employeeId)
-	if err != nil {
-		return nil, err
+// Предложения от имени переданных организаций и, если передан автор, его собственные
+func (r *BidRepo) GetUserBids(ctx context.Context, authorId uuid.NullUUID, organizationIds []uuid.UUID, pg *entity.PaginationInput) ([]entity.Bid, error) {
+	condition := squirrel.Or{squirrel.Eq{"bid.organization_id": organizationIds}}
+	if authorId.Valid {
+		condition = append(condition, squirrel.Eq{"bid.author_id": authorId.UUID})
 	}
 
-	getUserBidsReq, args, _ := r.SqlBuilder.
-		Select("bid.id, bid_version.name, bid_version.description, bid.status, bid.decision, bid.tender_id, bid.author_id, bid.author_type, bid.created_at, bid.current_version").
-		From("bid").
-		InnerJoin("bid_version on bid.id = bid_version.bid_id and bid.current_version = bid_version.version").
-		Where("author_id = ?", uuidForm).
+	getUserBidsReq, args, _ := r.selectBids().
+		Where(condition).
 		OrderBy("name ASC").
 		Offset(uint64(pg.Offset)).
 		Limit(uint64(pg.Limit)).
 		ToSql()
 
-	rows, err := r.Database.Query(getUserBidsReq, args...)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	bids := make([]entity.Bid, 0)
-	for rows.Next() {
-		var createdAt time.Time
-		var bid entity.Bid
-		if err := rows.Scan(&bid.Id, &bid.Name, &bid.Description, &bid.Status, &bid.Decision,
-			&bid.TenderId, &bid.AuthorId, &bid.AuthorType, &createdAt, &bid.Version); err != nil {
-			return bids, err
-		}
-		bid.CreatedAt = createdAt.Format(time.RFC3339)
-		bids = append(bids, bid)
-	}
-	if err = rows.Err(); err != nil {
-		return bids, err
-	}
-
-	return bids, nil
+	return r.queryBids(ctx, getUserBidsReq, args)
 }
 
 func (r *BidRepo) GetTenderBids(ctx context.Context, tenderId string, pg *entity.PaginationInput) ([]entity.Bid, error) {
@@ -248,42 +223,18 @@ func (r *BidRepo) GetTenderBids(ctx context.Context, tenderId string, pg *entity
 		return nil, err
 	}
 
-	getTenderBidsSql, args, _ := r.SqlBuilder.
-		Select("bid.id, bid_version.name, bid_version.description, bid.status, bid.decision, bid.tender_id, bid.author_id, bid.author_type, bid.created_at, bid.current_version").
-		From("bid").
-		InnerJoin("bid_version on bid.id = bid_version.bid_id and bid.current_version = bid_version.version").
+	getTenderBidsSql, args, _ := r.selectBids().
 		Where("tender_id = ?", uuidForm).
 		OrderBy("name ASC").
 		Offset(uint64(pg.Offset)).
 		Limit(uint64(pg.Limit)).
 		ToSql()
 
-	rows, err := r.Database.Query(getTenderBidsSql, args...)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	bids := make([]entity.Bid, 0)
-	for rows.Next() {
-		var bid entity.Bid
-		var createdAt time.Time
-		if err := rows.Scan(&bid.Id, &bid.Name, &bid.Description, &bid.Status, &bid.Decision,
-			&bid.TenderId, &bid.AuthorId, &bid.AuthorType, &createdAt, &bid.Version); err != nil {
-			return bids, err
-		}
-		bid.CreatedAt = createdAt.Format(time.RFC3339)
-		bids = append(bids, bid)
-	}
-	if err = rows.Err(); err != nil {
-		return bids, err
-	}
-
-	return bids, nil
+	return r.queryBids(ctx, getTenderBidsSql, args)
 }
 
-func submitReject(r *BidRepo, bidId uuid.UUID) error {
-	tx, err := r.Database.Begin()
+func submitReject(ctx context.Context, r *BidRepo, bidId uuid.UUID, employeeId uuid.UUID, delegateId uuid.NullUUID) error {
+	tx, err := r.Database.BeginTx(ctx, nil)
 	if err != nil {
 		if e := tx.Rollback(); e != nil {
 			return e
@@ -321,6 +272,14 @@ func submitReject(r *BidRepo, bidId uuid.UUID) error {
 		return err
 	}
 
+	if err := insertBidVote(tx, r.SqlBuilder, bidId, employeeId, delegateId, common.RejectedDecision); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return err
 	}
@@ -347,7 +306,7 @@ func (r *BidRepo) AlreadySubmitApprove(ctx context.Context, bidId string, employ
 		ToSql()
 
 	var uid uuid.UUID
-	err = r.Database.QueryRow(sqlReq, args...).Scan(&uid)
+	err = r.Database.QueryRowContext(ctx, sqlReq, args...).Scan(&uid)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, nil
@@ -359,7 +318,10 @@ func (r *BidRepo) AlreadySubmitApprove(ctx context.Context, bidId string, employ
 	return true, nil
 }
 
-func (r *BidRepo) SubmitBidDecision(ctx context.Context, bidId string, decision string, employeeId string, organizationId uuid.UUID) error {
+// Голос делегата записывается от имени делегирующего (employeeId), но в кворум идут разные люди,
+// подавшие голоса: делегат, проголосовавший и за себя, и за другого, учитывается один раз.
+// votersCnt - число сотрудников с правом голосовать в организации тендера, от него зависит кворум
+func (r *BidRepo) SubmitBidDecision(ctx context.Context, bidId string, decision string, employeeId string, delegateId uuid.NullUUID, votersCnt int) error {
 	bidUuid, err := uuid.Parse(bidId)
 	if err != nil {
 		return err
@@ -371,10 +333,10 @@ func (r *BidRepo) SubmitBidDecision(ctx context.Context, bidId string, decision
 	}
 
 	if decision == common.RejectedDecision {
-		return submitReject(r, bidUuid)
+		return submitReject(ctx, r, bidUuid, employeeUuid, delegateId)
 	}
 
-	tx, err := r.Database.Begin()
+	tx, err := r.Database.BeginTx(ctx, nil)
 	if err != nil {
 		if e := tx.Rollback(); e != nil {
 			return e
@@ -383,10 +345,16 @@ func (r *BidRepo) SubmitBidDecision(ctx context.Context, bidId string, decision
 		return err
 	}
 
+	voterUuid := employeeUuid
+	if delegateId.Valid {
+		voterUuid = delegateId.UUID
+	}
+
 	approvesCntSql, args, _ := r.SqlBuilder.
-		Select("count(*)").
+		Select("count(DISTINCT COALESCE(delegate_id, employee_id))").
 		From("approves").
 		Where("bid_id = ?", bidId).
+		Where("COALESCE(delegate_id, employee_id) <> ?", voterUuid).
 		RunWith(tx).
 		ToSql()
 
@@ -399,28 +367,12 @@ func (r *BidRepo) SubmitBidDecision(ctx context.Context, bidId string, decision
 		return err
 	}
 
-	countSql, args, _ := r.SqlBuilder.
-		Select("count(*)").
-		From("organization_responsible").
-		Where("organization_id = ?", organizationId).
-		RunWith(tx).
-		ToSql()
-
-	var responsibleCnt int
-	if err = tx.QueryRow(countSql, args...).Scan(&responsibleCnt); err != nil {
-		if e := tx.Rollback(); e != nil {
-			return e
-		}
-
-		return err
-	}
-
-	quorum := min(responsibleCnt, 3)
+	quorum := min(votersCnt, 3)
 	if approvesCnt < quorum-1 {
 		addApproveSql, args, _ := r.SqlBuilder.
 			Insert("approves").
-			Columns("bid_id", "employee_id").
-			Values(bidUuid, employeeUuid).
+			Columns("bid_id", "employee_id", "delegate_id").
+			Values(bidUuid, employeeUuid, delegateId).
 			RunWith(tx).
 			ToSql()
 
@@ -432,6 +384,14 @@ func (r *BidRepo) SubmitBidDecision(ctx context.Context, bidId string, decision
 			return err
 		}
 
+		if err := insertBidVote(tx, r.SqlBuilder, bidUuid, employeeUuid, delegateId, common.ApprovedDecision); err != nil {
+			if e := tx.Rollback(); e != nil {
+				return e
+			}
+
+			return err
+		}
+
 		if err := tx.Commit(); err != nil {
 			return err
 		}
@@ -467,6 +427,22 @@ func (r *BidRepo) SubmitBidDecision(ctx context.Context, bidId string, decision
 		return err
 	}
 
+	if err := insertBidVote(tx, r.SqlBuilder, bidUuid, employeeUuid, delegateId, common.ApprovedDecision); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
+	if err := createContractForBid(tx, r.SqlBuilder, bidUuid); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return err
 	}
@@ -474,7 +450,9 @@ func (r *BidRepo) SubmitBidDecision(ctx context.Context, bidId string, decision
 	return nil
 }
 
-func (r *BidRepo) RollbackBidVersion(ctx context.Context, bidId string, version int) error {
+// Версия, к которой откатываются, становится текущей и приписывается тому, кто сделал откат.
+// Подпись снимается: номер версии входит в подписываемые данные
+func (r *BidRepo) RollbackBidVersion(ctx context.Context, bidId string, version int, actorId uuid.UUID) error {
 	uuidForm, err := uuid.Parse(bidId)
 	if err != nil {
 		return err
@@ -510,12 +488,40 @@ func (r *BidRepo) RollbackBidVersion(ctx context.Context, bidId string, version
 	updateVersionInVersionTableSql, args, _ := r.SqlBuilder.
 		Update("bid_version").
 		Set("version", currentVersion).
+		Set("created_by", actorId).
+		Set("action", common.RollbackBidAction).
+		Set("created_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		Set("signature", nil).
+		Set("signing_key_id", nil).
+		Set("signed_at", nil).
 		Where("bid_id = ?", uuidForm).
 		Where("version = ?", version).
+		Where("version < ?", currentVersion).
 		RunWith(tx).
 		ToSql()
 
-	if _, err = tx.Exec(updateVersionInVersionTableSql, args...); err != nil {
+	res, err := tx.Exec(updateVersionInVersionTableSql, args...)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil || affected == 0 {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+		if err != nil {
+			return err
+		}
+
+		return repo_errors.ErrNotFound
+	}
+
+	if err = returnUnsignedBidToCreated(tx, r.SqlBuilder, uuidForm, actorId); err != nil {
 		if e := tx.Rollback(); e != nil {
 			return e
 		}
@@ -530,50 +536,387 @@ func (r *BidRepo) RollbackBidVersion(ctx context.Context, bidId string, version
 	return nil
 }
 
-func (r *BidRepo) SubmitBidFeedBack(ctx context.Context, bidId string, senderId uuid.UUID, receiverId uuid.UUID, content string) error {
+func (r *BidRepo) SubmitBidFeedBack(ctx context.Context, input *entity.CreateReviewInput) error {
 	createFeedbackReq, args, _ := r.SqlBuilder.
 		Insert("review").
-		Columns("bid_id", "receiver_id", "author_id", "description").
-		Values(bidId, receiverId, senderId, content).
+		Columns("bid_id", "contract_id", "receiver_id", "receiver_organization_id", "author_id", "description",
+			"quality_rating", "timeliness_rating", "communication_rating").
+		Values(input.BidId, input.ContractId, input.ReceiverId, input.ReceiverOrganization, input.AuthorId, input.Description,
+			input.QualityRating, input.TimelinessRating, input.CommunicationRating).
 		ToSql()
 
-	_, err := r.Database.Exec(createFeedbackReq, args...)
+	_, err := r.Database.ExecContext(ctx, createFeedbackReq, args...)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return repo_errors.ErrAlreadyExists
+		}
+
 		return err
 	}
 
 	return nil
 }
 
-func (r *BidRepo) GetReviewsByReceiverId(ctx context.Context, receiverId string, pg *entity.PaginationInput) ([]entity.Review, error) {
-	getTenderBidsSql, args, _ := r.SqlBuilder.
-		Select("id, description, created_at, author_id, receiver_id, bid_id").
-		From("review").
-		Where("receiver_id = ?", receiverId).
-		OrderBy("description ASC").
-		Offset(uint64(pg.Offset)).
-		Limit(uint64(pg.Limit)).
+func (r *BidRepo) GetTenderBidAuthorIds(ctx context.Context, tenderId uuid.UUID) ([]uuid.UUID, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("author_id").
+		Distinct().
+		From("bid").
+		Where("tender_id = ?", tenderId).
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return ids, err
+	}
+
+	return ids, nil
+}
+
+// Новая версия предложения строится от текущей: незаданные поля и вложения переносятся из нее
+func createNextBidVersion(tx *sql.Tx, builder squirrel.StatementBuilderType, bidId uuid.UUID, name string, description string, createdBy uuid.UUID, action string) error {
+	updateVersionReq, args, _ := builder.
+		Update("bid").
+		Set("current_version", squirrel.Expr("current_version + ?", 1)).
+		Where("id = ?", bidId).
+		Suffix("RETURNING current_version").
+		RunWith(tx).
+		ToSql()
+
+	var current_version int
+	if err := tx.QueryRow(updateVersionReq, args...).Scan(&current_version); err != nil {
+		return err
+	}
+
+	getOldValuesReq, args, _ := builder.
+		Select("id", "name", "description").
+		From("bid_version").
+		Where("bid_id = ?", bidId).
+		Where("version = ?", current_version-1).
+		RunWith(tx).
+		ToSql()
+
+	var prevVersionId uuid.UUID
+	var prevName, prevDescription string
+	if err := tx.QueryRow(getOldValuesReq, args...).
+		Scan(&prevVersionId, &prevName, &prevDescription); err != nil {
+		return err
+	}
+
+	if name == "" {
+		name = prevName
+	}
+
+	if description == "" {
+		description = prevDescription
+	}
+
+	createVersionReq, args, _ := builder.
+		Insert("bid_version").
+		Columns("name", "description", "version", "bid_id", "created_by", "action").
+		Values(name, description, current_version, bidId, createdBy, action).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		ToSql()
+
+	var newVersionId uuid.UUID
+	if err := tx.QueryRow(createVersionReq, args...).Scan(&newVersionId); err != nil {
+		return err
+	}
+
+	if err := copyVersionAttachments(tx, builder, "bid_version_id", prevVersionId, newVersionId); err != nil {
+		return err
+	}
+
+	return returnUnsignedBidToCreated(tx, builder, bidId, createdBy)
+}
+
+// Новая версия предложения не подписана. Если тендер принимает только подписанные предложения,
+// опубликованное предложение возвращается в Created, пока новую версию не подпишут и не опубликуют снова
+func returnUnsignedBidToCreated(tx *sql.Tx, builder squirrel.StatementBuilderType, bidId uuid.UUID, actorId uuid.UUID) error {
+	updateStatusSql, args, _ := builder.
+		Update("bid").
+		Set("status", common.Created).
+		Set("status_changed_by", actorId).
+		Where("id = ?", bidId).
+		Where("status = ?", common.Published).
+		Where("EXISTS (SELECT 1 FROM tender WHERE tender.id = bid.tender_id AND tender.requires_signature)").
+		ToSql()
+
+	_, err := tx.Exec(updateStatusSql, args...)
+
+	return err
+}
+
+// Голоса за отозванное предложение больше ничего не значат, поэтому удаляются вместе с отзывом
+func deleteBidApproves(tx *sql.Tx, builder squirrel.StatementBuilderType, bidId uuid.UUID) error {
+	deleteApprovesSql, args, _ := builder.
+		Delete("approves").
+		Where("bid_id = ?", bidId).
+		RunWith(tx).
+		ToSql()
+
+	_, err := tx.Exec(deleteApprovesSql, args...)
+
+	return err
+}
+
+func (r *BidRepo) ConsentBidWithdrawal(ctx context.Context, bidId uuid.UUID, employeeId uuid.UUID) error {
+	consentSql, args, _ := r.SqlBuilder.
+		Update("bid").
+		Set("withdrawal_consented_by", employeeId).
+		Where("id = ?", bidId).
+		Where("status <> ?", common.Canceled).
+		ToSql()
+
+	res, err := r.Database.ExecContext(ctx, consentSql, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo_errors.ErrNotFound
+	}
+
+	return nil
+}
+
+// Отозвать можно только предложение, по которому еще не принято решение. Если за него уже голосовали,
+// нужно согласие владельца тендера, иначе возвращается ErrInUse.
+// Строка предложения блокируется до конца транзакции: голос, поданный в это время, ждет ее
+// (вставка в approves берет блокировку строки bid по внешнему ключу), поэтому голоса проверяются
+// в том же запросе, что и отзыв, и ни один из них не удаляется без согласия
+func (r *BidRepo) WithdrawBid(ctx context.Context, bidId uuid.UUID, reason string, actorId uuid.UUID) error {
+	tx, err := r.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	lockSql, args, _ := r.SqlBuilder.
+		Select("status", "decision").
+		From("bid").
+		Where("id = ?", bidId).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		ToSql()
+
+	var status, decision string
+	if err = tx.QueryRow(lockSql, args...).Scan(&status, &decision); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return repo_errors.ErrNotFound
+		}
+
+		return err
+	}
+	if status == common.Canceled || decision != "-" {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return repo_errors.ErrNotFound
+	}
+
+	withdrawSql, args, _ := r.SqlBuilder.
+		Update("bid").
+		Set("status", common.Canceled).
+		Set("status_changed_by", actorId).
+		Set("withdrawal_reason", nullIfEmpty(reason)).
+		Set("withdrawn_at", squirrel.Expr("LOCALTIMESTAMP")).
+		Set("withdrawal_consented_by", nil).
+		Where("id = ?", bidId).
+		Where("(NOT EXISTS (SELECT 1 FROM approves WHERE approves.bid_id = bid.id) OR withdrawal_consented_by IS NOT NULL)").
+		RunWith(tx).
+		ToSql()
+
+	res, err := tx.Exec(withdrawSql, args...)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil || affected == 0 {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+		if err != nil {
+			return err
+		}
+
+		return repo_errors.ErrInUse
+	}
+
+	if err = deleteBidApproves(tx, r.SqlBuilder, bidId); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Повторная подача создает новую версию и возможна только до дедлайна тендера
+func (r *BidRepo) ResubmitBid(ctx context.Context, bidId uuid.UUID, name string, description string, status string, actorId uuid.UUID) error {
+	tx, err := r.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	resubmitSql, args, _ := r.SqlBuilder.
+		Update("bid").
+		Set("status", status).
+		Set("status_changed_by", actorId).
+		Set("withdrawal_reason", nil).
+		Set("withdrawn_at", nil).
+		Set("withdrawal_consented_by", nil).
+		Where("id = ?", bidId).
+		Where("status = ?", common.Canceled).
+		Where("EXISTS (SELECT 1 FROM tender WHERE tender.id = bid.tender_id AND (tender.deadline IS NULL OR tender.deadline > LOCALTIMESTAMP))").
+		RunWith(tx).
+		ToSql()
+
+	res, err := tx.Exec(resubmitSql, args...)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil || affected == 0 {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+		if err != nil {
+			return err
+		}
+
+		return repo_errors.ErrExpired
+	}
+
+	if err = createNextBidVersion(tx, r.SqlBuilder, bidId, name, description, actorId, common.ResubmitBidAction); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
+	if err = deleteBidApproves(tx, r.SqlBuilder, bidId); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *BidRepo) GetBidVersions(ctx context.Context, bidId uuid.UUID) ([]entity.BidVersion, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("bid_version.version", "bid_version.name", "coalesce(bid_version.description, '')", "bid_version.created_by",
+			"coalesce(employee.username, '')", "coalesce(bid_version.action::text, '')", "bid_version.created_at",
+			"bid_version.signature IS NOT NULL").
+		From("bid_version").
+		LeftJoin("employee ON employee.id = bid_version.created_by").
+		Where("bid_version.bid_id = ?", bidId).
+		OrderBy("bid_version.version DESC").
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := make([]entity.BidVersion, 0)
+	for rows.Next() {
+		var version entity.BidVersion
+		var createdAt sql.NullTime
+		if err := rows.Scan(&version.Version, &version.Name, &version.Description, &version.CreatedBy,
+			&version.CreatedByUsername, &version.Action, &createdAt, &version.Signed); err != nil {
+			return versions, err
+		}
+		version.CreatedAt = formatNullTime(createdAt)
+		versions = append(versions, version)
+	}
+	if err = rows.Err(); err != nil {
+		return versions, err
+	}
+
+	return versions, nil
+}
+
+func insertBidVote(tx *sql.Tx, builder squirrel.StatementBuilderType, bidId uuid.UUID, employeeId uuid.UUID, delegateId uuid.NullUUID, decision string) error {
+	sqlReq, args, _ := builder.
+		Insert("bid_vote").
+		Columns("bid_id", "employee_id", "delegate_id", "decision").
+		Values(bidId, employeeId, delegateId, decision).
+		ToSql()
+
+	_, err := tx.Exec(sqlReq, args...)
+
+	return err
+}
+
+func (r *BidRepo) GetBidVotes(ctx context.Context, bidId uuid.UUID) ([]entity.BidVote, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("bid_vote.employee_id, employee.username, bid_vote.delegate_id, COALESCE(delegate.username, ''), bid_vote.decision, bid_vote.created_at").
+		From("bid_vote").
+		InnerJoin("employee ON employee.id = bid_vote.employee_id").
+		LeftJoin("employee delegate ON delegate.id = bid_vote.delegate_id").
+		Where("bid_vote.bid_id = ?", bidId).
+		OrderBy("bid_vote.created_at ASC").
 		ToSql()
 
-	rows, err := r.Database.Query(getTenderBidsSql, args...)
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	reviews := make([]entity.Review, 0)
+	votes := make([]entity.BidVote, 0)
 	for rows.Next() {
-		var review entity.Review
+		var vote entity.BidVote
 		var createdAt time.Time
-		if err := rows.Scan(&review.Id, &review.Description, &createdAt, &review.AuthorId, &review.ReceiverId, &review.BidId); err != nil {
-			return reviews, err
+		err := rows.Scan(&vote.EmployeeId, &vote.EmployeeUsername, &vote.DelegateId, &vote.DelegateUsername, &vote.Decision, &createdAt)
+		if err != nil {
+			return votes, err
 		}
-		review.CreatedAt = createdAt.Format(time.RFC3339)
-		reviews = append(reviews, review)
+		vote.CreatedAt = createdAt.Format(time.RFC3339)
+		votes = append(votes, vote)
 	}
 	if err = rows.Err(); err != nil {
-		return reviews, err
+		return votes, err
 	}
 
-	return reviews, nil
+	return votes, nil
 }
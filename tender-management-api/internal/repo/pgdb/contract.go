@@ -0,0 +1,217 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"tender-management-api/internal/common"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo/repo_errors"
+	"tender-management-api/pkg/postgres"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type ContractRepo struct {
+	*postgres.Postgres
+}
+
+func NewContractRepo(pgdb *postgres.Postgres) *ContractRepo {
+	return &ContractRepo{pgdb}
+}
+
+const contractColumns = "id, tender_id, bid_id, bid_version, customer_organization_id, supplier_id, supplier_organization_id, " +
+	"price, start_date, end_date, status, customer_signed_at, supplier_signed_at, termination_reason, created_at, updated_at"
+
+func formatNullDate(t sql.NullTime) string {
+	if !t.Valid {
+		return ""
+	}
+
+	return t.Time.Format(time.DateOnly)
+}
+
+func scanContract(row rowScanner) (*entity.Contract, error) {
+	var contract entity.Contract
+	var createdAt, updatedAt time.Time
+	var startDate, endDate, customerSignedAt, supplierSignedAt sql.NullTime
+	var terminationReason sql.NullString
+	err := row.Scan(&contract.Id, &contract.TenderId, &contract.BidId, &contract.BidVersion, &contract.CustomerOrganizationId,
+		&contract.SupplierId, &contract.SupplierOrganizationId, &contract.Price, &startDate, &endDate, &contract.Status,
+		&customerSignedAt, &supplierSignedAt, &terminationReason, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	contract.StartDate, contract.EndDate = formatNullDate(startDate), formatNullDate(endDate)
+	contract.CustomerSignedAt, contract.SupplierSignedAt = formatNullTime(customerSignedAt), formatNullTime(supplierSignedAt)
+	contract.TerminationReason = terminationReason.String
+	contract.CreatedAt, contract.UpdatedAt = createdAt.Format(time.RFC3339), updatedAt.Format(time.RFC3339)
+
+	return &contract, nil
+}
+
+// Черновик контракта создается в той же транзакции, в которой предложение окончательно одобряется.
+// Организация поставщика - та, от имени которой подано предложение; у предложений от пользователя ее нет
+func createContractForBid(tx *sql.Tx, builder squirrel.StatementBuilderType, bidId uuid.UUID) error {
+	sqlReq, args, _ := builder.
+		Insert("contract").
+		Columns("tender_id", "bid_id", "bid_version", "customer_organization_id", "supplier_id", "supplier_organization_id").
+		Select(squirrel.
+			Select("bid.tender_id", "bid.id", "bid.current_version", "tender.organization_id", "bid.author_id", "bid.organization_id").
+			From("bid").
+			InnerJoin("tender ON tender.id = bid.tender_id").
+			Where("bid.id = ?", bidId)).
+		Suffix("ON CONFLICT (bid_id) DO NOTHING").
+		ToSql()
+
+	_, err := tx.Exec(sqlReq, args...)
+
+	return err
+}
+
+func (r *ContractRepo) getContract(ctx context.Context, where string, arg any) (*entity.Contract, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select(contractColumns).
+		From("contract").
+		Where(where, arg).
+		ToSql()
+
+	contract, err := scanContract(r.Database.QueryRowContext(ctx, sqlReq, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo_errors.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return contract, nil
+}
+
+func (r *ContractRepo) GetContractById(ctx context.Context, id string) (*entity.Contract, error) {
+	uuidForm, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.getContract(ctx, "id = ?", uuidForm)
+}
+
+func (r *ContractRepo) GetContractByBidId(ctx context.Context, bidId uuid.UUID) (*entity.Contract, error) {
+	return r.getContract(ctx, "bid_id = ?", bidId)
+}
+
+// Контракты переданных организаций заказчика и поставщика и, если передан поставщик, его личные контракты
+func (r *ContractRepo) GetEmployeeContracts(ctx context.Context, supplierId uuid.NullUUID, customerOrganizationIds []uuid.UUID, supplierOrganizationIds []uuid.UUID, filter *entity.ContractFilter, pg *entity.PaginationInput) ([]entity.Contract, error) {
+	condition := squirrel.Or{
+		squirrel.Eq{"customer_organization_id": customerOrganizationIds},
+		squirrel.Eq{"supplier_organization_id": supplierOrganizationIds},
+	}
+	if supplierId.Valid {
+		condition = append(condition, squirrel.Eq{"supplier_id": supplierId.UUID})
+	}
+
+	builder := r.SqlBuilder.
+		Select(contractColumns).
+		From("contract").
+		Where(condition)
+	if filter.TenderId != "" {
+		builder = builder.Where("tender_id = ?", filter.TenderId)
+	}
+	if filter.Status != "" {
+		builder = builder.Where("status = ?", filter.Status)
+	}
+
+	sqlReq, args, _ := builder.
+		OrderBy("created_at DESC").
+		Offset(uint64(pg.Offset)).
+		Limit(uint64(pg.Limit)).
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contracts := make([]entity.Contract, 0)
+	for rows.Next() {
+		contract, err := scanContract(rows)
+		if err != nil {
+			return contracts, err
+		}
+		contracts = append(contracts, *contract)
+	}
+	if err = rows.Err(); err != nil {
+		return contracts, err
+	}
+
+	return contracts, nil
+}
+
+func execContractUpdate(ctx context.Context, r *ContractRepo, builder squirrel.UpdateBuilder) error {
+	sqlReq, args, _ := builder.
+		Set("updated_at", squirrel.Expr("LOCALTIMESTAMP")).
+		ToSql()
+
+	res, err := r.Database.ExecContext(ctx, sqlReq, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo_errors.ErrNotFound
+	}
+
+	return nil
+}
+
+// Изменение условий сбрасывает уже поставленные подписи. ErrNotFound, если контракт уже не черновик
+func (r *ContractRepo) EditContract(ctx context.Context, id uuid.UUID, input *entity.EditContractInput) error {
+	return execContractUpdate(ctx, r, r.SqlBuilder.
+		Update("contract").
+		Set("price", input.Price).
+		Set("start_date", input.StartDate).
+		Set("end_date", input.EndDate).
+		Set("customer_signed_at", nil).
+		Set("supplier_signed_at", nil).
+		Where("id = ?", id).
+		Where("status = ?", common.ContractDraft))
+}
+
+// Контракт становится подписанным, как только подпись ставит вторая сторона
+func (r *ContractRepo) SignContract(ctx context.Context, id uuid.UUID, byCustomer bool) error {
+	signedColumn, otherColumn := "supplier_signed_at", "customer_signed_at"
+	if byCustomer {
+		signedColumn, otherColumn = otherColumn, signedColumn
+	}
+
+	return execContractUpdate(ctx, r, r.SqlBuilder.
+		Update("contract").
+		Set(signedColumn, squirrel.Expr("COALESCE("+signedColumn+", LOCALTIMESTAMP)")).
+		Set("status", squirrel.Expr("CASE WHEN "+otherColumn+" IS NOT NULL THEN ?::contract_status_type ELSE status END", common.ContractSigned)).
+		Where("id = ?", id).
+		Where("status = ?", common.ContractDraft))
+}
+
+// ErrNotFound, если контракт уже не в одном из исходных статусов
+func (r *ContractRepo) UpdateContractStatus(ctx context.Context, id uuid.UUID, from []string, to string, reason string) error {
+	builder := r.SqlBuilder.
+		Update("contract").
+		Set("status", to).
+		Where("id = ?", id).
+		Where("status::text = ANY(?)", pq.Array(from))
+	if reason != "" {
+		builder = builder.Set("termination_reason", reason)
+	}
+
+	return execContractUpdate(ctx, r, builder)
+}
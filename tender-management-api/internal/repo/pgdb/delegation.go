@@ -0,0 +1,176 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo/repo_errors"
+	"tender-management-api/pkg/postgres"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+type DelegationRepo struct {
+	*postgres.Postgres
+}
+
+func NewDelegationRepo(pgdb *postgres.Postgres) *DelegationRepo {
+	return &DelegationRepo{pgdb}
+}
+
+const approvalDelegationColumns = "approval_delegation.id, approval_delegation.organization_id, " +
+	"approval_delegation.delegator_id, delegator.username, approval_delegation.delegate_id, delegate.username, " +
+	"approval_delegation.starts_on, approval_delegation.ends_on, approval_delegation.created_at"
+
+func scanApprovalDelegation(row rowScanner) (*entity.ApprovalDelegation, error) {
+	var delegation entity.ApprovalDelegation
+	var startsOn, endsOn, createdAt time.Time
+	err := row.Scan(&delegation.Id, &delegation.OrganizationId, &delegation.DelegatorId, &delegation.DelegatorUsername,
+		&delegation.DelegateId, &delegation.DelegateUsername, &startsOn, &endsOn, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	delegation.StartsOn = startsOn.Format(time.DateOnly)
+	delegation.EndsOn = endsOn.Format(time.DateOnly)
+	delegation.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return &delegation, nil
+}
+
+func (r *DelegationRepo) selectApprovalDelegations() squirrel.SelectBuilder {
+	return r.SqlBuilder.
+		Select(approvalDelegationColumns).
+		From("approval_delegation").
+		InnerJoin("employee delegator ON delegator.id = approval_delegation.delegator_id").
+		InnerJoin("employee delegate ON delegate.id = approval_delegation.delegate_id")
+}
+
+// У сотрудника в организации не может быть пересекающихся по датам передач, иначе непонятно, кто голосует за него
+func (r *DelegationRepo) CreateApprovalDelegation(ctx context.Context, input *entity.CreateApprovalDelegationInput) (uuid.UUID, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Insert("approval_delegation").
+		Columns("organization_id", "delegator_id", "delegate_id", "starts_on", "ends_on").
+		Select(squirrel.
+			Select().
+			Column("?::uuid", input.OrganizationId).
+			Column("?::uuid", input.DelegatorId).
+			Column("?::uuid", input.DelegateId).
+			Column("?::date", input.StartsOn).
+			Column("?::date", input.EndsOn).
+			Where("NOT EXISTS (SELECT 1 FROM approval_delegation WHERE organization_id = ? AND delegator_id = ? "+
+				"AND starts_on <= ?::date AND ends_on >= ?::date)",
+				input.OrganizationId, input.DelegatorId, input.EndsOn, input.StartsOn)).
+		Suffix("RETURNING id").
+		ToSql()
+
+	var id uuid.UUID
+	if err := r.Database.QueryRowContext(ctx, sqlReq, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, repo_errors.ErrAlreadyExists
+		}
+		if isForeignKeyViolation(err) {
+			return uuid.Nil, repo_errors.ErrNotFound
+		}
+
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
+func (r *DelegationRepo) GetApprovalDelegationById(ctx context.Context, id uuid.UUID) (*entity.ApprovalDelegation, error) {
+	sqlReq, args, _ := r.selectApprovalDelegations().
+		Where("approval_delegation.id = ?", id).
+		ToSql()
+
+	delegation, err := scanApprovalDelegation(r.Database.QueryRowContext(ctx, sqlReq, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo_errors.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return delegation, nil
+}
+
+// Выданные и полученные сотрудником передачи, которые еще не закончились
+func (r *DelegationRepo) GetEmployeeApprovalDelegations(ctx context.Context, employeeId uuid.UUID) ([]entity.ApprovalDelegation, error) {
+	sqlReq, args, _ := r.selectApprovalDelegations().
+		Where(squirrel.Or{
+			squirrel.Eq{"approval_delegation.delegator_id": employeeId},
+			squirrel.Eq{"approval_delegation.delegate_id": employeeId},
+		}).
+		Where("approval_delegation.ends_on >= CURRENT_DATE").
+		OrderBy("approval_delegation.starts_on ASC").
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	delegations := make([]entity.ApprovalDelegation, 0)
+	for rows.Next() {
+		delegation, err := scanApprovalDelegation(rows)
+		if err != nil {
+			return delegations, err
+		}
+		delegations = append(delegations, *delegation)
+	}
+	if err = rows.Err(); err != nil {
+		return delegations, err
+	}
+
+	return delegations, nil
+}
+
+func (r *DelegationRepo) IsApprovalDelegatedToday(ctx context.Context, organizationId uuid.UUID, delegatorId uuid.UUID, delegateId uuid.UUID) (bool, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("1").
+		From("approval_delegation").
+		Where("organization_id = ?", organizationId).
+		Where("delegator_id = ?", delegatorId).
+		Where("delegate_id = ?", delegateId).
+		Where("CURRENT_DATE BETWEEN starts_on AND ends_on").
+		Limit(1).
+		ToSql()
+
+	var one int
+	if err := r.Database.QueryRowContext(ctx, sqlReq, args...).Scan(&one); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *DelegationRepo) DeleteApprovalDelegation(ctx context.Context, id uuid.UUID) error {
+	sqlReq, args, _ := r.SqlBuilder.
+		Delete("approval_delegation").
+		Where("id = ?", id).
+		ToSql()
+
+	res, err := r.Database.ExecContext(ctx, sqlReq, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo_errors.ErrNotFound
+	}
+
+	return nil
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"tender-management-api/internal/entity"
 	"tender-management-api/internal/repo/repo_errors"
 	"tender-management-api/pkg/postgres"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 )
 
@@ -26,7 +28,7 @@ func (r *EmployeeRepo) GetEmployeeIdByUsername(ctx context.Context, username str
 		ToSql()
 
 	var employeeId string
-	err := r.Database.QueryRow(sqlReq, args...).Scan(&employeeId)
+	err := r.Database.QueryRowContext(ctx, sqlReq, args...).Scan(&employeeId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return "", repo_errors.ErrNotFound
@@ -51,7 +53,7 @@ func (r *EmployeeRepo) DoesOrganizationExistById(ctx context.Context, id string)
 		ToSql()
 
 	var organizationId string
-	err = r.Database.QueryRow(sqlReq, args...).Scan(&organizationId)
+	err = r.Database.QueryRowContext(ctx, sqlReq, args...).Scan(&organizationId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, nil
@@ -63,25 +65,37 @@ func (r *EmployeeRepo) DoesOrganizationExistById(ctx context.Context, id string)
 	return true, nil
 }
 
-func (r *EmployeeRepo) GetUserOrganizationIdByEmployeeId(ctx context.Context, employeeId string) (uuid.UUID, error) {
-	uuidForm, err := uuid.Parse(employeeId)
-	if err != nil {
-		return uuid.Nil, err
-	}
-
+// Сотрудник состоит в организации, если отвечает за нее или получил в ней роль
+func (r *EmployeeRepo) GetEmployeeMemberships(ctx context.Context, employeeId uuid.UUID) ([]entity.Membership, error) {
 	sqlReq, args, _ := r.SqlBuilder.
-		Select("organization_id").
-		From("organization_responsible").
-		Where("user_id = ?", uuidForm).
+		Select("organization.id, organization.name").
+		From("organization").
+		Where(squirrel.Or{
+			squirrel.Expr("organization.id IN (SELECT organization_id FROM organization_responsible WHERE user_id = ?)", employeeId),
+			squirrel.Expr("organization.id IN (SELECT organization_id FROM organization_role WHERE employee_id = ?)", employeeId),
+		}).
+		OrderBy("organization.name ASC").
 		ToSql()
 
-	var organizationId uuid.UUID
-	err = r.Database.QueryRow(sqlReq, args...).Scan(&organizationId)
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
 	if err != nil {
-		return uuid.Nil, err
+		return nil, err
 	}
+	defer rows.Close()
 
-	return organizationId, nil
+	memberships := make([]entity.Membership, 0)
+	for rows.Next() {
+		var membership entity.Membership
+		if err := rows.Scan(&membership.OrganizationId, &membership.OrganizationName); err != nil {
+			return memberships, err
+		}
+		memberships = append(memberships, membership)
+	}
+	if err = rows.Err(); err != nil {
+		return memberships, err
+	}
+
+	return memberships, nil
 }
 
 func (r *EmployeeRepo) IsEmployeeResponsible(ctx context.Context, employeeId string, organizationId uuid.UUID) (bool, error) {
@@ -97,7 +111,7 @@ func (r *EmployeeRepo) IsEmployeeResponsible(ctx context.Context, employeeId str
 		ToSql()
 
 	var id string
-	err = r.Database.QueryRow(sqlReq, args...).Scan(&id)
+	err = r.Database.QueryRowContext(ctx, sqlReq, args...).Scan(&id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, nil
@@ -109,6 +123,30 @@ func (r *EmployeeRepo) IsEmployeeResponsible(ctx context.Context, employeeId str
 	return true, nil
 }
 
+func (r *EmployeeRepo) IsEmployeeAdmin(ctx context.Context, employeeId string) (bool, error) {
+	uuidForm, err := uuid.Parse(employeeId)
+	if err != nil {
+		return false, err
+	}
+
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("is_admin").
+		From("employee").
+		Where("id = ?", uuidForm).
+		ToSql()
+
+	var isAdmin bool
+	if err = r.Database.QueryRowContext(ctx, sqlReq, args...).Scan(&isAdmin); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return isAdmin, nil
+}
+
 func (r *EmployeeRepo) DoesEmployeeExistsById(ctx context.Context, id string) (bool, error) {
 	uuidForm, err := uuid.Parse(id)
 	if err != nil {
@@ -122,7 +160,7 @@ func (r *EmployeeRepo) DoesEmployeeExistsById(ctx context.Context, id string) (b
 		ToSql()
 
 	var uid uuid.UUID
-	err = r.Database.QueryRow(sqlReq, args...).Scan(&uid)
+	err = r.Database.QueryRowContext(ctx, sqlReq, args...).Scan(&uid)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, nil
@@ -133,3 +171,53 @@ func (r *EmployeeRepo) DoesEmployeeExistsById(ctx context.Context, id string) (b
 
 	return true, nil
 }
+
+func (r *EmployeeRepo) GetOrganizationResponsibleIds(ctx context.Context, organizationId uuid.UUID) ([]uuid.UUID, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("user_id").
+		From("organization_responsible").
+		Where("organization_id = ?", organizationId).
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return ids, err
+	}
+
+	return ids, nil
+}
+
+func (r *EmployeeRepo) GetEmployeeById(ctx context.Context, id uuid.UUID) (*entity.Employee, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("id, username, email, locale").
+		From("employee").
+		Where("id = ?", id).
+		ToSql()
+
+	var employee entity.Employee
+	var email sql.NullString
+	err := r.Database.QueryRowContext(ctx, sqlReq, args...).Scan(&employee.Id, &employee.Username, &email, &employee.Locale)
+	employee.Email = email.String
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &employee, repo_errors.ErrNotFound
+		}
+
+		return &employee, err
+	}
+
+	return &employee, nil
+}
@@ -0,0 +1,70 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"tender-management-api/internal/entity"
+	"tender-management-api/pkg/postgres"
+	"time"
+)
+
+type EventRepo struct {
+	*postgres.Postgres
+}
+
+func NewEventRepo(pgdb *postgres.Postgres) *EventRepo {
+	return &EventRepo{pgdb}
+}
+
+func (r *EventRepo) CreateEvent(ctx context.Context, event *entity.Event) (int64, string, error) {
+	createEventSql, args, _ := r.SqlBuilder.
+		Insert("event").
+		Columns("type", "tender_id", "bid_id", "organization_id", "bid_author_id", "service_type", "status", "decision").
+		Values(event.Type, event.TenderId, event.BidId, event.OrganizationId, event.BidAuthorId,
+			event.ServiceType, event.Status, event.Decision).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	var id int64
+	var createdAt time.Time
+	if err := r.Database.QueryRowContext(ctx, createEventSql, args...).Scan(&id, &createdAt); err != nil {
+		return 0, "", err
+	}
+
+	return id, createdAt.Format(time.RFC3339), nil
+}
+
+func (r *EventRepo) GetEventsAfterId(ctx context.Context, id int64, limit int) ([]entity.Event, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("id, tenant_id, type, tender_id, bid_id, organization_id, bid_author_id, service_type, status, decision, created_at").
+		From("event").
+		Where("id > ?", id).
+		OrderBy("id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]entity.Event, 0)
+	for rows.Next() {
+		var event entity.Event
+		var createdAt time.Time
+		var serviceType, status, decision sql.NullString
+		if err := rows.Scan(&event.Id, &event.TenantId, &event.Type, &event.TenderId, &event.BidId, &event.OrganizationId,
+			&event.BidAuthorId, &serviceType, &status, &decision, &createdAt); err != nil {
+			return events, err
+		}
+		event.ServiceType, event.Status, event.Decision = serviceType.String, status.String, decision.String
+		event.CreatedAt = createdAt.Format(time.RFC3339)
+		events = append(events, event)
+	}
+	if err = rows.Err(); err != nil {
+		return events, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,223 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo/repo_errors"
+	"tender-management-api/pkg/postgres"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+type NotificationRepo struct {
+	*postgres.Postgres
+}
+
+func NewNotificationRepo(pgdb *postgres.Postgres) *NotificationRepo {
+	return &NotificationRepo{pgdb}
+}
+
+const notificationColumns = "notification.id, notification.employee_id, notification.is_read, notification.created_at, " +
+	"event.id, event.type, event.tender_id, event.bid_id, event.organization_id, event.bid_author_id, event.status, event.decision"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanNotification(row rowScanner) (*entity.Notification, error) {
+	var notification entity.Notification
+	var createdAt time.Time
+	var status, decision sql.NullString
+	err := row.Scan(&notification.Id, &notification.EmployeeId, &notification.IsRead, &createdAt,
+		&notification.Event.Id, &notification.Event.Type, &notification.Event.TenderId, &notification.Event.BidId,
+		&notification.Event.OrganizationId, &notification.Event.BidAuthorId, &status, &decision)
+	notification.CreatedAt = createdAt.Format(time.RFC3339)
+	notification.Event.Status, notification.Event.Decision = status.String, decision.String
+
+	return &notification, err
+}
+
+func (r *NotificationRepo) CreateNotification(ctx context.Context, employeeId uuid.UUID, eventId int64) error {
+	sqlReq, args, _ := r.SqlBuilder.
+		Insert("notification").
+		Columns("employee_id", "event_id").
+		Values(employeeId, eventId).
+		ToSql()
+
+	_, err := r.Database.ExecContext(ctx, sqlReq, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *NotificationRepo) GetNotifications(ctx context.Context, employeeId string, unreadOnly bool, pg *entity.PaginationInput) ([]entity.Notification, error) {
+	uuidForm, err := uuid.Parse(employeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := r.SqlBuilder.
+		Select(notificationColumns).
+		From("notification").
+		InnerJoin("event on notification.event_id = event.id").
+		Where("notification.employee_id = ?", uuidForm)
+
+	if unreadOnly {
+		builder = builder.Where("notification.is_read = ?", false)
+	}
+
+	sqlReq, args, _ := builder.
+		OrderBy("notification.created_at DESC").
+		Offset(uint64(pg.Offset)).
+		Limit(uint64(pg.Limit)).
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := make([]entity.Notification, 0)
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return notifications, err
+		}
+		notifications = append(notifications, *notification)
+	}
+	if err = rows.Err(); err != nil {
+		return notifications, err
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationRepo) CountUnreadNotifications(ctx context.Context, employeeId string) (int, error) {
+	uuidForm, err := uuid.Parse(employeeId)
+	if err != nil {
+		return 0, err
+	}
+
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("count(*)").
+		From("notification").
+		Where("employee_id = ?", uuidForm).
+		Where("is_read = ?", false).
+		ToSql()
+
+	var count int
+	if err = r.Database.QueryRowContext(ctx, sqlReq, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *NotificationRepo) GetNotificationById(ctx context.Context, id string) (*entity.Notification, error) {
+	uuidForm, err := uuid.Parse(id)
+	if err != nil {
+		return nil, repo_errors.ErrNotFound
+	}
+
+	sqlReq, args, _ := r.SqlBuilder.
+		Select(notificationColumns).
+		From("notification").
+		InnerJoin("event on notification.event_id = event.id").
+		Where("notification.id = ?", uuidForm).
+		ToSql()
+
+	notification, err := scanNotification(r.Database.QueryRowContext(ctx, sqlReq, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return notification, repo_errors.ErrNotFound
+		}
+
+		return notification, err
+	}
+
+	return notification, nil
+}
+
+func (r *NotificationRepo) MarkNotificationsRead(ctx context.Context, employeeId string, ids []uuid.UUID) error {
+	uuidForm, err := uuid.Parse(employeeId)
+	if err != nil {
+		return err
+	}
+
+	builder := r.SqlBuilder.
+		Update("notification").
+		Set("is_read", true).
+		Where("employee_id = ?", uuidForm)
+
+	// пустой список означает все уведомления сотрудника
+	if len(ids) > 0 {
+		builder = builder.Where(squirrel.Eq{"id": ids})
+	}
+
+	sqlReq, args, _ := builder.ToSql()
+	if _, err = r.Database.ExecContext(ctx, sqlReq, args...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *NotificationRepo) GetNotificationPreferences(ctx context.Context, employeeId string) ([]entity.NotificationPreference, error) {
+	uuidForm, err := uuid.Parse(employeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("event_type, enabled").
+		From("notification_preference").
+		Where("employee_id = ?", uuidForm).
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	preferences := make([]entity.NotificationPreference, 0)
+	for rows.Next() {
+		var preference entity.NotificationPreference
+		if err := rows.Scan(&preference.EventType, &preference.Enabled); err != nil {
+			return preferences, err
+		}
+		preferences = append(preferences, preference)
+	}
+	if err = rows.Err(); err != nil {
+		return preferences, err
+	}
+
+	return preferences, nil
+}
+
+func (r *NotificationRepo) SetNotificationPreference(ctx context.Context, employeeId string, eventType string, enabled bool) error {
+	uuidForm, err := uuid.Parse(employeeId)
+	if err != nil {
+		return err
+	}
+
+	sqlReq, args, _ := r.SqlBuilder.
+		Insert("notification_preference").
+		Columns("employee_id", "event_type", "enabled").
+		Values(uuidForm, eventType, enabled).
+		Suffix("ON CONFLICT (employee_id, event_type) DO UPDATE SET enabled = EXCLUDED.enabled").
+		ToSql()
+
+	if _, err = r.Database.ExecContext(ctx, sqlReq, args...); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"tender-management-api/internal/common"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo/repo_errors"
+	"tender-management-api/pkg/postgres"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+type OrganizationRelationRepo struct {
+	*postgres.Postgres
+}
+
+func NewOrganizationRelationRepo(pgdb *postgres.Postgres) *OrganizationRelationRepo {
+	return &OrganizationRelationRepo{pgdb}
+}
+
+const organizationRelationColumns = "organization_relation.id, organization_relation.organization_id, organization.name, " +
+	"organization_relation.related_organization_id, related.name, organization_relation.relation_type, organization_relation.created_at"
+
+func scanOrganizationRelation(row rowScanner) (*entity.OrganizationRelation, error) {
+	var relation entity.OrganizationRelation
+	var createdAt time.Time
+	err := row.Scan(&relation.Id, &relation.OrganizationId, &relation.OrganizationName,
+		&relation.RelatedOrganizationId, &relation.RelatedOrganizationName, &relation.Type, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	relation.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return &relation, nil
+}
+
+func (r *OrganizationRelationRepo) selectOrganizationRelations() squirrel.SelectBuilder {
+	return r.SqlBuilder.
+		Select(organizationRelationColumns).
+		From("organization_relation").
+		InnerJoin("organization ON organization.id = organization_relation.organization_id").
+		InnerJoin("organization related ON related.id = organization_relation.related_organization_id")
+}
+
+// Связь между парой организаций может быть только одна, в каком бы направлении ее ни задали
+func (r *OrganizationRelationRepo) CreateOrganizationRelation(ctx context.Context, input *entity.CreateOrganizationRelationInput) (uuid.UUID, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Insert("organization_relation").
+		Columns("organization_id", "related_organization_id", "relation_type", "created_by").
+		Select(squirrel.
+			Select().
+			Column("?::uuid", input.OrganizationId).
+			Column("?::uuid", input.RelatedOrganizationId).
+			Column("?::organization_relation_type", input.Type).
+			Column("?::uuid", input.CreatedBy).
+			Where("NOT EXISTS (SELECT 1 FROM organization_relation WHERE organization_id = ? AND related_organization_id = ?)",
+				input.RelatedOrganizationId, input.OrganizationId)).
+		Suffix("RETURNING id").
+		ToSql()
+
+	var id uuid.UUID
+	if err := r.Database.QueryRowContext(ctx, sqlReq, args...).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) || isUniqueViolation(err) {
+			return uuid.Nil, repo_errors.ErrAlreadyExists
+		}
+		if isForeignKeyViolation(err) {
+			return uuid.Nil, repo_errors.ErrNotFound
+		}
+
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
+func (r *OrganizationRelationRepo) GetOrganizationRelationById(ctx context.Context, id uuid.UUID) (*entity.OrganizationRelation, error) {
+	sqlReq, args, _ := r.selectOrganizationRelations().
+		Where("organization_relation.id = ?", id).
+		ToSql()
+
+	relation, err := scanOrganizationRelation(r.Database.QueryRowContext(ctx, sqlReq, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo_errors.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return relation, nil
+}
+
+// Связи организации в обоих направлениях
+func (r *OrganizationRelationRepo) GetOrganizationRelations(ctx context.Context, organizationId uuid.UUID) ([]entity.OrganizationRelation, error) {
+	sqlReq, args, _ := r.selectOrganizationRelations().
+		Where(squirrel.Or{
+			squirrel.Eq{"organization_relation.organization_id": organizationId},
+			squirrel.Eq{"organization_relation.related_organization_id": organizationId},
+		}).
+		OrderBy("organization_relation.created_at ASC").
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	relations := make([]entity.OrganizationRelation, 0)
+	for rows.Next() {
+		relation, err := scanOrganizationRelation(rows)
+		if err != nil {
+			return relations, err
+		}
+		relations = append(relations, *relation)
+	}
+	if err = rows.Err(); err != nil {
+		return relations, err
+	}
+
+	return relations, nil
+}
+
+func (r *OrganizationRelationRepo) DeleteOrganizationRelation(ctx context.Context, id uuid.UUID) error {
+	sqlReq, args, _ := r.SqlBuilder.
+		Delete("organization_relation").
+		Where("id = ?", id).
+		ToSql()
+
+	res, err := r.Database.ExecContext(ctx, sqlReq, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo_errors.ErrNotFound
+	}
+
+	return nil
+}
+
+// Причины конфликта для организации участника (bidder) и организации тендера (owner).
+// Совпадение самих организаций сюда не входит: такие предложения запрещены при создании
+var conflictReasons = []struct {
+	reason    string
+	condition string
+}{
+	{common.SharedResponsibleConflict, "SELECT 1 FROM organization_responsible x " +
+		"JOIN organization_responsible y ON x.user_id = y.user_id " +
+		"WHERE x.organization_id = candidate.bidder_id AND y.organization_id = candidate.owner_id"},
+	{common.ParentConflict, "SELECT 1 FROM organization_relation rel WHERE rel.relation_type = '" + common.ParentRelation + "' " +
+		"AND rel.organization_id = candidate.bidder_id AND rel.related_organization_id = candidate.owner_id"},
+	{common.SubsidiaryConflict, "SELECT 1 FROM organization_relation rel WHERE rel.relation_type = '" + common.ParentRelation + "' " +
+		"AND rel.organization_id = candidate.owner_id AND rel.related_organization_id = candidate.bidder_id"},
+	{common.AffiliateConflict, "SELECT 1 FROM organization_relation rel WHERE rel.relation_type = '" + common.AffiliateRelation + "' " +
+		"AND (rel.organization_id, rel.related_organization_id) IN ((candidate.bidder_id, candidate.owner_id), (candidate.owner_id, candidate.bidder_id))"},
+	{common.SameParentConflict, "SELECT 1 FROM organization_relation p1 " +
+		"JOIN organization_relation p2 ON p1.organization_id = p2.organization_id " +
+		"WHERE p1.relation_type = '" + common.ParentRelation + "' AND p2.relation_type = '" + common.ParentRelation + "' " +
+		"AND p1.related_organization_id = candidate.bidder_id AND p2.related_organization_id = candidate.owner_id"},
+}
+
+// candidate - подзапрос с колонками bid_id, bidder_id, owner_id: по строке на каждую организацию участника
+func (r *OrganizationRelationRepo) getConflicts(ctx context.Context, candidate squirrel.SelectBuilder) ([]entity.OrganizationConflict, error) {
+	candidateSql, candidateArgs, _ := candidate.PlaceholderFormat(squirrel.Question).ToSql()
+
+	reasons := make([]string, 0, len(conflictReasons))
+	for _, c := range conflictReasons {
+		reasons = append(reasons, "SELECT '"+c.reason+"' AS reason WHERE EXISTS ("+c.condition+")")
+	}
+	reasonsSql := "LATERAL (" + strings.Join(reasons, " UNION ALL ") + ") conflict ON true"
+
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("candidate.bid_id", "organization.id", "organization.name", "conflict.reason").
+		Prefix("WITH candidate AS ("+candidateSql+")", candidateArgs...).
+		From("candidate").
+		InnerJoin("organization ON organization.id = candidate.bidder_id").
+		InnerJoin(reasonsSql).
+		Where("candidate.bidder_id <> candidate.owner_id").
+		OrderBy("candidate.bid_id", "organization.name", "conflict.reason").
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conflicts := make([]entity.OrganizationConflict, 0)
+	for rows.Next() {
+		var conflict entity.OrganizationConflict
+		if err := rows.Scan(&conflict.BidId, &conflict.OrganizationId, &conflict.OrganizationName, &conflict.Reason); err != nil {
+			return conflicts, err
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	if err = rows.Err(); err != nil {
+		return conflicts, err
+	}
+
+	return conflicts, nil
+}
+
+// Конфликты будущего предложения: bidderOrganizationIds - организация, от имени которой оно подается,
+// или все организации автора личного предложения
+func (r *OrganizationRelationRepo) GetBidderConflicts(ctx context.Context, bidderOrganizationIds []uuid.UUID, organizationId uuid.UUID) ([]entity.OrganizationConflict, error) {
+	candidate := squirrel.
+		Select().
+		Column("?::uuid AS bid_id", uuid.Nil).
+		Column("organization.id AS bidder_id").
+		Column("?::uuid AS owner_id", organizationId).
+		From("organization").
+		Where(squirrel.Eq{"organization.id": bidderOrganizationIds})
+
+	return r.getConflicts(ctx, candidate)
+}
+
+// Текущие конфликты всех предложений по тендеру. Предложение от имени организации проверяется по ней,
+// личное - по всем организациям автора: и тем, где он ответственный, и тем, где у него есть роль
+func (r *OrganizationRelationRepo) GetTenderBidConflicts(ctx context.Context, tenderId uuid.UUID) ([]entity.OrganizationConflict, error) {
+	candidate := squirrel.
+		Select("bid.id AS bid_id", "bidder.organization_id AS bidder_id", "tender.organization_id AS owner_id").
+		From("bid").
+		InnerJoin("tender ON tender.id = bid.tender_id").
+		InnerJoin("LATERAL (SELECT bid.organization_id WHERE bid.organization_id IS NOT NULL "+
+			"UNION SELECT organization_id FROM organization_responsible WHERE bid.organization_id IS NULL AND user_id = bid.author_id "+
+			"UNION SELECT organization_id FROM organization_role WHERE bid.organization_id IS NULL AND employee_id = bid.author_id) bidder ON true").
+		Where("bid.tender_id = ?", tenderId)
+
+	return r.getConflicts(ctx, candidate)
+}
@@ -0,0 +1,358 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo/repo_errors"
+	"tender-management-api/pkg/postgres"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// За полгода вес отзыва в репутации падает вдвое
+const reputationHalfLifeDays = 180
+
+type ReviewRepo struct {
+	*postgres.Postgres
+}
+
+func NewReviewRepo(pgdb *postgres.Postgres) *ReviewRepo {
+	return &ReviewRepo{pgdb}
+}
+
+const reviewColumns = "review.id, review.description, review.created_at, review.author_id, review.receiver_id, review.bid_id, " +
+	"review.receiver_organization_id, COALESCE(review.quality_rating, 0), COALESCE(review.timeliness_rating, 0), " +
+	"COALESCE(review.communication_rating, 0), review.version, review.updated_at, review.reply, review.replied_at, " +
+	"review.hidden_at, review.hidden_by, review.hidden_reason, review.contract_id, " +
+	"bid.tender_id, tender.organization_id, organization.name, employee.username"
+
+// Отзыв всегда отдается вместе с тендером и организацией, от имени которой он оставлен
+func (r *ReviewRepo) selectReviews() squirrel.SelectBuilder {
+	return r.SqlBuilder.
+		Select(reviewColumns).
+		From("review").
+		InnerJoin("bid ON bid.id = review.bid_id").
+		InnerJoin("tender ON tender.id = bid.tender_id").
+		InnerJoin("organization ON organization.id = tender.organization_id").
+		InnerJoin("employee ON employee.id = review.author_id")
+}
+
+func scanReview(row rowScanner) (*entity.Review, error) {
+	var review entity.Review
+	var createdAt time.Time
+	var updatedAt, repliedAt, hiddenAt sql.NullTime
+	var reply, hiddenReason sql.NullString
+	err := row.Scan(&review.Id, &review.Description, &createdAt, &review.AuthorId, &review.ReceiverId, &review.BidId,
+		&review.ReceiverOrganization, &review.QualityRating, &review.TimelinessRating, &review.CommunicationRating,
+		&review.Version, &updatedAt, &reply, &repliedAt, &hiddenAt, &review.HiddenBy, &hiddenReason, &review.ContractId,
+		&review.TenderId, &review.ReviewerOrganizationId, &review.ReviewerOrganizationName, &review.AuthorUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	review.CreatedAt = createdAt.Format(time.RFC3339)
+	review.UpdatedAt, review.RepliedAt, review.HiddenAt = formatNullTime(updatedAt), formatNullTime(repliedAt), formatNullTime(hiddenAt)
+	review.Reply, review.HiddenReason = reply.String, hiddenReason.String
+
+	return &review, nil
+}
+
+func (r *ReviewRepo) GetReviewById(ctx context.Context, id string) (*entity.Review, error) {
+	uuidForm, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlReq, args, _ := r.selectReviews().
+		Where("review.id = ?", uuidForm).
+		ToSql()
+
+	review, err := scanReview(r.Database.QueryRowContext(ctx, sqlReq, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo_errors.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *ReviewRepo) GetBidReviews(ctx context.Context, bidId uuid.UUID, includeHidden bool) ([]entity.Review, error) {
+	builder := r.selectReviews().
+		Where("review.bid_id = ?", bidId).
+		OrderBy("review.created_at DESC")
+	if !includeHidden {
+		builder = builder.Where("review.hidden_at IS NULL")
+	}
+
+	return r.queryReviews(ctx, builder)
+}
+
+func (r *ReviewRepo) queryReviews(ctx context.Context, builder squirrel.SelectBuilder) ([]entity.Review, error) {
+	sqlReq, args, _ := builder.ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := make([]entity.Review, 0)
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return reviews, err
+		}
+		reviews = append(reviews, *review)
+	}
+	if err = rows.Err(); err != nil {
+		return reviews, err
+	}
+
+	return reviews, nil
+}
+
+// Скрытые отзывы в чужую выдачу не попадают, новые отзывы идут первыми
+func (r *ReviewRepo) GetReviewsByReceiverId(ctx context.Context, receiverId uuid.UUID, filter *entity.ReviewFilter, pg *entity.PaginationInput) ([]entity.Review, error) {
+	builder := r.selectReviews().
+		Where("review.receiver_id = ?", receiverId).
+		Where("review.hidden_at IS NULL")
+
+	rating := "(review.quality_rating + review.timeliness_rating + review.communication_rating) / 3.0"
+	if filter.MinRating > 0 {
+		builder = builder.Where(rating+" >= ?", filter.MinRating)
+	}
+	if filter.MaxRating > 0 {
+		builder = builder.Where(rating+" <= ?", filter.MaxRating)
+	}
+	if filter.DateFrom != "" {
+		builder = builder.Where("review.created_at >= ?::date", filter.DateFrom)
+	}
+	if filter.DateTo != "" {
+		builder = builder.Where("review.created_at < ?::date + 1", filter.DateTo)
+	}
+	if filter.ReviewerOrganizationId != "" {
+		builder = builder.Where("tender.organization_id = ?", filter.ReviewerOrganizationId)
+	}
+	if len(filter.ServiceTypes) != 0 {
+		builder = builder.
+			InnerJoin("tender_version ON tender_version.tender_id = tender.id AND tender_version.version = tender.current_version").
+			Where("tender_version.service_type = ANY(?)", pq.Array(filter.ServiceTypes))
+	}
+
+	return r.queryReviews(ctx, builder.
+		OrderBy("review.created_at DESC").
+		Offset(uint64(pg.Offset)).
+		Limit(uint64(pg.Limit)))
+}
+
+// Текущее содержимое уходит в историю, если окно редактирования с момента создания отзыва еще не закрылось
+func (r *ReviewRepo) EditReview(ctx context.Context, id uuid.UUID, input *entity.EditReviewInput, window time.Duration) error {
+	tx, err := r.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	saveVersionSql, args, _ := r.SqlBuilder.
+		Insert("review_version").
+		Columns("review_id", "version", "description", "quality_rating", "timeliness_rating", "communication_rating", "created_at").
+		Select(squirrel.
+			Select("id", "version", "description", "quality_rating", "timeliness_rating", "communication_rating",
+				"COALESCE(updated_at, created_at)").
+			From("review").
+			Where("id = ?", id).
+			Where("created_at > LOCALTIMESTAMP - ?::interval", fmt.Sprintf("%d seconds", int64(window.Seconds())))).
+		ToSql()
+
+	res, err := tx.Exec(saveVersionSql, args...)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+	if affected == 0 {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return repo_errors.ErrExpired
+	}
+
+	updateReviewSql, args, _ := r.SqlBuilder.
+		Update("review").
+		Set("description", input.Description).
+		Set("quality_rating", input.QualityRating).
+		Set("timeliness_rating", input.TimelinessRating).
+		Set("communication_rating", input.CommunicationRating).
+		Set("version", squirrel.Expr("version + 1")).
+		Set("updated_at", squirrel.Expr("LOCALTIMESTAMP")).
+		Where("id = ?", id).
+		ToSql()
+
+	if _, err = tx.Exec(updateReviewSql, args...); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *ReviewRepo) GetReviewVersions(ctx context.Context, id uuid.UUID) ([]entity.ReviewVersion, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("version", "COALESCE(description, '')",
+			"COALESCE(quality_rating, 0)", "COALESCE(timeliness_rating, 0)", "COALESCE(communication_rating, 0)", "created_at").
+		From("review_version").
+		Where("review_id = ?", id).
+		OrderBy("version ASC").
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := make([]entity.ReviewVersion, 0)
+	for rows.Next() {
+		var version entity.ReviewVersion
+		var createdAt time.Time
+		if err := rows.Scan(&version.Version, &version.Description, &version.QualityRating,
+			&version.TimelinessRating, &version.CommunicationRating, &createdAt); err != nil {
+			return versions, err
+		}
+		version.CreatedAt = createdAt.Format(time.RFC3339)
+		versions = append(versions, version)
+	}
+	if err = rows.Err(); err != nil {
+		return versions, err
+	}
+
+	return versions, nil
+}
+
+// Ответить на отзыв можно только один раз
+func (r *ReviewRepo) ReplyToReview(ctx context.Context, id uuid.UUID, reply string) error {
+	sqlReq, args, _ := r.SqlBuilder.
+		Update("review").
+		Set("reply", reply).
+		Set("replied_at", squirrel.Expr("LOCALTIMESTAMP")).
+		Where("id = ?", id).
+		Where("reply IS NULL").
+		ToSql()
+
+	res, err := r.Database.ExecContext(ctx, sqlReq, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo_errors.ErrAlreadyExists
+	}
+
+	return nil
+}
+
+func (r *ReviewRepo) HideReview(ctx context.Context, id uuid.UUID, moderatorId uuid.UUID, reason string) error {
+	sqlReq, args, _ := r.SqlBuilder.
+		Update("review").
+		Set("hidden_at", squirrel.Expr("LOCALTIMESTAMP")).
+		Set("hidden_by", moderatorId).
+		Set("hidden_reason", reason).
+		Where("id = ?", id).
+		ToSql()
+
+	res, err := r.Database.ExecContext(ctx, sqlReq, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo_errors.ErrNotFound
+	}
+
+	return nil
+}
+
+// Отзывы без оценок (оставленные до их появления) и скрытые модераторами в репутации не учитываются
+func (r *ReviewRepo) getReputations(ctx context.Context, subjectColumn string, subjectIds []uuid.UUID) ([]entity.Reputation, error) {
+	reputations := make([]entity.Reputation, 0)
+	if len(subjectIds) == 0 {
+		return reputations, nil
+	}
+
+	weighted := squirrel.
+		Select(subjectColumn, "quality_rating", "timeliness_rating", "communication_rating").
+		Column("power(0.5, EXTRACT(EPOCH FROM LOCALTIMESTAMP - created_at)::float8 / 86400 / ?::float8) AS weight", reputationHalfLifeDays).
+		From("review").
+		Where(subjectColumn+" = ANY(?)", pq.Array(subjectIds)).
+		Where("quality_rating IS NOT NULL").
+		Where("hidden_at IS NULL")
+
+	sqlReq, args, _ := r.SqlBuilder.
+		Select(subjectColumn,
+			"SUM(weight * quality_rating) / SUM(weight)",
+			"SUM(weight * timeliness_rating) / SUM(weight)",
+			"SUM(weight * communication_rating) / SUM(weight)",
+			"COUNT(*)").
+		FromSelect(weighted, "weighted").
+		GroupBy(subjectColumn).
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reputation entity.Reputation
+		if err := rows.Scan(&reputation.SubjectId, &reputation.Quality, &reputation.Timeliness,
+			&reputation.Communication, &reputation.ReviewsCount); err != nil {
+			return reputations, err
+		}
+		reputations = append(reputations, reputation)
+	}
+	if err = rows.Err(); err != nil {
+		return reputations, err
+	}
+
+	return reputations, nil
+}
+
+func (r *ReviewRepo) GetEmployeeReputations(ctx context.Context, employeeIds []uuid.UUID) ([]entity.Reputation, error) {
+	return r.getReputations(ctx, "receiver_id", employeeIds)
+}
+
+func (r *ReviewRepo) GetOrganizationReputations(ctx context.Context, organizationIds []uuid.UUID) ([]entity.Reputation, error) {
+	return r.getReputations(ctx, "receiver_organization_id", organizationIds)
+}
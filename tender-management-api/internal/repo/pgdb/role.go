@@ -0,0 +1,163 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo/repo_errors"
+	"tender-management-api/pkg/postgres"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+type RoleRepo struct {
+	*postgres.Postgres
+}
+
+func NewRoleRepo(pgdb *postgres.Postgres) *RoleRepo {
+	return &RoleRepo{pgdb}
+}
+
+const roleAssignmentColumns = "organization_role.id, organization_role.organization_id, organization_role.employee_id, " +
+	"employee.username, organization_role.role, organization_role.granted_by, organization_role.created_at"
+
+func scanRoleAssignment(row rowScanner) (*entity.RoleAssignment, error) {
+	var assignment entity.RoleAssignment
+	var createdAt time.Time
+	err := row.Scan(&assignment.Id, &assignment.OrganizationId, &assignment.EmployeeId,
+		&assignment.EmployeeUsername, &assignment.Role, &assignment.GrantedBy, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	assignment.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return &assignment, nil
+}
+
+func (r *RoleRepo) selectRoleAssignments() squirrel.SelectBuilder {
+	return r.SqlBuilder.
+		Select(roleAssignmentColumns).
+		From("organization_role").
+		InnerJoin("employee ON employee.id = organization_role.employee_id")
+}
+
+func (r *RoleRepo) CreateRoleAssignment(ctx context.Context, input *entity.CreateRoleAssignmentInput) (uuid.UUID, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Insert("organization_role").
+		Columns("organization_id", "employee_id", "role", "granted_by").
+		Values(input.OrganizationId, input.EmployeeId, input.Role, input.GrantedBy).
+		Suffix("RETURNING id").
+		ToSql()
+
+	var id uuid.UUID
+	if err := r.Database.QueryRowContext(ctx, sqlReq, args...).Scan(&id); err != nil {
+		if isUniqueViolation(err) {
+			return uuid.Nil, repo_errors.ErrAlreadyExists
+		}
+		if isForeignKeyViolation(err) {
+			return uuid.Nil, repo_errors.ErrNotFound
+		}
+
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
+func (r *RoleRepo) GetRoleAssignmentById(ctx context.Context, id uuid.UUID) (*entity.RoleAssignment, error) {
+	sqlReq, args, _ := r.selectRoleAssignments().
+		Where("organization_role.id = ?", id).
+		ToSql()
+
+	assignment, err := scanRoleAssignment(r.Database.QueryRowContext(ctx, sqlReq, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo_errors.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return assignment, nil
+}
+
+func (r *RoleRepo) GetOrganizationRoleAssignments(ctx context.Context, organizationId uuid.UUID) ([]entity.RoleAssignment, error) {
+	sqlReq, args, _ := r.selectRoleAssignments().
+		Where("organization_role.organization_id = ?", organizationId).
+		OrderBy("employee.username ASC", "organization_role.role ASC").
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	assignments := make([]entity.RoleAssignment, 0)
+	for rows.Next() {
+		assignment, err := scanRoleAssignment(rows)
+		if err != nil {
+			return assignments, err
+		}
+		assignments = append(assignments, *assignment)
+	}
+	if err = rows.Err(); err != nil {
+		return assignments, err
+	}
+
+	return assignments, nil
+}
+
+func (r *RoleRepo) GetEmployeeRoles(ctx context.Context, employeeId uuid.UUID, organizationId uuid.UUID) ([]string, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("role").
+		From("organization_role").
+		Where("employee_id = ?", employeeId).
+		Where("organization_id = ?", organizationId).
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := make([]string, 0)
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return roles, err
+		}
+		roles = append(roles, role)
+	}
+	if err = rows.Err(); err != nil {
+		return roles, err
+	}
+
+	return roles, nil
+}
+
+func (r *RoleRepo) DeleteRoleAssignment(ctx context.Context, id uuid.UUID) error {
+	sqlReq, args, _ := r.SqlBuilder.
+		Delete("organization_role").
+		Where("id = ?", id).
+		ToSql()
+
+	res, err := r.Database.ExecContext(ctx, sqlReq, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo_errors.ErrNotFound
+	}
+
+	return nil
+}
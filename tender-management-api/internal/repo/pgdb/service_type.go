@@ -0,0 +1,191 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo/repo_errors"
+	"tender-management-api/pkg/postgres"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type ServiceTypeRepo struct {
+	*postgres.Postgres
+}
+
+func NewServiceTypeRepo(pgdb *postgres.Postgres) *ServiceTypeRepo {
+	return &ServiceTypeRepo{pgdb}
+}
+
+const foreignKeyViolationCode = "23503"
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+
+	return errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode
+}
+
+func nullIfEmpty(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func scanServiceType(row rowScanner) (*entity.ServiceType, error) {
+	var serviceType entity.ServiceType
+	var parentCode sql.NullString
+	var createdAt time.Time
+	err := row.Scan(&serviceType.Code, &serviceType.Name, &parentCode, &createdAt)
+	serviceType.ParentCode = parentCode.String
+	serviceType.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return &serviceType, err
+}
+
+func (r *ServiceTypeRepo) GetServiceTypes(ctx context.Context) ([]entity.ServiceType, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("code", "name", "parent_code", "created_at").
+		From("service_type").
+		OrderBy("code ASC").
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	serviceTypes := make([]entity.ServiceType, 0)
+	for rows.Next() {
+		serviceType, err := scanServiceType(rows)
+		if err != nil {
+			return serviceTypes, err
+		}
+		serviceTypes = append(serviceTypes, *serviceType)
+	}
+	if err = rows.Err(); err != nil {
+		return serviceTypes, err
+	}
+
+	return serviceTypes, nil
+}
+
+func (r *ServiceTypeRepo) GetServiceTypeByCode(ctx context.Context, code string) (*entity.ServiceType, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("code", "name", "parent_code", "created_at").
+		From("service_type").
+		Where("code = ?", code).
+		ToSql()
+
+	serviceType, err := scanServiceType(r.Database.QueryRowContext(ctx, sqlReq, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return serviceType, repo_errors.ErrNotFound
+		}
+
+		return serviceType, err
+	}
+
+	return serviceType, nil
+}
+
+func (r *ServiceTypeRepo) CreateServiceType(ctx context.Context, serviceType *entity.ServiceType) error {
+	sqlReq, args, _ := r.SqlBuilder.
+		Insert("service_type").
+		Columns("code", "name", "parent_code").
+		Values(serviceType.Code, serviceType.Name, nullIfEmpty(serviceType.ParentCode)).
+		ToSql()
+
+	if _, err := r.Database.ExecContext(ctx, sqlReq, args...); err != nil {
+		if isUniqueViolation(err) {
+			return repo_errors.ErrAlreadyExists
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *ServiceTypeRepo) UpdateServiceType(ctx context.Context, code string, name string, parentCode string) error {
+	sqlReq, args, _ := r.SqlBuilder.
+		Update("service_type").
+		Set("name", name).
+		Set("parent_code", nullIfEmpty(parentCode)).
+		Where("code = ?", code).
+		ToSql()
+
+	res, err := r.Database.ExecContext(ctx, sqlReq, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo_errors.ErrNotFound
+	}
+
+	return nil
+}
+
+// Удалить можно только категорию без подкатегорий, на которую не ссылается ни один тендер или шаблон
+func (r *ServiceTypeRepo) DeleteServiceType(ctx context.Context, code string) error {
+	sqlReq, args, _ := r.SqlBuilder.
+		Delete("service_type").
+		Where("code = ?", code).
+		ToSql()
+
+	res, err := r.Database.ExecContext(ctx, sqlReq, args...)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return repo_errors.ErrInUse
+		}
+
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo_errors.ErrNotFound
+	}
+
+	return nil
+}
+
+// Возвращает переданные категории вместе со всеми их подкатегориями на любой глубине
+func (r *ServiceTypeRepo) ExpandServiceTypes(ctx context.Context, codes []string) ([]string, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("code").
+		Prefix("WITH RECURSIVE subtree AS ("+
+			"SELECT code FROM service_type WHERE code = ANY(?) "+
+			"UNION SELECT service_type.code FROM service_type INNER JOIN subtree ON service_type.parent_code = subtree.code)", pq.Array(codes)).
+		From("subtree").
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	expanded := make([]string, 0, len(codes))
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return expanded, err
+		}
+		expanded = append(expanded, code)
+	}
+	if err = rows.Err(); err != nil {
+		return expanded, err
+	}
+
+	return expanded, nil
+}
@@ -0,0 +1,177 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo/repo_errors"
+	"tender-management-api/pkg/postgres"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+type SigningRepo struct {
+	*postgres.Postgres
+}
+
+func NewSigningRepo(pgdb *postgres.Postgres) *SigningRepo {
+	return &SigningRepo{pgdb}
+}
+
+const employeeKeyColumns = "id, employee_id, public_key, created_at, revoked_at"
+
+func scanEmployeeKey(row rowScanner) (*entity.EmployeeKey, error) {
+	var key entity.EmployeeKey
+	var createdAt time.Time
+	var revokedAt sql.NullTime
+	if err := row.Scan(&key.Id, &key.EmployeeId, &key.PublicKey, &createdAt, &revokedAt); err != nil {
+		return nil, err
+	}
+
+	key.CreatedAt = createdAt.Format(time.RFC3339)
+	key.RevokedAt = formatNullTime(revokedAt)
+
+	return &key, nil
+}
+
+func (r *SigningRepo) CreateEmployeeKey(ctx context.Context, employeeId uuid.UUID, publicKey []byte) (*entity.EmployeeKey, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Insert("employee_key").
+		Columns("employee_id", "public_key").
+		Values(employeeId, publicKey).
+		Suffix("RETURNING " + employeeKeyColumns).
+		ToSql()
+
+	key, err := scanEmployeeKey(r.Database.QueryRowContext(ctx, sqlReq, args...))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, repo_errors.ErrAlreadyExists
+		}
+
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (r *SigningRepo) GetEmployeeKeys(ctx context.Context, employeeId uuid.UUID) ([]entity.EmployeeKey, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select(employeeKeyColumns).
+		From("employee_key").
+		Where("employee_id = ?", employeeId).
+		OrderBy("created_at DESC").
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]entity.EmployeeKey, 0)
+	for rows.Next() {
+		key, err := scanEmployeeKey(rows)
+		if err != nil {
+			return keys, err
+		}
+		keys = append(keys, *key)
+	}
+	if err = rows.Err(); err != nil {
+		return keys, err
+	}
+
+	return keys, nil
+}
+
+func (r *SigningRepo) GetEmployeeKeyById(ctx context.Context, id string) (*entity.EmployeeKey, error) {
+	uuidForm, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlReq, args, _ := r.SqlBuilder.
+		Select(employeeKeyColumns).
+		From("employee_key").
+		Where("id = ?", uuidForm).
+		ToSql()
+
+	key, err := scanEmployeeKey(r.Database.QueryRowContext(ctx, sqlReq, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo_errors.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Отозванный ключ остается в базе, чтобы можно было проверить уже поставленные им подписи
+func (r *SigningRepo) RevokeEmployeeKey(ctx context.Context, id uuid.UUID) error {
+	sqlReq, args, _ := r.SqlBuilder.
+		Update("employee_key").
+		Set("revoked_at", squirrel.Expr("LOCALTIMESTAMP")).
+		Where("id = ?", id).
+		Where("revoked_at IS NULL").
+		ToSql()
+
+	_, err := r.Database.ExecContext(ctx, sqlReq, args...)
+
+	return err
+}
+
+func (r *SigningRepo) GetBidVersionSignature(ctx context.Context, bidId uuid.UUID, version int) (*entity.BidVersionSignature, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("bid_id", "version", "name", "COALESCE(description, '')", "signature", "signing_key_id", "signed_at").
+		From("bid_version").
+		Where("bid_id = ?", bidId).
+		Where("version = ?", version).
+		ToSql()
+
+	var signature entity.BidVersionSignature
+	var signedAt sql.NullTime
+	err := r.Database.QueryRowContext(ctx, sqlReq, args...).Scan(&signature.BidId, &signature.Version, &signature.Name,
+		&signature.Description, &signature.Signature, &signature.SigningKeyId, &signedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repo_errors.ErrNotFound
+		}
+
+		return nil, err
+	}
+	signature.SignedAt = formatNullTime(signedAt)
+
+	return &signature, nil
+}
+
+// Подпись версии ставится один раз, повторная попытка дает ErrAlreadyExists
+func (r *SigningRepo) SignBidVersion(ctx context.Context, bidId uuid.UUID, version int, keyId uuid.UUID, signature []byte) error {
+	sqlReq, args, _ := r.SqlBuilder.
+		Update("bid_version").
+		Set("signature", signature).
+		Set("signing_key_id", keyId).
+		Set("signed_at", squirrel.Expr("LOCALTIMESTAMP")).
+		Where("bid_id = ?", bidId).
+		Where("version = ?", version).
+		Where("signature IS NULL").
+		ToSql()
+
+	res, err := r.Database.ExecContext(ctx, sqlReq, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo_errors.ErrAlreadyExists
+	}
+
+	return nil
+}
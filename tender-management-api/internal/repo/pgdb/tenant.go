@@ -0,0 +1,106 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"tender-management-api/internal/repo/repo_errors"
+	"tender-management-api/pkg/postgres"
+
+	"github.com/google/uuid"
+)
+
+// Таблица tenant не изолирована политиками RLS: по ней арендатор выбирается до начала работы с данными
+type TenantRepo struct {
+	*postgres.Postgres
+}
+
+func NewTenantRepo(pgdb *postgres.Postgres) *TenantRepo {
+	return &TenantRepo{pgdb}
+}
+
+// Пока арендатор не выбран, политика principal_lookup показывает только сотрудника, чей username или id
+// записан в app.principal
+func (r *TenantRepo) getEmployeeTenantId(ctx context.Context, principal string, column string, value any) (uuid.UUID, error) {
+	tx, err := r.Database.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err = tx.ExecContext(ctx, "SELECT set_config('app.principal', $1, true)", principal); err != nil {
+		return uuid.Nil, err
+	}
+
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("tenant_id").
+		From("employee").
+		Where(column+" = ?", value).
+		ToSql()
+
+	var tenantId uuid.UUID
+	if err = tx.QueryRowContext(ctx, sqlReq, args...).Scan(&tenantId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, repo_errors.ErrNotFound
+		}
+
+		return uuid.Nil, err
+	}
+
+	return tenantId, nil
+}
+
+func (r *TenantRepo) GetEmployeeTenantId(ctx context.Context, username string) (uuid.UUID, error) {
+	return r.getEmployeeTenantId(ctx, username, "username", username)
+}
+
+func (r *TenantRepo) GetEmployeeTenantIdById(ctx context.Context, employeeId uuid.UUID) (uuid.UUID, error) {
+	return r.getEmployeeTenantId(ctx, employeeId.String(), "id", employeeId)
+}
+
+func (r *TenantRepo) GetDefaultTenantId(ctx context.Context) (uuid.UUID, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("id").
+		From("tenant").
+		Where("is_default").
+		ToSql()
+
+	var tenantId uuid.UUID
+	if err := r.Database.QueryRowContext(ctx, sqlReq, args...).Scan(&tenantId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, repo_errors.ErrNotFound
+		}
+
+		return uuid.Nil, err
+	}
+
+	return tenantId, nil
+}
+
+func (r *TenantRepo) GetTenantIds(ctx context.Context) ([]uuid.UUID, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("id").
+		From("tenant").
+		OrderBy("created_at ASC").
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tenantIds := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var tenantId uuid.UUID
+		if err := rows.Scan(&tenantId); err != nil {
+			return tenantIds, err
+		}
+		tenantIds = append(tenantIds, tenantId)
+	}
+	if err = rows.Err(); err != nil {
+		return tenantIds, err
+	}
+
+	return tenantIds, nil
+}
@@ -3,6 +3,7 @@ package pgdb
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"tender-management-api/internal/common"
 	"tender-management-api/internal/entity"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type TenderRepo struct {
@@ -22,22 +24,81 @@ func NewTenderRepo(pgdb *postgres.Postgres) *TenderRepo {
 	return &TenderRepo{pgdb}
 }
 
+const tenderColumns = "tender.created_at, tender.id, tender.status, tender.organization_id, tender_version.version, " +
+	"tender_version.name, tender_version.description, tender_version.service_type, tender.deadline, " +
+	"tender_version.tags, tender_version.attributes, tender.requires_signature, tender.conflict_policy"
+
+func scanTender(row rowScanner) (*entity.Tender, error) {
+	var tender entity.Tender
+	var createdAt time.Time
+	var deadline sql.NullTime
+	var attributes []byte
+	err := row.Scan(&createdAt, &tender.Id, &tender.Status, &tender.OrganizationId,
+		&tender.Version, &tender.Name, &tender.Description, &tender.ServiceType, &deadline,
+		pq.Array(&tender.Tags), &attributes, &tender.RequiresSignature, &tender.ConflictPolicy)
+	if err != nil {
+		return &tender, err
+	}
+
+	tender.CreatedAt = createdAt.Format(time.RFC3339)
+	tender.Deadline = formatNullTime(deadline)
+	if tender.Tags == nil {
+		tender.Tags = make([]string, 0)
+	}
+	tender.Attributes = make(map[string]string)
+	if err = json.Unmarshal(attributes, &tender.Attributes); err != nil {
+		return &tender, err
+	}
+
+	return &tender, nil
+}
+
+func encodeTenderAttributes(attributes map[string]string) (string, error) {
+	if attributes == nil {
+		attributes = make(map[string]string)
+	}
+	encoded, err := json.Marshal(attributes)
+
+	return string(encoded), err
+}
+
+func encodeTenderTags(tags []string) any {
+	if tags == nil {
+		tags = make([]string, 0)
+	}
+
+	return pq.Array(tags)
+}
+
+func applyTenderFilter(builder squirrel.SelectBuilder, filter *entity.TenderFilter) (squirrel.SelectBuilder, error) {
+	if filter == nil {
+		return builder, nil
+	}
+
+	if len(filter.ServiceTypes) > 0 {
+		builder = builder.Where(squirrel.Eq{"tender_version.service_type": filter.ServiceTypes})
+	}
+	if len(filter.Tags) > 0 {
+		builder = builder.Where("tender_version.tags @> ?", pq.Array(filter.Tags))
+	}
+	if len(filter.Attributes) > 0 {
+		attributes, err := encodeTenderAttributes(filter.Attributes)
+		if err != nil {
+			return builder, err
+		}
+		builder = builder.Where("tender_version.attributes @> ?", attributes)
+	}
+
+	return builder, nil
+}
+
 func (r *TenderRepo) CreateTender(ctx context.Context, input *entity.CreateTenderInput) (uuid.UUID, error) {
-	tx, err := r.Database.Begin()
+	tx, err := r.Database.BeginTx(ctx, nil)
 	if err != nil {
 		return uuid.Nil, err
 	}
 
-	createTenderSql, args, _ := r.SqlBuilder.
-		Insert("tender").
-		Columns("status", "organization_id", "current_version").
-		Values(common.Created, input.OrganizationId, 1).
-		Suffix("RETURNING id").
-		RunWith(tx).
-		ToSql()
-
-	var tenderId uuid.UUID
-	err = tx.QueryRow(createTenderSql, args...).Scan(&tenderId)
+	tenderId, _, err := insertTender(tx, r.SqlBuilder, input)
 	if err != nil {
 		if e := tx.Rollback(); e != nil {
 			return uuid.Nil, err
@@ -46,17 +107,52 @@ func (r *TenderRepo) CreateTender(ctx context.Context, input *entity.CreateTende
 		return uuid.Nil, err
 	}
 
-	createVersionReq, args, _ := r.SqlBuilder.
-		Insert("tender_version").
-		Columns("name", "description", "service_type", "version", "tender_id").
-		Values(input.Name, input.Description, input.ServiceType, 1, tenderId).
-		RunWith(tx).
+	if err = tx.Commit(); err != nil {
+		return uuid.Nil, err
+	}
+
+	return tenderId, nil
+}
+
+// Копия создается вместе с вложениями версии fromVersion в одной транзакции,
+// чтобы при ошибке копирования не оставался тендер без вложений
+func (r *TenderRepo) CloneTender(ctx context.Context, fromTenderId uuid.UUID, fromVersion int, input *entity.CreateTenderInput) (uuid.UUID, error) {
+	tx, err := r.Database.BeginTx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	fromVersionSql, args, _ := r.SqlBuilder.
+		Select("id").
+		From("tender_version").
+		Where("tender_id = ?", fromTenderId).
+		Where("version = ?", fromVersion).
 		ToSql()
 
-	_, err = tx.Exec(createVersionReq, args...)
+	var fromVersionId uuid.UUID
+	if err = tx.QueryRow(fromVersionSql, args...).Scan(&fromVersionId); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return uuid.Nil, e
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, repo_errors.ErrNotFound
+		}
+
+		return uuid.Nil, err
+	}
+
+	tenderId, versionId, err := insertTender(tx, r.SqlBuilder, input)
 	if err != nil {
 		if e := tx.Rollback(); e != nil {
-			return uuid.Nil, err
+			return uuid.Nil, e
+		}
+
+		return uuid.Nil, err
+	}
+
+	if err = copyVersionAttachments(tx, r.SqlBuilder, "tender_version_id", fromVersionId, versionId); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return uuid.Nil, e
 		}
 
 		return uuid.Nil, err
@@ -69,35 +165,63 @@ func (r *TenderRepo) CreateTender(ctx context.Context, input *entity.CreateTende
 	return tenderId, nil
 }
 
+// Возвращает id тендера и id его первой версии
+func insertTender(tx *sql.Tx, builder squirrel.StatementBuilderType, input *entity.CreateTenderInput) (uuid.UUID, uuid.UUID, error) {
+	createTenderSql, args, _ := builder.
+		Insert("tender").
+		Columns("status", "organization_id", "current_version", "deadline", "requires_signature", "conflict_policy").
+		Values(common.Created, input.OrganizationId, 1, input.Deadline, input.RequiresSignature, input.ConflictPolicy).
+		Suffix("RETURNING id").
+		ToSql()
+
+	var tenderId uuid.UUID
+	if err := tx.QueryRow(createTenderSql, args...).Scan(&tenderId); err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	attributes, err := encodeTenderAttributes(input.Attributes)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	createVersionReq, args, _ := builder.
+		Insert("tender_version").
+		Columns("name", "description", "service_type", "tags", "attributes", "version", "tender_id").
+		Values(input.Name, input.Description, input.ServiceType, encodeTenderTags(input.Tags), attributes, 1, tenderId).
+		Suffix("RETURNING id").
+		ToSql()
+
+	var versionId uuid.UUID
+	if err = tx.QueryRow(createVersionReq, args...).Scan(&versionId); err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return tenderId, versionId, nil
+}
+
 func (r *TenderRepo) GetTenderById(ctx context.Context, id string) (*entity.Tender, error) {
 	getTenderSql, args, _ := r.SqlBuilder.
-		Select("tender.created_at, tender.id, tender.status, tender.organization_id, tender_version.version, tender_version.name, tender_version.description, tender_version.service_type").
+		Select(tenderColumns).
 		From("tender").
 		InnerJoin("tender_version on tender.id = tender_version.tender_id and tender.current_version = tender_version.version").
 		Where("tender.id = ?", id).
 		ToSql()
 
-	var tender entity.Tender
-	var createdAt time.Time
-	row := r.Database.QueryRow(getTenderSql, args...)
-	err := row.Scan(&createdAt, &tender.Id, &tender.Status, &tender.OrganizationId,
-		&tender.Version, &tender.Name, &tender.Description, &tender.ServiceType)
-
-	tender.CreatedAt = createdAt.Format(time.RFC3339)
+	tender, err := scanTender(r.Database.QueryRowContext(ctx, getTenderSql, args...))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return &tender, repo_errors.ErrNotFound
+			return tender, repo_errors.ErrNotFound
 		}
 
-		return &tender, err
+		return tender, err
 	}
 
-	return &tender, nil
+	return tender, nil
 }
 
 // Можно ругаться, если новая версия тендера не отличается от последней
 // Но в задании такого требования нет + наверно не успею это сделать, поэтому оставлю как есть
-func (r *TenderRepo) EditTenderById(ctx context.Context, id string, name string, description string, serviceType string) error {
+func (r *TenderRepo) EditTenderById(ctx context.Context, id string, input *entity.EditTenderInput) error {
 	uuidForm, err := uuid.Parse(id)
 	if err != nil {
 		return err
@@ -131,15 +255,17 @@ func (r *TenderRepo) EditTenderById(ctx context.Context, id string, name string,
 	}
 
 	getOldValuesSql, args, _ := r.SqlBuilder.
-		Select("name", "description", "service_type").
+		Select("id", "name", "description", "service_type", "tags").
 		From("tender_version").
 		Where("tender_id = ?", id).
 		Where("version = ?", currentVersion-1).
 		RunWith(tx).
 		ToSql()
 
+	var prevVersionId uuid.UUID
 	var prevName, prevDescr, prevServType string
-	if err = tx.QueryRow(getOldValuesSql, args...).Scan(&prevName, &prevDescr, &prevServType); err != nil {
+	var prevTags []string
+	if err = tx.QueryRow(getOldValuesSql, args...).Scan(&prevVersionId, &prevName, &prevDescr, &prevServType, pq.Array(&prevTags)); err != nil {
 		if e := tx.Rollback(); e != nil {
 			return e
 		}
@@ -147,6 +273,7 @@ func (r *TenderRepo) EditTenderById(ctx context.Context, id string, name string,
 		return err
 	}
 
+	name, description, serviceType, tags := input.Name, input.Description, input.ServiceType, input.Tags
 	if name == "" {
 		name = prevName
 	}
@@ -159,14 +286,30 @@ func (r *TenderRepo) EditTenderById(ctx context.Context, id string, name string,
 		serviceType = prevServType
 	}
 
+	if tags == nil {
+		tags = prevTags
+	}
+
+	// сервис передает атрибуты уже объединенными с предыдущей версией
+	attributes, err := encodeTenderAttributes(input.Attributes)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
 	createVersionSql, args, _ := r.SqlBuilder.
 		Insert("tender_version").
-		Columns("name", "description", "service_type", "version", "tender_id").
-		Values(name, description, serviceType, currentVersion, uuidForm).
+		Columns("name", "description", "service_type", "tags", "attributes", "version", "tender_id").
+		Values(name, description, serviceType, encodeTenderTags(tags), attributes, currentVersion, uuidForm).
+		Suffix("RETURNING id").
 		RunWith(tx).
 		ToSql()
 
-	_, err = tx.Exec(createVersionSql, args...)
+	var newVersionId uuid.UUID
+	err = tx.QueryRow(createVersionSql, args...).Scan(&newVersionId)
 	if err != nil {
 		if e := tx.Rollback(); e != nil {
 			return e
@@ -175,6 +318,14 @@ func (r *TenderRepo) EditTenderById(ctx context.Context, id string, name string,
 		return err
 	}
 
+	if err = copyVersionAttachments(tx, r.SqlBuilder, "tender_version_id", prevVersionId, newVersionId); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return e
+		}
+
+		return err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return err
 	}
@@ -194,7 +345,7 @@ func (r *TenderRepo) UpdateTenderStatusById(ctx context.Context, id string, newS
 		Where("id = ?", uuidForm).
 		ToSql()
 
-	_, err = r.Database.Exec(updateStatusSql, args...)
+	_, err = r.Database.ExecContext(ctx, updateStatusSql, args...)
 	if err != nil {
 		return err
 	}
@@ -202,15 +353,16 @@ func (r *TenderRepo) UpdateTenderStatusById(ctx context.Context, id string, newS
 	return nil
 }
 
-func (r *TenderRepo) GetPublishedTenders(ctx context.Context, serviceTypes []string, pg *entity.PaginationInput) ([]entity.Tender, error) {
+func (r *TenderRepo) GetPublishedTenders(ctx context.Context, filter *entity.TenderFilter, pg *entity.PaginationInput) ([]entity.Tender, error) {
 	builder := r.SqlBuilder.
-		Select("tender.created_at, tender.id, tender.status, tender.organization_id, tender_version.version, tender_version.name, tender_version.description, tender_version.service_type").
+		Select(tenderColumns).
 		From("tender").
 		InnerJoin("tender_version on tender.id = tender_version.tender_id and tender.current_version = tender_version.version").
 		Where("status = ?", "Published")
 
-	if len(serviceTypes) > 0 {
-		builder = builder.Where(squirrel.Eq{"service_type": serviceTypes})
+	builder, err := applyTenderFilter(builder, filter)
+	if err != nil {
+		return nil, err
 	}
 
 	sqlReq, args, _ := builder.
@@ -219,7 +371,7 @@ func (r *TenderRepo) GetPublishedTenders(ctx context.Context, serviceTypes []str
 		Limit(uint64(pg.Limit)).
 		ToSql()
 
-	rows, err := r.Database.Query(sqlReq, args...)
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -227,14 +379,11 @@ func (r *TenderRepo) GetPublishedTenders(ctx context.Context, serviceTypes []str
 
 	tenders := make([]entity.Tender, 0)
 	for rows.Next() {
-		var tender entity.Tender
-		var createdAt time.Time
-		if err := rows.Scan(&createdAt, &tender.Id, &tender.Status, &tender.OrganizationId,
-			&tender.Version, &tender.Name, &tender.Description, &tender.ServiceType); err != nil {
+		tender, err := scanTender(rows)
+		if err != nil {
 			return tenders, err
 		}
-		tender.CreatedAt = createdAt.Format(time.RFC3339)
-		tenders = append(tenders, tender)
+		tenders = append(tenders, *tender)
 	}
 	if err = rows.Err(); err != nil {
 		return tenders, err
@@ -243,18 +392,25 @@ func (r *TenderRepo) GetPublishedTenders(ctx context.Context, serviceTypes []str
 	return tenders, nil
 }
 
-func (r *TenderRepo) GetTendersByOrganizationId(ctx context.Context, organizationId uuid.UUID, pg *entity.PaginationInput) ([]entity.Tender, error) {
-	sqlReq, args, _ := r.SqlBuilder.
-		Select("tender.created_at, tender.id, tender.status, tender.organization_id, tender_version.version, tender_version.name, tender_version.description, tender_version.service_type").
+func (r *TenderRepo) GetTendersByOrganizationIds(ctx context.Context, organizationIds []uuid.UUID, filter *entity.TenderFilter, pg *entity.PaginationInput) ([]entity.Tender, error) {
+	builder := r.SqlBuilder.
+		Select(tenderColumns).
 		From("tender").
 		InnerJoin("tender_version on tender.id = tender_version.tender_id and tender.current_version = tender_version.version").
-		Where("organization_id = ?", organizationId.String()).
+		Where(squirrel.Eq{"organization_id": organizationIds})
+
+	builder, err := applyTenderFilter(builder, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlReq, args, _ := builder.
 		OrderBy("name ASC").
 		Offset(uint64(pg.Offset)).
 		Limit(uint64(pg.Limit)).
 		ToSql()
 
-	rows, err := r.Database.Query(sqlReq, args...)
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -262,14 +418,11 @@ func (r *TenderRepo) GetTendersByOrganizationId(ctx context.Context, organizatio
 
 	tenders := make([]entity.Tender, 0)
 	for rows.Next() {
-		var tender entity.Tender
-		var createdAt time.Time
-		if err := rows.Scan(&createdAt, &tender.Id, &tender.Status, &tender.OrganizationId,
-			&tender.Version, &tender.Name, &tender.Description, &tender.ServiceType); err != nil {
+		tender, err := scanTender(rows)
+		if err != nil {
 			return tenders, err
 		}
-		tender.CreatedAt = createdAt.Format(time.RFC3339)
-		tenders = append(tenders, tender)
+		tenders = append(tenders, *tender)
 	}
 	if err = rows.Err(); err != nil {
 		return tenders, err
@@ -333,3 +486,58 @@ func (r *TenderRepo) RollbackTenderVersion(ctx context.Context, tenderId string,
 
 	return nil
 }
+
+func formatNullTime(t sql.NullTime) string {
+	if !t.Valid {
+		return ""
+	}
+
+	return t.Time.Format(time.RFC3339)
+}
+
+// Тендеры, до закрытия которых осталось меньше заданного времени и о которых ещё не напоминали
+func (r *TenderRepo) GetTendersClosingBefore(ctx context.Context, before time.Time) ([]entity.Tender, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select(tenderColumns).
+		From("tender").
+		InnerJoin("tender_version on tender.id = tender_version.tender_id and tender.current_version = tender_version.version").
+		Where("tender.status = ?", common.Published).
+		Where("tender.deadline_reminder_sent = ?", false).
+		Where("tender.deadline > ?", time.Now()).
+		Where("tender.deadline <= ?", before).
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tenders := make([]entity.Tender, 0)
+	for rows.Next() {
+		tender, err := scanTender(rows)
+		if err != nil {
+			return tenders, err
+		}
+		tenders = append(tenders, *tender)
+	}
+	if err = rows.Err(); err != nil {
+		return tenders, err
+	}
+
+	return tenders, nil
+}
+
+func (r *TenderRepo) MarkDeadlineReminderSent(ctx context.Context, tenderId uuid.UUID) error {
+	sqlReq, args, _ := r.SqlBuilder.
+		Update("tender").
+		Set("deadline_reminder_sent", true).
+		Where("id = ?", tenderId).
+		ToSql()
+
+	if _, err := r.Database.ExecContext(ctx, sqlReq, args...); err != nil {
+		return err
+	}
+
+	return nil
+}
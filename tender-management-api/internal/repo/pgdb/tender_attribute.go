@@ -0,0 +1,100 @@
+package pgdb
+
+import (
+	"context"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo/repo_errors"
+	"tender-management-api/pkg/postgres"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type TenderAttributeRepo struct {
+	*postgres.Postgres
+}
+
+func NewTenderAttributeRepo(pgdb *postgres.Postgres) *TenderAttributeRepo {
+	return &TenderAttributeRepo{pgdb}
+}
+
+func (r *TenderAttributeRepo) GetTenderAttributeDefinitions(ctx context.Context, organizationId uuid.UUID) ([]entity.TenderAttributeDefinition, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select("organization_id", "code", "name", "type", "required", "allowed_values", "created_at").
+		From("tender_attribute_definition").
+		Where("organization_id = ?", organizationId).
+		OrderBy("code ASC").
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	definitions := make([]entity.TenderAttributeDefinition, 0)
+	for rows.Next() {
+		var definition entity.TenderAttributeDefinition
+		var createdAt time.Time
+		if err := rows.Scan(&definition.OrganizationId, &definition.Code, &definition.Name, &definition.Type,
+			&definition.Required, pq.Array(&definition.AllowedValues), &createdAt); err != nil {
+			return definitions, err
+		}
+		definition.CreatedAt = createdAt.Format(time.RFC3339)
+		if definition.AllowedValues == nil {
+			definition.AllowedValues = make([]string, 0)
+		}
+		definitions = append(definitions, definition)
+	}
+	if err = rows.Err(); err != nil {
+		return definitions, err
+	}
+
+	return definitions, nil
+}
+
+// Определение атрибута перезаписывается целиком, уже сохраненные значения в тендерах не трогаются
+func (r *TenderAttributeRepo) SetTenderAttributeDefinition(ctx context.Context, definition *entity.TenderAttributeDefinition) error {
+	allowedValues := definition.AllowedValues
+	if allowedValues == nil {
+		allowedValues = make([]string, 0)
+	}
+
+	sqlReq, args, _ := r.SqlBuilder.
+		Insert("tender_attribute_definition").
+		Columns("organization_id", "code", "name", "type", "required", "allowed_values").
+		Values(definition.OrganizationId, definition.Code, definition.Name, definition.Type, definition.Required, pq.Array(allowedValues)).
+		Suffix("ON CONFLICT (organization_id, code) DO UPDATE SET " +
+			"name = EXCLUDED.name, type = EXCLUDED.type, required = EXCLUDED.required, allowed_values = EXCLUDED.allowed_values").
+		ToSql()
+
+	if _, err := r.Database.ExecContext(ctx, sqlReq, args...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *TenderAttributeRepo) DeleteTenderAttributeDefinition(ctx context.Context, organizationId uuid.UUID, code string) error {
+	sqlReq, args, _ := r.SqlBuilder.
+		Delete("tender_attribute_definition").
+		Where("organization_id = ?", organizationId).
+		Where("code = ?", code).
+		ToSql()
+
+	res, err := r.Database.ExecContext(ctx, sqlReq, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo_errors.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package pgdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo/repo_errors"
+	"tender-management-api/pkg/postgres"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type TenderTemplateRepo struct {
+	*postgres.Postgres
+}
+
+func NewTenderTemplateRepo(pgdb *postgres.Postgres) *TenderTemplateRepo {
+	return &TenderTemplateRepo{pgdb}
+}
+
+const uniqueViolationCode = "23505"
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode
+}
+
+const tenderTemplateColumns = "id, organization_id, name, tender_name, tender_description, service_type, created_at"
+
+func scanTenderTemplate(row rowScanner) (*entity.TenderTemplate, error) {
+	var template entity.TenderTemplate
+	var createdAt time.Time
+	err := row.Scan(&template.Id, &template.OrganizationId, &template.Name, &template.TenderName,
+		&template.TenderDescription, &template.ServiceType, &createdAt)
+	template.CreatedAt = createdAt.Format(time.RFC3339)
+
+	return &template, err
+}
+
+func (r *TenderTemplateRepo) CreateTenderTemplate(ctx context.Context, input *entity.CreateTenderTemplateInput) (uuid.UUID, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Insert("tender_template").
+		Columns("organization_id", "name", "tender_name", "tender_description", "service_type").
+		Values(input.OrganizationId, input.Name, input.TenderName, input.TenderDescription, input.ServiceType).
+		Suffix("RETURNING id").
+		ToSql()
+
+	var id uuid.UUID
+	if err := r.Database.QueryRowContext(ctx, sqlReq, args...).Scan(&id); err != nil {
+		if isUniqueViolation(err) {
+			return uuid.Nil, repo_errors.ErrAlreadyExists
+		}
+
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
+func (r *TenderTemplateRepo) GetTenderTemplateById(ctx context.Context, id string) (*entity.TenderTemplate, error) {
+	uuidForm, err := uuid.Parse(id)
+	if err != nil {
+		return nil, repo_errors.ErrNotFound
+	}
+
+	sqlReq, args, _ := r.SqlBuilder.
+		Select(tenderTemplateColumns).
+		From("tender_template").
+		Where("id = ?", uuidForm).
+		ToSql()
+
+	template, err := scanTenderTemplate(r.Database.QueryRowContext(ctx, sqlReq, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return template, repo_errors.ErrNotFound
+		}
+
+		return template, err
+	}
+
+	return template, nil
+}
+
+func (r *TenderTemplateRepo) GetTenderTemplatesByOrganizationId(ctx context.Context, organizationId uuid.UUID, pg *entity.PaginationInput) ([]entity.TenderTemplate, error) {
+	sqlReq, args, _ := r.SqlBuilder.
+		Select(tenderTemplateColumns).
+		From("tender_template").
+		Where("organization_id = ?", organizationId).
+		OrderBy("name ASC").
+		Offset(uint64(pg.Offset)).
+		Limit(uint64(pg.Limit)).
+		ToSql()
+
+	rows, err := r.Database.QueryContext(ctx, sqlReq, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := make([]entity.TenderTemplate, 0)
+	for rows.Next() {
+		template, err := scanTenderTemplate(rows)
+		if err != nil {
+			return templates, err
+		}
+		templates = append(templates, *template)
+	}
+	if err = rows.Err(); err != nil {
+		return templates, err
+	}
+
+	return templates, nil
+}
+
+func (r *TenderTemplateRepo) DeleteTenderTemplateById(ctx context.Context, id uuid.UUID) error {
+	sqlReq, args, _ := r.SqlBuilder.
+		Delete("tender_template").
+		Where("id = ?", id).
+		ToSql()
+
+	res, err := r.Database.ExecContext(ctx, sqlReq, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repo_errors.ErrNotFound
+	}
+
+	return nil
+}
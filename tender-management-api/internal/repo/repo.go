@@ -5,6 +5,7 @@ import (
 	"tender-management-api/internal/entity"
 	"tender-management-api/internal/repo/pgdb"
 	"tender-management-api/pkg/postgres"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,34 +16,153 @@ type Diagnostics interface {
 
 type Employee interface {
 	GetEmployeeIdByUsername(ctx context.Context, username string) (string, error)
-	GetUserOrganizationIdByEmployeeId(ctx context.Context, employeeId string) (uuid.UUID, error)
+	GetEmployeeMemberships(ctx context.Context, employeeId uuid.UUID) ([]entity.Membership, error)
 	DoesOrganizationExistById(ctx context.Context, id string) (bool, error)
 	DoesEmployeeExistsById(ctx context.Context, id string) (bool, error)
 	IsEmployeeResponsible(ctx context.Context, employeeId string, organizationId uuid.UUID) (bool, error)
+	IsEmployeeAdmin(ctx context.Context, employeeId string) (bool, error)
+	GetOrganizationResponsibleIds(ctx context.Context, organizationId uuid.UUID) ([]uuid.UUID, error)
+	GetEmployeeById(ctx context.Context, id uuid.UUID) (*entity.Employee, error)
 }
 
 type Tender interface {
 	CreateTender(ctx context.Context, input *entity.CreateTenderInput) (uuid.UUID, error)
+	CloneTender(ctx context.Context, fromTenderId uuid.UUID, fromVersion int, input *entity.CreateTenderInput) (uuid.UUID, error)
 	GetTenderById(ctx context.Context, id string) (*entity.Tender, error)
-	EditTenderById(ctx context.Context, id string, name string, description string, serviceType string) error
+	EditTenderById(ctx context.Context, id string, input *entity.EditTenderInput) error
 	UpdateTenderStatusById(ctx context.Context, id string, newStatus string) error
-	GetPublishedTenders(ctx context.Context, serviceTypes []string, pg *entity.PaginationInput) ([]entity.Tender, error)
-	GetTendersByOrganizationId(ctx context.Context, organizationIds uuid.UUID, pg *entity.PaginationInput) ([]entity.Tender, error)
+	GetPublishedTenders(ctx context.Context, filter *entity.TenderFilter, pg *entity.PaginationInput) ([]entity.Tender, error)
+	GetTendersByOrganizationIds(ctx context.Context, organizationIds []uuid.UUID, filter *entity.TenderFilter, pg *entity.PaginationInput) ([]entity.Tender, error)
 	RollbackTenderVersion(ctx context.Context, tenderId string, version int) error
+	GetTendersClosingBefore(ctx context.Context, before time.Time) ([]entity.Tender, error)
+	MarkDeadlineReminderSent(ctx context.Context, tenderId uuid.UUID) error
 }
 
 type Bid interface {
 	CreateBid(ctx context.Context, input *entity.CreateBidInput) (uuid.UUID, error)
 	GetBidById(ctx context.Context, id string) (*entity.Bid, error)
-	EditBidById(ctx context.Context, id string, name string, description string) error
-	UpdateBidStatusById(ctx context.Context, id string, newStatus string) error
-	GetUserBids(ctx context.Context, employeeId string, pg *entity.PaginationInput) ([]entity.Bid, error)
+	EditBidById(ctx context.Context, id string, name string, description string, editorId uuid.UUID) error
+	UpdateBidStatusById(ctx context.Context, id string, newStatus string, changedBy uuid.UUID) error
+	GetUserBids(ctx context.Context, authorId uuid.NullUUID, organizationIds []uuid.UUID, pg *entity.PaginationInput) ([]entity.Bid, error)
 	GetTenderBids(ctx context.Context, tenderId string, pg *entity.PaginationInput) ([]entity.Bid, error)
-	SubmitBidDecision(ctx context.Context, bidId string, decision string, employeeId string, organizationId uuid.UUID) error
-	RollbackBidVersion(ctx context.Context, bidId string, version int) error
-	SubmitBidFeedBack(ctx context.Context, bidId string, senderId uuid.UUID, receiverId uuid.UUID, content string) error
-	GetReviewsByReceiverId(ctx context.Context, receiverId string, pg *entity.PaginationInput) ([]entity.Review, error)
+	SubmitBidDecision(ctx context.Context, bidId string, decision string, employeeId string, delegateId uuid.NullUUID, votersCnt int) error
+	GetBidVotes(ctx context.Context, bidId uuid.UUID) ([]entity.BidVote, error)
+	RollbackBidVersion(ctx context.Context, bidId string, version int, actorId uuid.UUID) error
+	GetBidVersions(ctx context.Context, bidId uuid.UUID) ([]entity.BidVersion, error)
+	ConsentBidWithdrawal(ctx context.Context, bidId uuid.UUID, employeeId uuid.UUID) error
+	WithdrawBid(ctx context.Context, bidId uuid.UUID, reason string, actorId uuid.UUID) error
+	ResubmitBid(ctx context.Context, bidId uuid.UUID, name string, description string, status string, actorId uuid.UUID) error
+	SubmitBidFeedBack(ctx context.Context, input *entity.CreateReviewInput) error
 	AlreadySubmitApprove(ctx context.Context, bidId string, employeeId string) (bool, error)
+	GetTenderBidAuthorIds(ctx context.Context, tenderId uuid.UUID) ([]uuid.UUID, error)
+}
+
+type Review interface {
+	GetReviewById(ctx context.Context, id string) (*entity.Review, error)
+	GetBidReviews(ctx context.Context, bidId uuid.UUID, includeHidden bool) ([]entity.Review, error)
+	GetReviewsByReceiverId(ctx context.Context, receiverId uuid.UUID, filter *entity.ReviewFilter, pg *entity.PaginationInput) ([]entity.Review, error)
+	EditReview(ctx context.Context, id uuid.UUID, input *entity.EditReviewInput, window time.Duration) error
+	GetReviewVersions(ctx context.Context, id uuid.UUID) ([]entity.ReviewVersion, error)
+	ReplyToReview(ctx context.Context, id uuid.UUID, reply string) error
+	HideReview(ctx context.Context, id uuid.UUID, moderatorId uuid.UUID, reason string) error
+	GetEmployeeReputations(ctx context.Context, employeeIds []uuid.UUID) ([]entity.Reputation, error)
+	GetOrganizationReputations(ctx context.Context, organizationIds []uuid.UUID) ([]entity.Reputation, error)
+}
+
+type Contract interface {
+	GetContractById(ctx context.Context, id string) (*entity.Contract, error)
+	GetContractByBidId(ctx context.Context, bidId uuid.UUID) (*entity.Contract, error)
+	GetEmployeeContracts(ctx context.Context, supplierId uuid.NullUUID, customerOrganizationIds []uuid.UUID, supplierOrganizationIds []uuid.UUID, filter *entity.ContractFilter, pg *entity.PaginationInput) ([]entity.Contract, error)
+	EditContract(ctx context.Context, id uuid.UUID, input *entity.EditContractInput) error
+	SignContract(ctx context.Context, id uuid.UUID, byCustomer bool) error
+	UpdateContractStatus(ctx context.Context, id uuid.UUID, from []string, to string, reason string) error
+}
+
+type Signing interface {
+	CreateEmployeeKey(ctx context.Context, employeeId uuid.UUID, publicKey []byte) (*entity.EmployeeKey, error)
+	GetEmployeeKeys(ctx context.Context, employeeId uuid.UUID) ([]entity.EmployeeKey, error)
+	GetEmployeeKeyById(ctx context.Context, id string) (*entity.EmployeeKey, error)
+	RevokeEmployeeKey(ctx context.Context, id uuid.UUID) error
+	GetBidVersionSignature(ctx context.Context, bidId uuid.UUID, version int) (*entity.BidVersionSignature, error)
+	SignBidVersion(ctx context.Context, bidId uuid.UUID, version int, keyId uuid.UUID, signature []byte) error
+}
+
+type Event interface {
+	CreateEvent(ctx context.Context, event *entity.Event) (int64, string, error)
+	GetEventsAfterId(ctx context.Context, id int64, limit int) ([]entity.Event, error)
+}
+
+type Notification interface {
+	CreateNotification(ctx context.Context, employeeId uuid.UUID, eventId int64) error
+	GetNotifications(ctx context.Context, employeeId string, unreadOnly bool, pg *entity.PaginationInput) ([]entity.Notification, error)
+	CountUnreadNotifications(ctx context.Context, employeeId string) (int, error)
+	GetNotificationById(ctx context.Context, id string) (*entity.Notification, error)
+	MarkNotificationsRead(ctx context.Context, employeeId string, ids []uuid.UUID) error
+	GetNotificationPreferences(ctx context.Context, employeeId string) ([]entity.NotificationPreference, error)
+	SetNotificationPreference(ctx context.Context, employeeId string, eventType string, enabled bool) error
+}
+
+type Attachment interface {
+	AddTenderAttachment(ctx context.Context, tenderId uuid.UUID, actorId uuid.UUID, attachment *entity.Attachment) error
+	AddBidAttachment(ctx context.Context, bidId uuid.UUID, actorId uuid.UUID, attachment *entity.Attachment) error
+	GetTenderAttachments(ctx context.Context, tenderId uuid.UUID, version int) ([]entity.Attachment, error)
+	GetBidAttachments(ctx context.Context, bidId uuid.UUID, version int) ([]entity.Attachment, error)
+	GetTenderAttachmentById(ctx context.Context, tenderId uuid.UUID, attachmentId string) (*entity.Attachment, error)
+	GetBidAttachmentById(ctx context.Context, bidId uuid.UUID, attachmentId string) (*entity.Attachment, error)
+}
+
+type TenderTemplate interface {
+	CreateTenderTemplate(ctx context.Context, input *entity.CreateTenderTemplateInput) (uuid.UUID, error)
+	GetTenderTemplateById(ctx context.Context, id string) (*entity.TenderTemplate, error)
+	GetTenderTemplatesByOrganizationId(ctx context.Context, organizationId uuid.UUID, pg *entity.PaginationInput) ([]entity.TenderTemplate, error)
+	DeleteTenderTemplateById(ctx context.Context, id uuid.UUID) error
+}
+
+type ServiceType interface {
+	GetServiceTypes(ctx context.Context) ([]entity.ServiceType, error)
+	GetServiceTypeByCode(ctx context.Context, code string) (*entity.ServiceType, error)
+	CreateServiceType(ctx context.Context, serviceType *entity.ServiceType) error
+	UpdateServiceType(ctx context.Context, code string, name string, parentCode string) error
+	DeleteServiceType(ctx context.Context, code string) error
+	ExpandServiceTypes(ctx context.Context, codes []string) ([]string, error)
+}
+
+type TenderAttribute interface {
+	GetTenderAttributeDefinitions(ctx context.Context, organizationId uuid.UUID) ([]entity.TenderAttributeDefinition, error)
+	SetTenderAttributeDefinition(ctx context.Context, definition *entity.TenderAttributeDefinition) error
+	DeleteTenderAttributeDefinition(ctx context.Context, organizationId uuid.UUID, code string) error
+}
+
+type OrganizationRelation interface {
+	CreateOrganizationRelation(ctx context.Context, input *entity.CreateOrganizationRelationInput) (uuid.UUID, error)
+	GetOrganizationRelationById(ctx context.Context, id uuid.UUID) (*entity.OrganizationRelation, error)
+	GetOrganizationRelations(ctx context.Context, organizationId uuid.UUID) ([]entity.OrganizationRelation, error)
+	DeleteOrganizationRelation(ctx context.Context, id uuid.UUID) error
+	GetBidderConflicts(ctx context.Context, bidderOrganizationIds []uuid.UUID, organizationId uuid.UUID) ([]entity.OrganizationConflict, error)
+	GetTenderBidConflicts(ctx context.Context, tenderId uuid.UUID) ([]entity.OrganizationConflict, error)
+}
+
+type Role interface {
+	CreateRoleAssignment(ctx context.Context, input *entity.CreateRoleAssignmentInput) (uuid.UUID, error)
+	GetRoleAssignmentById(ctx context.Context, id uuid.UUID) (*entity.RoleAssignment, error)
+	GetOrganizationRoleAssignments(ctx context.Context, organizationId uuid.UUID) ([]entity.RoleAssignment, error)
+	GetEmployeeRoles(ctx context.Context, employeeId uuid.UUID, organizationId uuid.UUID) ([]string, error)
+	DeleteRoleAssignment(ctx context.Context, id uuid.UUID) error
+}
+
+type Delegation interface {
+	CreateApprovalDelegation(ctx context.Context, input *entity.CreateApprovalDelegationInput) (uuid.UUID, error)
+	GetApprovalDelegationById(ctx context.Context, id uuid.UUID) (*entity.ApprovalDelegation, error)
+	GetEmployeeApprovalDelegations(ctx context.Context, employeeId uuid.UUID) ([]entity.ApprovalDelegation, error)
+	IsApprovalDelegatedToday(ctx context.Context, organizationId uuid.UUID, delegatorId uuid.UUID, delegateId uuid.UUID) (bool, error)
+	DeleteApprovalDelegation(ctx context.Context, id uuid.UUID) error
+}
+
+type Tenant interface {
+	GetEmployeeTenantId(ctx context.Context, username string) (uuid.UUID, error)
+	GetEmployeeTenantIdById(ctx context.Context, employeeId uuid.UUID) (uuid.UUID, error)
+	GetDefaultTenantId(ctx context.Context) (uuid.UUID, error)
+	GetTenantIds(ctx context.Context) ([]uuid.UUID, error)
 }
 
 type Repositories struct {
@@ -50,13 +170,40 @@ type Repositories struct {
 	Employee
 	Tender
 	Bid
+	Review
+	Contract
+	Signing
+	Event
+	Notification
+	Attachment
+	TenderTemplate
+	ServiceType
+	TenderAttribute
+	OrganizationRelation
+	Role
+	Delegation
+	Tenant
 }
 
 func NewRepositories(p *postgres.Postgres) *Repositories {
 	return &Repositories{
-		Diagnostics: pgdb.NewDiagnosticsRepo(p),
-		Employee:    pgdb.NewEmployeeRepo(p),
-		Tender:      pgdb.NewTenderRepo(p),
-		Bid:         pgdb.NewBidRepo(p),
+		Diagnostics:     pgdb.NewDiagnosticsRepo(p),
+		Employee:        pgdb.NewEmployeeRepo(p),
+		Tender:          pgdb.NewTenderRepo(p),
+		Bid:             pgdb.NewBidRepo(p),
+		Review:          pgdb.NewReviewRepo(p),
+		Contract:        pgdb.NewContractRepo(p),
+		Signing:         pgdb.NewSigningRepo(p),
+		Event:           pgdb.NewEventRepo(p),
+		Notification:    pgdb.NewNotificationRepo(p),
+		Attachment:      pgdb.NewAttachmentRepo(p),
+		TenderTemplate:  pgdb.NewTenderTemplateRepo(p),
+		ServiceType:     pgdb.NewServiceTypeRepo(p),
+		TenderAttribute: pgdb.NewTenderAttributeRepo(p),
+
+		OrganizationRelation: pgdb.NewOrganizationRelationRepo(p),
+		Role:                 pgdb.NewRoleRepo(p),
+		Delegation:           pgdb.NewDelegationRepo(p),
+		Tenant:               pgdb.NewTenantRepo(p),
 	}
 }
@@ -3,5 +3,8 @@ package repo_errors
 import "errors"
 
 var (
-	ErrNotFound = errors.New("not found")
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+	ErrInUse         = errors.New("referenced by other records")
+	ErrExpired       = errors.New("time window for change is over")
 )
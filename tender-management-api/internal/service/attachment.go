@@ -0,0 +1,435 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"tender-management-api/internal/common"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+	"tender-management-api/internal/repo/repo_errors"
+	"tender-management-api/pkg/blobstore"
+
+	"github.com/google/uuid"
+)
+
+const MaxAttachmentSize = 20 << 20
+
+// Тип файла определяется по содержимому, а не по тому, что прислал клиент
+var allowedAttachmentTypes = map[string]struct{}{
+	"application/pdf": {},
+	"application/zip": {},
+	"image/png":       {},
+	"image/jpeg":      {},
+	"text/plain":      {},
+}
+
+// Документы Office -- это zip-архивы, поэтому их уточняем по расширению
+var officeAttachmentTypes = map[string]string{
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+}
+
+type AttachmentService struct {
+	attachmentRepo repo.Attachment
+	tenderRepo     repo.Tender
+	bidRepo        repo.Bid
+	employeeRepo   repo.Employee
+	blobStore      blobstore.BlobStore
+	events         eventPublisher
+	policy         *Policy
+}
+
+func NewAttachmentService(repos *repo.Repositories, blobStore blobstore.BlobStore, events eventPublisher, policy *Policy) *AttachmentService {
+	return &AttachmentService{
+		attachmentRepo: repos.Attachment,
+		tenderRepo:     repos.Tender,
+		bidRepo:        repos.Bid,
+		employeeRepo:   repos.Employee,
+		blobStore:      blobStore,
+		events:         events,
+		policy:         policy,
+	}
+}
+
+func detectAttachmentType(fileName string, content []byte) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(content))
+	if err != nil {
+		return "", ErrAttachmentTypeNotAllowed
+	}
+	if _, ok := allowedAttachmentTypes[mediaType]; !ok {
+		return "", ErrAttachmentTypeNotAllowed
+	}
+
+	if mediaType == "application/zip" {
+		if officeType, ok := officeAttachmentTypes[strings.ToLower(filepath.Ext(fileName))]; ok {
+			return officeType, nil
+		}
+	}
+
+	return mediaType, nil
+}
+
+// Проверяет размер, тип и контрольную сумму файла и собирает по нему модель вложения
+func newAttachment(input *entity.UploadAttachmentInput, authorId uuid.UUID, keyPrefix string) (*entity.Attachment, error) {
+	if len(input.Content) == 0 {
+		return nil, ErrAttachmentIsEmpty
+	}
+	if len(input.Content) > MaxAttachmentSize {
+		return nil, ErrAttachmentTooLarge.WithDetails(map[string]any{"maxSize": MaxAttachmentSize})
+	}
+
+	contentType, err := detectAttachmentType(input.FileName, input.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(input.Content)
+	checksum := hex.EncodeToString(sum[:])
+	if input.Checksum != "" && !strings.EqualFold(input.Checksum, checksum) {
+		return nil, ErrAttachmentChecksumMismatch
+	}
+
+	id := uuid.New()
+
+	return &entity.Attachment{
+		Id:          id,
+		FileName:    filepath.Base(input.FileName),
+		ContentType: contentType,
+		Size:        int64(len(input.Content)),
+		Checksum:    checksum,
+		StorageKey:  keyPrefix + "/" + id.String(),
+		AuthorId:    authorId,
+	}, nil
+}
+
+// Файл сначала кладется в хранилище, и только потом запись о нем попадает в базу.
+// Если записать в базу не удалось, файл удаляется, чтобы в хранилище не оставалось мусора
+func (s *AttachmentService) store(ctx context.Context, attachment *entity.Attachment, content []byte, save func() error) error {
+	if err := s.blobStore.Put(ctx, attachment.StorageKey, content); err != nil {
+		return err
+	}
+
+	if err := save(); err != nil {
+		if e := s.blobStore.Delete(ctx, attachment.StorageKey); e != nil {
+			log.Println("Failed to delete orphaned attachment " + attachment.StorageKey + ": " + e.Error())
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *AttachmentService) load(ctx context.Context, attachment *entity.Attachment) (*entity.AttachmentContent, error) {
+	reader, err := s.blobStore.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+
+		return nil, err
+	}
+	defer reader.Close()
+
+	var buf bytes.Buffer
+	if _, err = io.Copy(&buf, io.LimitReader(reader, MaxAttachmentSize+1)); err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	if hex.EncodeToString(sum[:]) != attachment.Checksum {
+		return nil, ErrAttachmentCorrupted
+	}
+
+	return &entity.AttachmentContent{
+		Attachment: *mapAttachment(attachment),
+		Content:    buf.Bytes(),
+	}, nil
+}
+
+// Загружать файлы к тендеру может любой ответственный за организацию, каждая загрузка создает новую версию тендера
+func (s *AttachmentService) UploadTenderAttachment(ctx context.Context, tenderId string, input *entity.UploadAttachmentInput) (*entity.AttachmentOutputModel, error) {
+	tender, err := s.tenderRepo.GetTenderById(ctx, tenderId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrTenderNotFound
+		}
+
+		return nil, err
+	}
+
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, input.Username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrEmployeeNotFound
+		}
+
+		return nil, err
+	}
+
+	canManage, err := s.policy.Can(ctx, employeeId, tender.OrganizationId, ManageTenders)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrUserHasNoAccessToTender
+	}
+
+	actorId := uuid.MustParse(employeeId)
+	attachment, err := newAttachment(input, actorId, "tenders/"+tender.Id.String())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store(ctx, attachment, input.Content, func() error {
+		return s.attachmentRepo.AddTenderAttachment(ctx, tender.Id, actorId, attachment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err = s.attachmentRepo.GetTenderAttachmentById(ctx, tender.Id, attachment.Id.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAttachment(attachment), nil
+}
+
+// Вложения тендера доступны так же, как и сам тендер: всем, если он опубликован, иначе только ответственным
+func (s *AttachmentService) getReadableTender(ctx context.Context, tenderId string, username string, usernamePassed bool) (*entity.Tender, error) {
+	var employeeId string
+	var err error
+	if usernamePassed {
+		employeeId, err = s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+		if err != nil {
+			if errors.Is(err, repo_errors.ErrNotFound) {
+				return nil, ErrEmployeeNotFound
+			}
+
+			return nil, err
+		}
+	}
+
+	tender, err := s.tenderRepo.GetTenderById(ctx, tenderId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrTenderNotFound
+		}
+
+		return nil, err
+	}
+
+	if tender.Status == common.Published {
+		return tender, nil
+	}
+
+	if !usernamePassed {
+		return nil, ErrUnauthorizedTryToAccessWithEmployeeRights
+	}
+
+	canRead, err := s.policy.Can(ctx, employeeId, tender.OrganizationId, ReadTenders)
+	if err != nil {
+		return nil, err
+	}
+	if !canRead {
+		return nil, ErrUserHasNoAccessToTender
+	}
+
+	return tender, nil
+}
+
+// Если версия не передана, возвращаются вложения текущей версии
+func (s *AttachmentService) GetTenderAttachments(ctx context.Context, tenderId string, username string, usernamePassed bool, version int) ([]entity.AttachmentOutputModel, error) {
+	tender, err := s.getReadableTender(ctx, tenderId, username, usernamePassed)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == 0 {
+		version = tender.Version
+	}
+	if version > tender.Version {
+		return nil, ErrNoSuchVersion
+	}
+
+	attachments, err := s.attachmentRepo.GetTenderAttachments(ctx, tender.Id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAttachments(attachments), nil
+}
+
+func (s *AttachmentService) DownloadTenderAttachment(ctx context.Context, tenderId string, attachmentId string, username string, usernamePassed bool) (*entity.AttachmentContent, error) {
+	tender, err := s.getReadableTender(ctx, tenderId, username, usernamePassed)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.attachmentRepo.GetTenderAttachmentById(ctx, tender.Id, attachmentId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+
+		return nil, err
+	}
+
+	return s.load(ctx, attachment)
+}
+
+// Загружать файлы к биду может только его автор, каждая загрузка создает новую версию бида.
+// Новая версия не подписана, поэтому опубликованный бид к тендеру с обязательной подписью возвращается в Created
+func (s *AttachmentService) UploadBidAttachment(ctx context.Context, bidId string, input *entity.UploadAttachmentInput) (*entity.AttachmentOutputModel, error) {
+	bid, err := s.bidRepo.GetBidById(ctx, bidId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrBidNotFound
+		}
+
+		return nil, err
+	}
+
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, input.Username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrEmployeeNotFound
+		}
+
+		return nil, err
+	}
+
+	canManage, err := s.policy.CanOnBid(ctx, employeeId, bid, ManageBids)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrUserHasNoAccessToBid
+	}
+
+	actorId := uuid.MustParse(employeeId)
+	attachment, err := newAttachment(input, actorId, "bids/"+bid.Id.String())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store(ctx, attachment, input.Content, func() error {
+		return s.attachmentRepo.AddBidAttachment(ctx, bid.Id, actorId, attachment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishBidStatusChange(ctx, bid)
+
+	attachment, err = s.attachmentRepo.GetBidAttachmentById(ctx, bid.Id, attachment.Id.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAttachment(attachment), nil
+}
+
+// Если загрузка вернула бид в Created, подписчики узнают об этом так же, как о любой смене статуса.
+// Файл к этому моменту уже сохранен, поэтому ошибка только логируется
+func (s *AttachmentService) publishBidStatusChange(ctx context.Context, before *entity.Bid) {
+	bid, err := s.bidRepo.GetBidById(ctx, before.Id.String())
+	if err != nil {
+		log.Println("Failed to check bid status after upload: " + err.Error())
+
+		return
+	}
+	if bid.Status == before.Status {
+		return
+	}
+
+	tender, err := s.tenderRepo.GetTenderById(ctx, bid.TenderId.String())
+	if err != nil {
+		log.Println("Failed to check bid status after upload: " + err.Error())
+
+		return
+	}
+	s.events.Publish(ctx, newBidEvent(common.BidStatusChangedEvent, bid, tender))
+}
+
+// Вложения бида доступны тем же, кто видит сам бид
+func (s *AttachmentService) getReadableBid(ctx context.Context, bidId string, username string) (*entity.Bid, error) {
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrEmployeeNotFound
+		}
+
+		return nil, err
+	}
+
+	bid, err := s.bidRepo.GetBidById(ctx, bidId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrBidNotFound
+		}
+
+		return nil, err
+	}
+
+	canRead, err := s.policy.CanReadBid(ctx, employeeId, bid)
+	if err != nil {
+		return nil, err
+	}
+	if !canRead {
+		return nil, ErrUserHasNoAccessToBid
+	}
+
+	return bid, nil
+}
+
+// Если версия не передана, возвращаются вложения текущей версии
+func (s *AttachmentService) GetBidAttachments(ctx context.Context, bidId string, username string, version int) ([]entity.AttachmentOutputModel, error) {
+	bid, err := s.getReadableBid(ctx, bidId, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if version == 0 {
+		version = bid.Version
+	}
+	if version > bid.Version {
+		return nil, ErrNoSuchVersion
+	}
+
+	attachments, err := s.attachmentRepo.GetBidAttachments(ctx, bid.Id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAttachments(attachments), nil
+}
+
+func (s *AttachmentService) DownloadBidAttachment(ctx context.Context, bidId string, attachmentId string, username string) (*entity.AttachmentContent, error) {
+	bid, err := s.getReadableBid(ctx, bidId, username)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.attachmentRepo.GetBidAttachmentById(ctx, bid.Id, attachmentId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+
+		return nil, err
+	}
+
+	return s.load(ctx, attachment)
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
 	"tender-management-api/internal/common"
 	"tender-management-api/internal/entity"
 	"tender-management-api/internal/repo"
@@ -12,16 +13,30 @@ import (
 )
 
 type BidService struct {
-	bidRepo      repo.Bid
-	employeeRepo repo.Employee
-	tenderRepo   repo.Tender
+	bidRepo         repo.Bid
+	employeeRepo    repo.Employee
+	tenderRepo      repo.Tender
+	reviewRepo      repo.Review
+	serviceTypeRepo repo.ServiceType
+	contractRepo    repo.Contract
+	relationRepo    repo.OrganizationRelation
+	delegationRepo  repo.Delegation
+	events          eventPublisher
+	policy          *Policy
 }
 
-func NewBidService(repos *repo.Repositories) *BidService {
+func NewBidService(repos *repo.Repositories, events eventPublisher, policy *Policy) *BidService {
 	return &BidService{
-		bidRepo:      repos.Bid,
-		employeeRepo: repos.Employee,
-		tenderRepo:   repos.Tender,
+		bidRepo:         repos.Bid,
+		employeeRepo:    repos.Employee,
+		tenderRepo:      repos.Tender,
+		reviewRepo:      repos.Review,
+		serviceTypeRepo: repos.ServiceType,
+		contractRepo:    repos.Contract,
+		relationRepo:    repos.OrganizationRelation,
+		delegationRepo:  repos.Delegation,
+		events:          events,
+		policy:          policy,
 	}
 }
 
@@ -47,14 +62,55 @@ func (s *BidService) CreateBid(ctx context.Context, input *entity.CreateBidInput
 		return nil, ErrEmployeeNotFound
 	}
 
-	isResponsible, err := s.employeeRepo.IsEmployeeResponsible(ctx, input.AuthorId, tender.OrganizationId)
+	access, err := s.policy.BidProposal(ctx, input.AuthorId, tender.OrganizationId)
 	if err != nil {
 		return nil, err
 	}
-	if isResponsible {
+	if access.IsTenderMember {
 		return nil, ErrBidCanNotBeProposedBySameOrganization
 	}
 
+	if input.AuthorType == common.OrganizationAuthor {
+		organizationIds := access.Organizations
+
+		// Организацию можно не указывать, только если автор подает предложения от имени единственной
+		if input.OrganizationId == nil {
+			switch len(organizationIds) {
+			case 0:
+				return nil, ErrUserIsNotOrganizationResponsible
+			case 1:
+				input.OrganizationId = &organizationIds[0]
+			default:
+				return nil, ErrBidOrganizationRequired
+			}
+		}
+
+		if !slices.Contains(organizationIds, *input.OrganizationId) {
+			return nil, ErrUserIsNotOrganizationResponsible
+		}
+	} else {
+		input.OrganizationId = nil
+	}
+
+	// Связанные с организацией тендера участники либо блокируются, либо помечаются для проверки.
+	// Предложение организации проверяется по ней, личное - по всем организациям автора
+	bidderOrganizationIds := []uuid.UUID{}
+	if input.OrganizationId != nil {
+		bidderOrganizationIds = append(bidderOrganizationIds, *input.OrganizationId)
+	} else if bidderOrganizationIds, err = s.policy.Organizations(ctx, input.AuthorId); err != nil {
+		return nil, err
+	}
+	conflicts, err := s.relationRepo.GetBidderConflicts(ctx, bidderOrganizationIds, tender.OrganizationId)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 {
+		if tender.ConflictPolicy == common.ConflictBlock {
+			return nil, ErrBidConflictOfInterest
+		}
+		input.ConflictOfInterest = true
+	}
+
 	id, err := s.bidRepo.CreateBid(ctx, input)
 	if err != nil {
 		return nil, err
@@ -89,21 +145,20 @@ func (s *BidService) EditBidById(ctx context.Context, bidId string, username str
 		return nil, err
 	}
 
-	if bid.AuthorId.String() != employeeId {
-		return nil, ErrUserHasNoAccessToBid
-	}
-
-	err = s.bidRepo.EditBidById(ctx, bidId, name, description)
+	canManage, err := s.policy.CanOnBid(ctx, employeeId, bid, ManageBids)
 	if err != nil {
 		return nil, err
 	}
+	if !canManage {
+		return nil, ErrUserHasNoAccessToBid
+	}
 
-	bid, err = s.bidRepo.GetBidById(ctx, bidId)
+	err = s.bidRepo.EditBidById(ctx, bidId, name, description, uuid.MustParse(employeeId))
 	if err != nil {
 		return nil, err
 	}
 
-	return mapBid(bid), nil
+	return s.getChangedBid(ctx, bid)
 }
 
 // Бид вне зависимости от его статуса доступен только автору и ответсвенным за организацию
@@ -126,21 +181,11 @@ func (s *BidService) GetBidStatusById(ctx context.Context, bidId string, usernam
 		return "", err
 	}
 
-	if employeeId == bid.AuthorId.String() {
-		return bid.Status, nil
-	}
-
-	// к нам обратился не автор бида. Значит этот пользователь должен быть из организации при тендере
-	tender, err := s.tenderRepo.GetTenderById(ctx, bid.TenderId.String())
-	if err != nil {
-		return "", err
-	}
-
-	isEmployeeResponsible, err := s.employeeRepo.IsEmployeeResponsible(ctx, employeeId, tender.OrganizationId)
+	canRead, err := s.policy.CanReadBid(ctx, employeeId, bid)
 	if err != nil {
 		return "", err
 	}
-	if !isEmployeeResponsible {
+	if !canRead {
 		return "", ErrUserHasNoAccessToBid
 	}
 
@@ -148,6 +193,11 @@ func (s *BidService) GetBidStatusById(ctx context.Context, bidId string, usernam
 }
 
 func (s *BidService) UpdateBidStatusById(ctx context.Context, bidId string, newStatus string, username string) (*entity.BidOutputModel, error) {
+	// Отмена предложения подчиняется тем же правилам, что и отзыв
+	if newStatus == common.Canceled {
+		return s.WithdrawBid(ctx, bidId, username, "")
+	}
+
 	bid, err := s.bidRepo.GetBidById(ctx, bidId)
 	if err != nil {
 		if errors.Is(err, repo_errors.ErrNotFound) {
@@ -166,20 +216,46 @@ func (s *BidService) UpdateBidStatusById(ctx context.Context, bidId string, newS
 		return nil, err
 	}
 
-	if bid.AuthorId.String() != employeeId {
+	canManage, err := s.policy.CanOnBid(ctx, employeeId, bid, ManageBids)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
 		return nil, ErrUserHasNoAccessToBid
 	}
+	if bid.Status == common.Canceled {
+		return nil, ErrBidIsWithdrawn
+	}
+
+	if newStatus == common.Published && !bid.Signed {
+		tender, err := s.tenderRepo.GetTenderById(ctx, bid.TenderId.String())
+		if err != nil {
+			return nil, err
+		}
+		if tender.RequiresSignature {
+			return nil, ErrBidIsNotSigned
+		}
+	}
 
-	err = s.bidRepo.UpdateBidStatusById(ctx, bidId, newStatus)
+	err = s.bidRepo.UpdateBidStatusById(ctx, bidId, newStatus, uuid.MustParse(employeeId))
 	if err != nil {
 		return nil, err
 	}
 
+	oldStatus := bid.Status
 	bid, err = s.bidRepo.GetBidById(ctx, bidId)
 	if err != nil {
 		return nil, err
 	}
 
+	if oldStatus != bid.Status {
+		tender, err := s.tenderRepo.GetTenderById(ctx, bid.TenderId.String())
+		if err != nil {
+			return nil, err
+		}
+		s.events.Publish(ctx, newBidEvent(common.BidStatusChangedEvent, bid, tender))
+	}
+
 	return mapBid(bid), nil
 }
 
@@ -202,11 +278,11 @@ func (s *BidService) GetBidsForTenderById(ctx context.Context, tenderId string,
 		return nil, err
 	}
 
-	isResponsible, err := s.employeeRepo.IsEmployeeResponsible(ctx, employeeId, tender.OrganizationId)
+	canRead, err := s.policy.Can(ctx, employeeId, tender.OrganizationId, ReadTenders)
 	if err != nil {
 		return nil, err
 	}
-	if !isResponsible {
+	if !canRead {
 		return nil, ErrUserHasNoAccessToTender
 	}
 
@@ -215,10 +291,12 @@ func (s *BidService) GetBidsForTenderById(ctx context.Context, tenderId string,
 		return nil, err
 	}
 
-	return mapBids(bids), nil
+	return s.withReputations(ctx, bids)
 }
 
-func (s *BidService) GetUserBids(ctx context.Context, username string, pg *entity.PaginationInput) ([]entity.BidOutputModel, error) {
+// Без organizationId возвращаются личные предложения сотрудника и предложения всех организаций,
+// где он их читает; с ним - только предложения выбранной организации
+func (s *BidService) GetUserBids(ctx context.Context, username string, organizationId string, pg *entity.PaginationInput) ([]entity.BidOutputModel, error) {
 	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
 	if err != nil {
 		if errors.Is(err, repo_errors.ErrNotFound) {
@@ -228,7 +306,22 @@ func (s *BidService) GetUserBids(ctx context.Context, username string, pg *entit
 		return nil, err
 	}
 
-	bids, err := s.bidRepo.GetUserBids(ctx, employeeId, pg)
+	organizationIds, err := s.policy.OrganizationsWith(ctx, employeeId, ReadBids)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationIds, ok := selectOrganization(organizationIds, organizationId)
+	if !ok {
+		return nil, ErrUserHasNoAccessToBid
+	}
+
+	var authorId uuid.NullUUID
+	if organizationId == "" {
+		authorId = uuid.NullUUID{UUID: uuid.MustParse(employeeId), Valid: true}
+	}
+
+	bids, err := s.bidRepo.GetUserBids(ctx, authorId, organizationIds, pg)
 	if err != nil {
 		return nil, err
 	}
@@ -236,7 +329,9 @@ func (s *BidService) GetUserBids(ctx context.Context, username string, pg *entit
 	return mapBids(bids), nil
 }
 
-func (s *BidService) SubmitBidDecision(ctx context.Context, bidId string, decision string, username string) (*entity.BidOutputModel, error) {
+// onBehalfOf - сотрудник, передавший username право голоса на сегодня. Голос тогда принадлежит ему,
+// и права проверяются по нему, а username сохраняется как подавший голос делегат
+func (s *BidService) SubmitBidDecision(ctx context.Context, bidId string, decision string, username string, onBehalfOf string) (*entity.BidOutputModel, error) {
 	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
 	if err != nil {
 		if errors.Is(err, repo_errors.ErrNotFound) {
@@ -264,19 +359,48 @@ func (s *BidService) SubmitBidDecision(ctx context.Context, bidId string, decisi
 		return nil, err
 	}
 
-	if bid.AuthorId.String() == employeeId {
-		return nil, ErrBidAuthorCanNotMakeDecisionsOnIt
+	voterId, delegateId := employeeId, uuid.NullUUID{}
+	if onBehalfOf != "" {
+		voterId, err = s.employeeRepo.GetEmployeeIdByUsername(ctx, onBehalfOf)
+		if err != nil {
+			if errors.Is(err, repo_errors.ErrNotFound) {
+				return nil, ErrDelegatorNotFound
+			}
+
+			return nil, err
+		}
+
+		delegated, err := s.delegationRepo.IsApprovalDelegatedToday(ctx, tender.OrganizationId, uuid.MustParse(voterId), uuid.MustParse(employeeId))
+		if err != nil {
+			return nil, err
+		}
+		if !delegated {
+			return nil, ErrApprovalIsNotDelegated
+		}
+		delegateId = uuid.NullUUID{UUID: uuid.MustParse(employeeId), Valid: true}
 	}
 
-	isResponsible, err := s.employeeRepo.IsEmployeeResponsible(ctx, employeeId, tender.OrganizationId)
+	access, err := s.policy.BidDecision(ctx, employeeId, voterId, bid, tender.OrganizationId)
 	if err != nil {
 		return nil, err
 	}
-	if !isResponsible {
+	if access.IsParticipant {
+		return nil, ErrBidAuthorCanNotMakeDecisionsOnIt
+	}
+	if bid.Status == common.Canceled {
+		return nil, ErrBidIsWithdrawn
+	}
+
+	// Решение принимается по текущей версии, и на таких тендерах она должна быть подписана
+	if tender.RequiresSignature && !bid.Signed {
+		return nil, ErrBidIsNotSigned
+	}
+
+	if !access.CanDecide {
 		return nil, ErrUserHasNoAccessToTender
 	}
 
-	alreadySendDecision, err := s.bidRepo.AlreadySubmitApprove(ctx, bidId, employeeId)
+	alreadySendDecision, err := s.bidRepo.AlreadySubmitApprove(ctx, bidId, voterId)
 	if err != nil {
 		return nil, err
 	}
@@ -289,16 +413,30 @@ func (s *BidService) SubmitBidDecision(ctx context.Context, bidId string, decisi
 		return result, nil
 	}
 
-	err = s.bidRepo.SubmitBidDecision(ctx, bidId, decision, employeeId, tender.OrganizationId)
+	// кворум считается от тех же сотрудников, что могут голосовать, а не только от ответственных
+	voters, err := s.policy.EmployeesWith(ctx, tender.OrganizationId, DecideBids)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.bidRepo.SubmitBidDecision(ctx, bidId, decision, voterId, delegateId, len(voters))
 	if err != nil {
 		return nil, err
 	}
 
+	oldDecision := bid.Decision
 	bid, err = s.bidRepo.GetBidById(ctx, bidId)
 	if err != nil {
 		return nil, err
 	}
 
+	if oldDecision != bid.Decision {
+		s.events.Publish(ctx, newBidEvent(common.BidDecisionChangedEvent, bid, tender))
+	} else if decision == common.ApprovedDecision {
+		// голос учтён, но кворум ещё не набран
+		s.events.Publish(ctx, newBidEvent(common.BidVoteRequestedEvent, bid, tender))
+	}
+
 	result = mapBid(bid)
 
 	return result, nil
@@ -323,11 +461,15 @@ func (s *BidService) RollbackBidVersion(ctx context.Context, bidId string, versi
 		return nil, err
 	}
 
-	if bid.AuthorId.String() != employeeId {
+	canManage, err := s.policy.CanOnBid(ctx, employeeId, bid, ManageBids)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
 		return nil, ErrUserHasNoAccessToBid
 	}
 
-	err = s.bidRepo.RollbackBidVersion(ctx, bidId, version)
+	err = s.bidRepo.RollbackBidVersion(ctx, bidId, version, uuid.MustParse(employeeId))
 	if err != nil {
 		if errors.Is(err, repo_errors.ErrNotFound) {
 			return nil, ErrNoSuchVersion
@@ -336,15 +478,12 @@ func (s *BidService) RollbackBidVersion(ctx context.Context, bidId string, versi
 		return nil, err
 	}
 
-	bid, err = s.bidRepo.GetBidById(ctx, bidId)
-	if err != nil {
-		return nil, err
-	}
-
-	return mapBid(bid), nil
+	return s.getChangedBid(ctx, bid)
 }
 
-func (s *BidService) GetReviewsOnBidAuthorBids(ctx context.Context, tenderId string, authorUsername string, requesterUsername string, pg *entity.PaginationInput) ([]entity.ReviewOutputModel, error) {
+// Ответственный за тендер видит прошлые отзывы на автора предложения к этому тендеру,
+// при необходимости только по тендерам того же типа услуг
+func (s *BidService) GetReviewsOnBidAuthorBids(ctx context.Context, tenderId string, authorUsername string, requesterUsername string, filter *entity.ReviewFilter, pg *entity.PaginationInput) ([]entity.ReviewOutputModel, error) {
 	bidAuthorId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, authorUsername)
 	if err != nil {
 		if errors.Is(err, repo_errors.ErrNotFound) {
@@ -372,15 +511,35 @@ func (s *BidService) GetReviewsOnBidAuthorBids(ctx context.Context, tenderId str
 		return nil, err
 	}
 
-	isResponsible, err := s.employeeRepo.IsEmployeeResponsible(ctx, requesterEmployeeId, tender.OrganizationId)
+	canRead, err := s.policy.Can(ctx, requesterEmployeeId, tender.OrganizationId, ReadTenders)
 	if err != nil {
 		return nil, err
 	}
-	if !isResponsible {
+	if !canRead {
 		return nil, ErrUserHasNoAccessToTender
 	}
 
-	reviews, err := s.bidRepo.GetReviewsByReceiverId(ctx, bidAuthorId, pg)
+	bidAuthorUuid, err := uuid.Parse(bidAuthorId)
+	if err != nil {
+		return nil, err
+	}
+
+	authorIds, err := s.bidRepo.GetTenderBidAuthorIds(ctx, tender.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(authorIds, bidAuthorUuid) {
+		return nil, ErrBidAuthorHasNoBidsOnTender
+	}
+
+	if filter.Comparable {
+		filter.ServiceTypes, err = expandServiceTypes(ctx, s.serviceTypeRepo, []string{tender.ServiceType})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reviews, err := s.reviewRepo.GetReviewsByReceiverId(ctx, bidAuthorUuid, filter, pg)
 	if err != nil {
 		return nil, err
 	}
@@ -388,7 +547,126 @@ func (s *BidService) GetReviewsOnBidAuthorBids(ctx context.Context, tenderId str
 	return mapReviews(reviews), nil
 }
 
-func (s *BidService) SubmitBidFeedback(ctx context.Context, bidId string, username string, content string) (*entity.BidOutputModel, error) {
+func (s *BidService) SubmitBidFeedback(ctx context.Context, username string, input *entity.CreateReviewInput) (*entity.BidOutputModel, error) {
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrEmployeeNotFound
+		}
+
+		return nil, err
+	}
+
+	bid, err := s.bidRepo.GetBidById(ctx, input.BidId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrBidNotFound
+		}
+
+		return nil, err
+	}
+
+	tender, err := s.tenderRepo.GetTenderById(ctx, bid.TenderId.String())
+	if err != nil {
+		return nil, err
+	}
+
+	canReview, err := s.policy.Can(ctx, employeeId, tender.OrganizationId, ReviewBids)
+	if err != nil {
+		return nil, err
+	}
+	if !canReview {
+		return nil, ErrUserHasNoAccessToBid
+	}
+
+	// Отзыв оставляется по итогам исполнения контракта, а не на любое предложение
+	contract, err := s.contractRepo.GetContractByBidId(ctx, bid.Id)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrContractNotCompleted
+		}
+
+		return nil, err
+	}
+	if contract.Status != common.ContractCompleted {
+		return nil, ErrContractNotCompleted
+	}
+
+	input.ContractId = contract.Id
+	input.ReceiverId = bid.AuthorId
+	input.AuthorId, err = uuid.Parse(employeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Отзыв на предложение от имени организации идет и в репутацию организации
+	if bid.AuthorType == common.OrganizationAuthor {
+		input.ReceiverOrganization = bid.OrganizationId
+	}
+
+	if err = s.bidRepo.SubmitBidFeedBack(ctx, input); err != nil {
+		if errors.Is(err, repo_errors.ErrAlreadyExists) {
+			return nil, ErrReviewAlreadyExists
+		}
+
+		return nil, err
+	}
+	s.events.Publish(ctx, newBidEvent(common.BidFeedbackSubmittedEvent, bid, tender))
+
+	return mapBid(bid), nil
+}
+
+// Автор отзывает предложение, пока по нему нет решения. Если за него уже голосовали,
+// нужно согласие ответственного за тендер, а голоса удаляются вместе с отзывом
+func (s *BidService) WithdrawBid(ctx context.Context, bidId string, username string, reason string) (*entity.BidOutputModel, error) {
+	bid, err := s.bidRepo.GetBidById(ctx, bidId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrBidNotFound
+		}
+
+		return nil, err
+	}
+
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrEmployeeNotFound
+		}
+
+		return nil, err
+	}
+
+	canManage, err := s.policy.CanOnBid(ctx, employeeId, bid, ManageBids)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, ErrUserHasNoAccessToBid
+	}
+	if bid.Status == common.Canceled {
+		return nil, ErrBidIsWithdrawn
+	}
+	if bid.Decision == common.ApprovedDecision || bid.Decision == common.RejectedDecision {
+		return nil, ErrBidDecisionAlreadyMade
+	}
+
+	// правило о голосах проверяется в запросе отзыва: голос может прийти уже после чтения предложения
+	if err = s.bidRepo.WithdrawBid(ctx, bid.Id, reason, uuid.MustParse(employeeId)); err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrBidDecisionAlreadyMade
+		}
+		if errors.Is(err, repo_errors.ErrInUse) {
+			return nil, ErrBidHasVotes
+		}
+
+		return nil, err
+	}
+
+	return s.publishBidStatusChange(ctx, bidId)
+}
+
+func (s *BidService) ConsentBidWithdrawal(ctx context.Context, bidId string, username string) (*entity.BidOutputModel, error) {
 	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
 	if err != nil {
 		if errors.Is(err, repo_errors.ErrNotFound) {
@@ -409,26 +687,188 @@ func (s *BidService) SubmitBidFeedback(ctx context.Context, bidId string, userna
 
 	tender, err := s.tenderRepo.GetTenderById(ctx, bid.TenderId.String())
 	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrTenderNotFound
+		}
+
 		return nil, err
 	}
 
-	isResponsible, err := s.employeeRepo.IsEmployeeResponsible(ctx, employeeId, tender.OrganizationId)
+	canDecide, err := s.policy.Can(ctx, employeeId, tender.OrganizationId, DecideBids)
 	if err != nil {
 		return nil, err
 	}
-	if !isResponsible {
+	if !canDecide {
+		return nil, ErrUserHasNoAccessToTender
+	}
+
+	employeeUuid, err := uuid.Parse(employeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.bidRepo.ConsentBidWithdrawal(ctx, bid.Id, employeeUuid); err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrBidIsWithdrawn
+		}
+
+		return nil, err
+	}
+
+	bid, err = s.bidRepo.GetBidById(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapBid(bid), nil
+}
+
+// Отозванное предложение подается заново новой версией до дедлайна тендера.
+// На тендерах с обязательной подписью новую версию нужно подписать, поэтому она возвращается в Created
+func (s *BidService) ResubmitBid(ctx context.Context, bidId string, username string, name string, description string) (*entity.BidOutputModel, error) {
+	bid, err := s.bidRepo.GetBidById(ctx, bidId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrBidNotFound
+		}
+
+		return nil, err
+	}
+
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrEmployeeNotFound
+		}
+
+		return nil, err
+	}
+
+	canManage, err := s.policy.CanOnBid(ctx, employeeId, bid, ManageBids)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
 		return nil, ErrUserHasNoAccessToBid
 	}
+	if bid.Status != common.Canceled {
+		return nil, ErrBidIsNotWithdrawn
+	}
+
+	tender, err := s.tenderRepo.GetTenderById(ctx, bid.TenderId.String())
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrTenderNotFound
+		}
+
+		return nil, err
+	}
+	if tender.Status != common.Published {
+		return nil, ErrTenderIsNotPublished
+	}
+
+	status := common.Published
+	if tender.RequiresSignature {
+		status = common.Created
+	}
+
+	if err = s.bidRepo.ResubmitBid(ctx, bid.Id, name, description, status, uuid.MustParse(employeeId)); err != nil {
+		if errors.Is(err, repo_errors.ErrExpired) {
+			return nil, ErrTenderDeadlinePassed
+		}
+
+		return nil, err
+	}
+
+	return s.publishBidStatusChange(ctx, bidId)
+}
+
+// Новая версия предложения к тендеру с обязательной подписью не подписана, и опубликованное предложение
+// возвращается в Created. Об этом сообщается так же, как о любой смене статуса
+func (s *BidService) getChangedBid(ctx context.Context, before *entity.Bid) (*entity.BidOutputModel, error) {
+	bid, err := s.bidRepo.GetBidById(ctx, before.Id.String())
+	if err != nil {
+		return nil, err
+	}
+	if bid.Status == before.Status {
+		return mapBid(bid), nil
+	}
 
-	receiverId := bid.AuthorId
-	senderId, err := uuid.Parse(employeeId)
+	return s.publishBidStatusChange(ctx, bid.Id.String())
+}
+
+func (s *BidService) publishBidStatusChange(ctx context.Context, bidId string) (*entity.BidOutputModel, error) {
+	bid, err := s.bidRepo.GetBidById(ctx, bidId)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = s.bidRepo.SubmitBidFeedBack(ctx, bidId, senderId, receiverId, content); err != nil {
+	tender, err := s.tenderRepo.GetTenderById(ctx, bid.TenderId.String())
+	if err != nil {
 		return nil, err
 	}
+	s.events.Publish(ctx, newBidEvent(common.BidStatusChangedEvent, bid, tender))
 
 	return mapBid(bid), nil
 }
+
+// История версий с тем, кто и каким действием создал каждую из них.
+// Доступ такой же, как к статусу предложения
+func (s *BidService) GetBidVersions(ctx context.Context, bidId string, username string) ([]entity.BidVersionOutputModel, error) {
+	if _, err := s.GetBidStatusById(ctx, bidId, username); err != nil {
+		return nil, err
+	}
+
+	bid, err := s.bidRepo.GetBidById(ctx, bidId)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.bidRepo.GetBidVersions(ctx, bid.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapBidVersions(versions), nil
+}
+
+// Голоса видят те, кто читает тендеры организации: решение принимается на ее стороне
+func (s *BidService) GetBidVotes(ctx context.Context, bidId string, username string) ([]entity.BidVoteOutputModel, error) {
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrEmployeeNotFound
+		}
+
+		return nil, err
+	}
+
+	bid, err := s.bidRepo.GetBidById(ctx, bidId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrBidNotFound
+		}
+
+		return nil, err
+	}
+
+	tender, err := s.tenderRepo.GetTenderById(ctx, bid.TenderId.String())
+	if err != nil {
+		return nil, err
+	}
+
+	canRead, err := s.policy.Can(ctx, employeeId, tender.OrganizationId, ReadTenders)
+	if err != nil {
+		return nil, err
+	}
+	if !canRead {
+		return nil, ErrUserHasNoAccessToTender
+	}
+
+	votes, err := s.bidRepo.GetBidVotes(ctx, bid.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapBidVotes(votes), nil
+}
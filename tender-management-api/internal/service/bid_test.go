@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"tender-management-api/internal/common"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// Членство берется из назначенных ролей: ответственных в заглушке нет
+type bidEmployeeRepo struct {
+	repo.Employee
+	roles *policyRoleRepo
+}
+
+func (r *bidEmployeeRepo) DoesEmployeeExistsById(_ context.Context, _ string) (bool, error) {
+	return true, nil
+}
+
+func (r *bidEmployeeRepo) IsEmployeeResponsible(_ context.Context, _ string, _ uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (r *bidEmployeeRepo) IsEmployeeAdmin(_ context.Context, _ string) (bool, error) {
+	return false, nil
+}
+
+func (r *bidEmployeeRepo) GetEmployeeMemberships(_ context.Context, employeeId uuid.UUID) ([]entity.Membership, error) {
+	memberships := make([]entity.Membership, 0)
+	for organizationId := range r.roles.roles[employeeId] {
+		memberships = append(memberships, entity.Membership{OrganizationId: organizationId})
+	}
+
+	return memberships, nil
+}
+
+// Любой набор организаций конфликтует: тест проверяет, какие организации участника переданы на проверку
+type bidRelationRepo struct {
+	repo.OrganizationRelation
+	checked []uuid.UUID
+}
+
+func (r *bidRelationRepo) GetBidderConflicts(_ context.Context, bidderOrganizationIds []uuid.UUID, _ uuid.UUID) ([]entity.OrganizationConflict, error) {
+	r.checked = bidderOrganizationIds
+	if len(bidderOrganizationIds) == 0 {
+		return nil, nil
+	}
+
+	return []entity.OrganizationConflict{{OrganizationId: bidderOrganizationIds[0], Reason: common.ParentConflict}}, nil
+}
+
+func TestCreateBidChecksConflictsOfBidderOrganizations(t *testing.T) {
+	owner, subsidiary, other := uuid.New(), uuid.New(), uuid.New()
+	// исполнитель по роли в дочерней организации, а не ответственный за нее
+	bidder, author := uuid.New(), uuid.New()
+	roles := &policyRoleRepo{roles: map[uuid.UUID]map[uuid.UUID][]string{
+		bidder: {subsidiary: {common.BidderRole}},
+		author: {subsidiary: {common.BidderRole}, other: {common.BidderRole}},
+	}}
+	employees := &bidEmployeeRepo{roles: roles}
+	tender := &entity.Tender{Id: uuid.New(), OrganizationId: owner, Status: common.Published, ConflictPolicy: common.ConflictBlock}
+	relations := &bidRelationRepo{}
+
+	s := NewBidService(&repo.Repositories{
+		Employee:             employees,
+		Tender:               &emailTenderRepo{tender: tender},
+		OrganizationRelation: relations,
+	}, nil, &Policy{roleRepo: roles, employeeRepo: employees})
+
+	cases := []struct {
+		name  string
+		input *entity.CreateBidInput
+		want  []uuid.UUID
+	}{
+		{
+			name:  "personal bid of a bidder by role",
+			input: &entity.CreateBidInput{AuthorId: bidder.String(), AuthorType: common.UserAuthor},
+			want:  []uuid.UUID{subsidiary},
+		},
+		{
+			name:  "organization bid",
+			input: &entity.CreateBidInput{AuthorId: author.String(), AuthorType: common.OrganizationAuthor, OrganizationId: &other},
+			want:  []uuid.UUID{other},
+		},
+	}
+	for _, c := range cases {
+		c.input.Name, c.input.TenderId = "Delivery", tender.Id.String()
+		_, err := s.CreateBid(context.Background(), c.input)
+		if !errors.Is(err, ErrBidConflictOfInterest) {
+			t.Errorf("%s: error = %v, want ErrBidConflictOfInterest", c.name, err)
+		}
+		if !slices.Equal(relations.checked, c.want) {
+			t.Errorf("%s: checked organizations %v, want %v", c.name, relations.checked, c.want)
+		}
+	}
+}
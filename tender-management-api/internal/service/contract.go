@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"tender-management-api/internal/common"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+	"tender-management-api/internal/repo/repo_errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type contractTransition struct {
+	from           []string
+	onlyByCustomer bool
+}
+
+// Подписание идет отдельным шагом, остальные переходы жизненного цикла контракта
+var contractTransitions = map[string]contractTransition{
+	common.ContractInProgress: {from: []string{common.ContractSigned}},
+	common.ContractCompleted:  {from: []string{common.ContractInProgress}, onlyByCustomer: true},
+	common.ContractTerminated: {from: []string{common.ContractDraft, common.ContractSigned, common.ContractInProgress}},
+}
+
+type ContractService struct {
+	contractRepo repo.Contract
+	employeeRepo repo.Employee
+	policy       *Policy
+}
+
+func NewContractService(repos *repo.Repositories, policy *Policy) *ContractService {
+	return &ContractService{
+		contractRepo: repos.Contract,
+		employeeRepo: repos.Employee,
+		policy:       policy,
+	}
+}
+
+type contractParty struct {
+	contract   *entity.Contract
+	isCustomer bool
+	isSupplier bool
+}
+
+// Стороны контракта определяет Policy.ContractAccess. Остальным с правом чтения контракт доступен только на просмотр
+func (s *ContractService) getContractParty(ctx context.Context, contractId string, username string) (*contractParty, error) {
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrEmployeeNotFound
+		}
+
+		return nil, err
+	}
+
+	contract, err := s.contractRepo.GetContractById(ctx, contractId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrContractNotFound
+		}
+
+		return nil, err
+	}
+
+	access, err := s.policy.ContractAccess(ctx, employeeId, contract)
+	if err != nil {
+		return nil, err
+	}
+	if !access.CanRead {
+		return nil, ErrUserHasNoAccessToContract
+	}
+
+	return &contractParty{contract: contract, isCustomer: access.IsCustomer, isSupplier: access.IsSupplier}, nil
+}
+
+func (s *ContractService) reloadContract(ctx context.Context, id uuid.UUID) (*entity.ContractOutputModel, error) {
+	contract, err := s.contractRepo.GetContractById(ctx, id.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return mapContract(contract), nil
+}
+
+func (s *ContractService) GetContract(ctx context.Context, contractId string, username string) (*entity.ContractOutputModel, error) {
+	party, err := s.getContractParty(ctx, contractId, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapContract(party.contract), nil
+}
+
+func (s *ContractService) GetUserContracts(ctx context.Context, username string, filter *entity.ContractFilter, pg *entity.PaginationInput) ([]entity.ContractOutputModel, error) {
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrEmployeeNotFound
+		}
+
+		return nil, err
+	}
+
+	customerOrganizationIds, supplierOrganizationIds, err := s.policy.ContractOrganizations(ctx, employeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	// С выбранной организацией личные контракты сотрудника не показываются
+	var supplierId uuid.NullUUID
+	if filter.OrganizationId == "" {
+		supplierId = uuid.NullUUID{UUID: uuid.MustParse(employeeId), Valid: true}
+	}
+	customerOrganizationIds, isCustomer := selectOrganization(customerOrganizationIds, filter.OrganizationId)
+	supplierOrganizationIds, isSupplier := selectOrganization(supplierOrganizationIds, filter.OrganizationId)
+	if !isCustomer && !isSupplier {
+		return nil, ErrUserHasNoAccessToContract
+	}
+
+	contracts, err := s.contractRepo.GetEmployeeContracts(ctx, supplierId, customerOrganizationIds, supplierOrganizationIds, filter, pg)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapContracts(contracts), nil
+}
+
+// Условия черновика заполняет заказчик, после этого обе стороны подписывают заново
+func (s *ContractService) EditContract(ctx context.Context, contractId string, username string, input *entity.EditContractInput) (*entity.ContractOutputModel, error) {
+	party, err := s.getContractParty(ctx, contractId, username)
+	if err != nil {
+		return nil, err
+	}
+	if !party.isCustomer {
+		return nil, ErrUserHasNoAccessToContract
+	}
+	if party.contract.Status != common.ContractDraft {
+		return nil, ErrContractIsNotDraft
+	}
+
+	startDate, _ := time.Parse(time.DateOnly, input.StartDate)
+	endDate, _ := time.Parse(time.DateOnly, input.EndDate)
+	if endDate.Before(startDate) {
+		return nil, ErrInvalidContractDates
+	}
+
+	if err = s.contractRepo.EditContract(ctx, party.contract.Id, input); err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrContractIsNotDraft
+		}
+
+		return nil, err
+	}
+
+	return s.reloadContract(ctx, party.contract.Id)
+}
+
+func (s *ContractService) SignContract(ctx context.Context, contractId string, username string) (*entity.ContractOutputModel, error) {
+	party, err := s.getContractParty(ctx, contractId, username)
+	if err != nil {
+		return nil, err
+	}
+	if !party.isCustomer && !party.isSupplier {
+		return nil, ErrUserHasNoAccessToContract
+	}
+	if party.contract.Status != common.ContractDraft {
+		return nil, ErrContractIsNotDraft
+	}
+	if party.contract.Price == nil || party.contract.StartDate == "" || party.contract.EndDate == "" {
+		return nil, ErrContractIsIncomplete
+	}
+
+	if err = s.contractRepo.SignContract(ctx, party.contract.Id, party.isCustomer); err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrContractIsNotDraft
+		}
+
+		return nil, err
+	}
+
+	return s.reloadContract(ctx, party.contract.Id)
+}
+
+func (s *ContractService) UpdateContractStatus(ctx context.Context, contractId string, username string, status string, reason string) (*entity.ContractOutputModel, error) {
+	party, err := s.getContractParty(ctx, contractId, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if !party.isCustomer && !party.isSupplier {
+		return nil, ErrUserHasNoAccessToContract
+	}
+
+	transition, ok := contractTransitions[status]
+	if !ok || !slices.Contains(transition.from, party.contract.Status) {
+		return nil, ErrContractStatusTransitionNotAllowed
+	}
+	if transition.onlyByCustomer && !party.isCustomer {
+		return nil, ErrUserHasNoAccessToContract
+	}
+	if status == common.ContractTerminated && reason == "" {
+		return nil, ErrContractTerminationReasonRequired
+	}
+
+	if err = s.contractRepo.UpdateContractStatus(ctx, party.contract.Id, transition.from, status, reason); err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrContractStatusTransitionNotAllowed
+		}
+
+		return nil, err
+	}
+
+	return s.reloadContract(ctx, party.contract.Id)
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"log"
+	"tender-management-api/internal/common"
+	"tender-management-api/internal/repo"
+	"tender-management-api/pkg/tenant"
+	"time"
+)
+
+const tenderClosingReminderWindow = 24 * time.Hour
+
+type DeadlineReminderService struct {
+	tenderRepo repo.Tender
+	tenantRepo repo.Tenant
+	events     eventPublisher
+}
+
+func NewDeadlineReminderService(repos *repo.Repositories, events eventPublisher) *DeadlineReminderService {
+	return &DeadlineReminderService{
+		tenderRepo: repos.Tender,
+		tenantRepo: repos.Tenant,
+		events:     events,
+	}
+}
+
+// Run периодически ищет опубликованные тендеры, которые закрываются в ближайшие сутки, до отмены контекста
+func (s *DeadlineReminderService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.remindAllTenants(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Тендеры каждого арендатора видны только в его контексте
+func (s *DeadlineReminderService) remindAllTenants(ctx context.Context) {
+	tenantIds, err := s.tenantRepo.GetTenantIds(ctx)
+	if err != nil {
+		log.Println("Failed to get tenants: " + err.Error())
+
+		return
+	}
+
+	for _, tenantId := range tenantIds {
+		s.remind(tenant.WithId(ctx, tenantId))
+	}
+}
+
+func (s *DeadlineReminderService) remind(ctx context.Context) {
+	tenders, err := s.tenderRepo.GetTendersClosingBefore(ctx, time.Now().Add(tenderClosingReminderWindow))
+	if err != nil {
+		log.Println("Failed to get closing tenders: " + err.Error())
+
+		return
+	}
+
+	for i := range tenders {
+		// сначала помечаем тендер, чтобы при ошибке не разослать напоминание повторно
+		if err = s.tenderRepo.MarkDeadlineReminderSent(ctx, tenders[i].Id); err != nil {
+			log.Println("Failed to mark tender deadline reminder: " + err.Error())
+
+			continue
+		}
+		s.events.Publish(ctx, newTenderEvent(common.TenderClosingSoonEvent, &tenders[i]))
+	}
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+	"tender-management-api/internal/repo/repo_errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func mapApprovalDelegation(d *entity.ApprovalDelegation) *entity.ApprovalDelegationOutputModel {
+	return &entity.ApprovalDelegationOutputModel{
+		Id:                d.Id.String(),
+		OrganizationId:    d.OrganizationId.String(),
+		DelegatorId:       d.DelegatorId.String(),
+		DelegatorUsername: d.DelegatorUsername,
+		DelegateId:        d.DelegateId.String(),
+		DelegateUsername:  d.DelegateUsername,
+		StartsOn:          d.StartsOn,
+		EndsOn:            d.EndsOn,
+		CreatedAt:         d.CreatedAt,
+	}
+}
+
+func mapApprovalDelegations(delegations []entity.ApprovalDelegation) []entity.ApprovalDelegationOutputModel {
+	s := make([]entity.ApprovalDelegationOutputModel, 0)
+	for _, d := range delegations {
+		s = append(s, *mapApprovalDelegation(&d))
+	}
+
+	return s
+}
+
+type DelegationService struct {
+	delegationRepo repo.Delegation
+	employeeRepo   repo.Employee
+	policy         *Policy
+}
+
+func NewDelegationService(repos *repo.Repositories, policy *Policy) *DelegationService {
+	return &DelegationService{
+		delegationRepo: repos.Delegation,
+		employeeRepo:   repos.Employee,
+		policy:         policy,
+	}
+}
+
+func (s *DelegationService) getEmployeeId(ctx context.Context, username string) (string, error) {
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return "", ErrEmployeeNotFound
+		}
+
+		return "", err
+	}
+
+	return employeeId, nil
+}
+
+// Передать можно только свое право голоса и только сотруднику той же организации.
+// Даты включительные, начало может быть в прошлом, но передача не должна уже закончиться
+func (s *DelegationService) CreateApprovalDelegation(ctx context.Context, username string, organizationId string, delegateUsername string, startsOn string, endsOn string) (*entity.ApprovalDelegationOutputModel, error) {
+	employeeId, err := s.getEmployeeId(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationExists, err := s.employeeRepo.DoesOrganizationExistById(ctx, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	if !organizationExists {
+		return nil, ErrOrganizationNotFound
+	}
+
+	delegateId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, delegateUsername)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrDelegateNotFound
+		}
+
+		return nil, err
+	}
+
+	organizationUuid, _ := uuid.Parse(organizationId)
+	access, err := s.policy.ApprovalDelegation(ctx, employeeId, delegateId, organizationUuid)
+	if err != nil {
+		return nil, err
+	}
+	if !access.CanDelegate {
+		return nil, ErrUserHasNoAccessToTender
+	}
+	if delegateId == employeeId {
+		return nil, ErrDelegationToItself
+	}
+	if !access.DelegateIsMember {
+		return nil, ErrDelegateIsNotMember
+	}
+
+	// даты в формате 2006-01-02 сравниваются как строки
+	if endsOn < startsOn || endsOn < time.Now().Format(time.DateOnly) {
+		return nil, ErrInvalidDelegationDates
+	}
+
+	id, err := s.delegationRepo.CreateApprovalDelegation(ctx, &entity.CreateApprovalDelegationInput{
+		OrganizationId: organizationUuid,
+		DelegatorId:    uuid.MustParse(employeeId),
+		DelegateId:     uuid.MustParse(delegateId),
+		StartsOn:       startsOn,
+		EndsOn:         endsOn,
+	})
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrAlreadyExists) {
+			return nil, ErrDelegationOverlaps
+		}
+
+		return nil, err
+	}
+
+	delegation, err := s.delegationRepo.GetApprovalDelegationById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapApprovalDelegation(delegation), nil
+}
+
+func (s *DelegationService) GetApprovalDelegations(ctx context.Context, username string) ([]entity.ApprovalDelegationOutputModel, error) {
+	employeeId, err := s.getEmployeeId(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	delegations, err := s.delegationRepo.GetEmployeeApprovalDelegations(ctx, uuid.MustParse(employeeId))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapApprovalDelegations(delegations), nil
+}
+
+// Уже поданные делегатом голоса остаются в силе
+func (s *DelegationService) DeleteApprovalDelegation(ctx context.Context, delegationId string, username string) error {
+	employeeId, err := s.getEmployeeId(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	delegationUuid, err := uuid.Parse(delegationId)
+	if err != nil {
+		return ErrApprovalDelegationNotFound
+	}
+
+	delegation, err := s.delegationRepo.GetApprovalDelegationById(ctx, delegationUuid)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return ErrApprovalDelegationNotFound
+		}
+
+		return err
+	}
+	if delegation.DelegatorId.String() != employeeId {
+		return ErrUserIsNotDelegator
+	}
+
+	if err = s.delegationRepo.DeleteApprovalDelegation(ctx, delegationUuid); err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return ErrApprovalDelegationNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	htmltemplate "html/template"
+	"io/fs"
+	"log"
+	"path"
+	"strings"
+	"tender-management-api/internal/common"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+	"tender-management-api/pkg/mailer"
+	texttemplate "text/template"
+
+	"github.com/google/uuid"
+)
+
+const defaultEmailLocale = "ru"
+
+//go:embed templates/email
+var emailTemplatesFS embed.FS
+
+type mailQueue interface {
+	Enqueue(message *mailer.Message) error
+}
+
+type emailData struct {
+	Username   string
+	TenderName string
+	BidName    string
+	Decision   string
+	Deadline   string
+}
+
+// Для каждого письма есть пара шаблонов <locale>/<name>.txt.tmpl и <locale>/<name>.html.tmpl.
+// Текстовый шаблон определяет блоки "subject" и "body", html-шаблон целиком является телом письма
+type emailTemplates struct {
+	text map[string]*texttemplate.Template
+	html map[string]*htmltemplate.Template
+}
+
+func parseEmailTemplates() *emailTemplates {
+	t := &emailTemplates{
+		text: make(map[string]*texttemplate.Template),
+		html: make(map[string]*htmltemplate.Template),
+	}
+
+	matches, err := fs.Glob(emailTemplatesFS, "templates/email/*/*.txt.tmpl")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, textPath := range matches {
+		locale := path.Base(path.Dir(textPath))
+		name := strings.TrimSuffix(path.Base(textPath), ".txt.tmpl")
+		htmlPath := strings.TrimSuffix(textPath, ".txt.tmpl") + ".html.tmpl"
+
+		t.text[locale+"/"+name] = texttemplate.Must(texttemplate.ParseFS(emailTemplatesFS, textPath))
+		t.html[locale+"/"+name] = htmltemplate.Must(htmltemplate.ParseFS(emailTemplatesFS, htmlPath))
+	}
+
+	return t
+}
+
+func (t *emailTemplates) render(locale string, name string, data *emailData) (*mailer.Message, error) {
+	key := locale + "/" + name
+	if _, ok := t.text[key]; !ok {
+		key = defaultEmailLocale + "/" + name
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := t.text[key].ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := t.text[key].ExecuteTemplate(&text, "body", data); err != nil {
+		return nil, err
+	}
+	if err := t.html[key].Execute(&html, data); err != nil {
+		return nil, err
+	}
+
+	return &mailer.Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+type EmailService struct {
+	employeeRepo repo.Employee
+	tenderRepo   repo.Tender
+	bidRepo      repo.Bid
+	queue        mailQueue
+	templates    *emailTemplates
+	policy       *Policy
+}
+
+func NewEmailService(repos *repo.Repositories, queue mailQueue, policy *Policy) *EmailService {
+	return &EmailService{
+		employeeRepo: repos.Employee,
+		tenderRepo:   repos.Tender,
+		bidRepo:      repos.Bid,
+		queue:        queue,
+		policy:       policy,
+		templates:    parseEmailTemplates(),
+	}
+}
+
+func (s *EmailService) HandleEvent(ctx context.Context, event *entity.Event) {
+	templateName, recipients, err := s.route(ctx, event)
+	if err != nil {
+		log.Println("Failed to get email recipients of event " + event.Type + ": " + err.Error())
+
+		return
+	}
+	if templateName == "" || len(recipients) == 0 {
+		return
+	}
+
+	tender, err := s.tenderRepo.GetTenderById(ctx, event.TenderId.String())
+	if err != nil {
+		log.Println("Failed to prepare email: " + err.Error())
+
+		return
+	}
+
+	data := emailData{TenderName: tender.Name, Decision: event.Decision, Deadline: tender.Deadline}
+	if event.BidId.Valid {
+		bid, err := s.bidRepo.GetBidById(ctx, event.BidId.UUID.String())
+		if err != nil {
+			log.Println("Failed to prepare email: " + err.Error())
+
+			return
+		}
+		data.BidName = bid.Name
+	}
+
+	for _, employeeId := range recipients {
+		employee, err := s.employeeRepo.GetEmployeeById(ctx, employeeId)
+		if err != nil {
+			log.Println("Failed to get email recipient: " + err.Error())
+
+			continue
+		}
+		if employee.Email == "" {
+			continue
+		}
+
+		recipientData := data
+		recipientData.Username = employee.Username
+		message, err := s.templates.render(employee.Locale, templateName, &recipientData)
+		if err != nil {
+			log.Println("Failed to render email " + templateName + ": " + err.Error())
+
+			continue
+		}
+		message.To = employee.Email
+
+		if err = s.queue.Enqueue(message); err != nil {
+			log.Println("Failed to enqueue email to " + employee.Email + ": " + err.Error())
+		}
+	}
+}
+
+func (s *EmailService) route(ctx context.Context, event *entity.Event) (string, []uuid.UUID, error) {
+	switch event.Type {
+	case common.BidDecisionChangedEvent:
+		return "bid_decision", []uuid.UUID{event.BidAuthorId.UUID}, nil
+	case common.BidFeedbackSubmittedEvent:
+		return "bid_feedback", []uuid.UUID{event.BidAuthorId.UUID}, nil
+	case common.TenderClosingSoonEvent:
+		recipients, err := s.bidRepo.GetTenderBidAuthorIds(ctx, event.TenderId)
+
+		return "tender_closing", recipients, err
+	case common.BidVoteRequestedEvent:
+		recipients, err := s.pendingVoters(ctx, event)
+
+		return "bid_vote_requested", recipients, err
+	}
+
+	return "", nil, nil
+}
+
+// Голос запрашивается у сотрудников с правом голосовать в организации тендера, которые ещё не одобрили бид
+func (s *EmailService) pendingVoters(ctx context.Context, event *entity.Event) ([]uuid.UUID, error) {
+	candidates, err := s.policy.EmployeesWith(ctx, event.OrganizationId, DecideBids)
+	if err != nil {
+		return nil, err
+	}
+
+	voters := make([]uuid.UUID, 0)
+	for _, id := range candidates {
+		if event.BidAuthorId.Valid && id == event.BidAuthorId.UUID {
+			continue
+		}
+
+		voted, err := s.bidRepo.AlreadySubmitApprove(ctx, event.BidId.UUID.String(), id.String())
+		if err != nil {
+			return nil, err
+		}
+		if !voted {
+			voters = append(voters, id)
+		}
+	}
+
+	return voters, nil
+}
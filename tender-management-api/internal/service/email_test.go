@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"net"
+	"strings"
+	"tender-management-api/internal/common"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+	"tender-management-api/pkg/mailer"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Заглушки реализуют только методы, которые вызывает EmailService, остальные методы интерфейсов не нужны
+type emailEmployeeRepo struct {
+	repo.Employee
+	employees map[uuid.UUID]*entity.Employee
+}
+
+func (r *emailEmployeeRepo) GetEmployeeById(_ context.Context, id uuid.UUID) (*entity.Employee, error) {
+	return r.employees[id], nil
+}
+
+type emailTenderRepo struct {
+	repo.Tender
+	tender *entity.Tender
+}
+
+func (r *emailTenderRepo) GetTenderById(_ context.Context, _ string) (*entity.Tender, error) {
+	return r.tender, nil
+}
+
+type emailBidRepo struct {
+	repo.Bid
+	bid *entity.Bid
+}
+
+func (r *emailBidRepo) GetBidById(_ context.Context, _ string) (*entity.Bid, error) {
+	return r.bid, nil
+}
+
+func TestEmailServiceSendsLocalizedBidDecision(t *testing.T) {
+	stub, err := mailer.NewStubServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("start stub SMTP server: %v", err)
+	}
+	defer stub.Close()
+
+	host, port, _ := net.SplitHostPort(stub.Addr())
+	queue := mailer.NewQueue(mailer.NewSMTPSender(host, port, "", "", "noreply@tenders.local"),
+		mailer.QueueConfig{Workers: 1, Capacity: 4, MaxAttempts: 1, BaseDelay: time.Millisecond})
+	defer queue.Close()
+
+	alice := &entity.Employee{Id: uuid.New(), Username: "alice", Email: "alice@example.com", Locale: "en"}
+	boris := &entity.Employee{Id: uuid.New(), Username: "boris", Email: "boris@example.com", Locale: "ru"}
+	tender := &entity.Tender{Id: uuid.New(), Name: "Office renovation"}
+	bid := &entity.Bid{Id: uuid.New(), Name: "Turnkey repair"}
+
+	s := NewEmailService(&repo.Repositories{
+		Employee: &emailEmployeeRepo{employees: map[uuid.UUID]*entity.Employee{alice.Id: alice, boris.Id: boris}},
+		Tender:   &emailTenderRepo{tender: tender},
+		Bid:      &emailBidRepo{bid: bid},
+	}, queue, nil)
+
+	for _, author := range []*entity.Employee{alice, boris} {
+		s.HandleEvent(context.Background(), &entity.Event{
+			Type:        common.BidDecisionChangedEvent,
+			TenderId:    tender.Id,
+			BidId:       uuid.NullUUID{UUID: bid.Id, Valid: true},
+			BidAuthorId: uuid.NullUUID{UUID: author.Id, Valid: true},
+			Decision:    "Approved",
+		})
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(stub.Messages()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("got %d emails, want 2", len(stub.Messages()))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	received := make(map[string]*mailer.Message)
+	for _, stubMessage := range stub.Messages() {
+		message, err := stubMessage.Message()
+		if err != nil {
+			t.Fatalf("parse email to %v: %v", stubMessage.To, err)
+		}
+		received[strings.Join(stubMessage.To, ",")] = message
+	}
+
+	tests := []struct {
+		to      string
+		subject string
+		text    string
+		html    string
+	}{
+		{
+			to:      alice.Email,
+			subject: `Your bid "Turnkey repair" was approved`,
+			text:    `Your bid "Turnkey repair" for the tender "Office renovation" was approved.`,
+			html:    "<p>Hello, alice!</p>",
+		},
+		{
+			to:      boris.Email,
+			subject: "Ваше предложение «Turnkey repair» одобрено",
+			text:    "Ваше предложение «Turnkey repair» к тендеру «Office renovation» одобрено.",
+			html:    "<p>Здравствуйте, boris!</p>",
+		},
+	}
+	for _, tt := range tests {
+		message, ok := received[tt.to]
+		if !ok {
+			t.Errorf("no email delivered to %s", tt.to)
+
+			continue
+		}
+		if message.Subject != tt.subject {
+			t.Errorf("subject to %s = %q, want %q", tt.to, message.Subject, tt.subject)
+		}
+		if !strings.Contains(message.Text, tt.text) {
+			t.Errorf("text to %s = %q, want it to contain %q", tt.to, message.Text, tt.text)
+		}
+		if !strings.Contains(message.HTML, tt.html) {
+			t.Errorf("html to %s = %q, want it to contain %q", tt.to, message.HTML, tt.html)
+		}
+	}
+}
@@ -1,25 +1,149 @@
 package service
 
-import "errors"
+import (
+	"maps"
+	"net/http"
+)
+
+// Error - ошибка бизнес-логики. Клиенты различают ошибки по Code: он не меняется вместе с текстом
+// Message, а Status - HTTP-статус, с которым ошибка возвращается клиенту
+type Error struct {
+	Code    string
+	Status  int
+	Message string
+	Details map[string]any
+}
+
+func NewError(code string, status int, message string) *Error {
+	return &Error{Code: code, Status: status, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Копия с подробностями конкретного случая по-прежнему совпадает с исходной ошибкой в errors.Is
+func (e *Error) WithDetails(details map[string]any) *Error {
+	withDetails := *e
+	withDetails.Details = maps.Clone(e.Details)
+	if withDetails.Details == nil {
+		withDetails.Details = make(map[string]any, len(details))
+	}
+	maps.Copy(withDetails.Details, details)
+
+	return &withDetails
+}
+
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+
+	return ok && t.Code == e.Code
+}
 
 var (
-	ErrTenderNotFound                            = errors.New("tender not found")
-	ErrBidNotFound                               = errors.New("bid not found")
-	ErrEmployeeNotFound                          = errors.New("employee not found")
-	ErrUserHasNoAccessToTender                   = errors.New("user doesт't have sufficient rights to access the tender")
-	ErrUserHasNoAccessToBid                      = errors.New("user doesn't have sufficient rights to access the bid")
-	ErrUserNotFound                              = errors.New("user with given username not found")
-	ErrUnauthorizedTryToAccessWithEmployeeRights = errors.New("try to sign in as employee")
-
-	ErrOrganizationNotFound                  = errors.New("organization not found")
-	ErrUserIsNotOrganizationResponsible      = errors.New("user isn't organization responsible")
-	ErrBidCanNotBeProposedBySameOrganization = errors.New("attempt to create a bid on behalf of the organization that owns the tender")
-
-	ErrNoNewChanges                     = errors.New("no new values")
-	ErrBidAuthorCanNotMakeDecisionsOnIt = errors.New("bid author can't approve or reject bid")
-
-	ErrBidAuthorNotAnEmployee = errors.New("no bid author employee with given username")
-	ErrRequesterNotAnEmployee = errors.New("no requester employee with given username")
-	ErrNoSuchVersion          = errors.New("no such version")
-	ErrAlreadyApproveBid      = errors.New("can't approve bid twice")
+	ErrTenderNotFound                            = NewError("tender_not_found", http.StatusNotFound, "There is no tender with given id")
+	ErrBidNotFound                               = NewError("bid_not_found", http.StatusNotFound, "There is no bid with given id")
+	ErrEmployeeNotFound                          = NewError("employee_not_found", http.StatusUnauthorized, "There is no employee with given username or id")
+	ErrUserHasNoAccessToTender                   = NewError("user_has_no_access_to_tender", http.StatusForbidden, "You have not enough rights to perform this action on tender")
+	ErrUserHasNoAccessToBid                      = NewError("user_has_no_access_to_bid", http.StatusForbidden, "You have not enough rights to perform this action on bid")
+	ErrUserNotFound                              = NewError("user_not_found", http.StatusUnauthorized, "There is no user with given username")
+	ErrUnauthorizedTryToAccessWithEmployeeRights = NewError("unauthorized_try_to_access_with_employee_rights", http.StatusForbidden, "Pass username to access with employee rights")
+
+	ErrOrganizationNotFound                  = NewError("organization_not_found", http.StatusNotFound, "There is no organization with given id")
+	ErrUserIsNotOrganizationResponsible      = NewError("user_is_not_organization_responsible", http.StatusForbidden, "You aren't responsible for the organization")
+	ErrBidCanNotBeProposedBySameOrganization = NewError("bid_can_not_be_proposed_by_same_organization", http.StatusForbidden, "Bid can't be proposed on behalf of the organization that owns the tender")
+	ErrBidOrganizationRequired               = NewError("bid_organization_required", http.StatusBadRequest, "You are member of several organizations, provide organizationId")
+
+	ErrNoNewChanges                     = NewError("no_new_changes", http.StatusBadRequest, "Pass at least one changed value")
+	ErrBidAuthorCanNotMakeDecisionsOnIt = NewError("bid_author_can_not_make_decisions_on_it", http.StatusForbidden, "You can't make decision on bid, because you are its author or responsible for its organization")
+
+	ErrBidAuthorNotAnEmployee = NewError("bid_author_not_an_employee", http.StatusNotFound, "There is no employee with given username for author of bid")
+	ErrRequesterNotAnEmployee = NewError("requester_not_an_employee", http.StatusUnauthorized, "There is no employee with given username for review requester")
+	ErrNoSuchVersion          = NewError("no_such_version", http.StatusBadRequest, "No such version")
+	ErrAlreadyApproveBid      = NewError("already_approve_bid", http.StatusForbidden, "You have already approved bid")
+
+	ErrNotificationNotFound = NewError("notification_not_found", http.StatusNotFound, "There is no notification with given id")
+	ErrUnknownEventType     = NewError("unknown_event_type", http.StatusBadRequest, "Notifications can't be configured for given event type")
+
+	ErrAttachmentNotFound         = NewError("attachment_not_found", http.StatusNotFound, "There is no file with given id")
+	ErrAttachmentIsEmpty          = NewError("attachment_is_empty", http.StatusBadRequest, "File is empty")
+	ErrAttachmentTooLarge         = NewError("attachment_too_large", http.StatusRequestEntityTooLarge, "File is larger than 20 MB")
+	ErrAttachmentTypeNotAllowed   = NewError("attachment_type_not_allowed", http.StatusUnsupportedMediaType, "Only pdf, office documents, zip, png, jpeg and plain text files are allowed")
+	ErrAttachmentChecksumMismatch = NewError("attachment_checksum_mismatch", http.StatusBadRequest, "Checksum doesn't match file content")
+	ErrAttachmentCorrupted        = NewError("attachment_corrupted", http.StatusInternalServerError, "Stored file is corrupted")
+
+	ErrTemplateNotFound             = NewError("template_not_found", http.StatusNotFound, "There is no tender template with given id")
+	ErrTemplateAlreadyExists        = NewError("template_already_exists", http.StatusConflict, "Organization already has tender template with such name")
+	ErrTemplatePlaceholderNotFilled = NewError("template_placeholder_not_filled", http.StatusBadRequest, "Pass values for all template placeholders")
+	ErrFilledTemplateIsInvalid      = NewError("filled_template_is_invalid", http.StatusBadRequest, "Tender name should be 1-100 and description 1-500 characters long after filling placeholders")
+
+	ErrUserIsNotAdmin            = NewError("user_is_not_admin", http.StatusForbidden, "Only administrators can perform this action")
+	ErrServiceTypeNotFound       = NewError("service_type_not_found", http.StatusNotFound, "There is no service type with given code")
+	ErrParentServiceTypeNotFound = NewError("parent_service_type_not_found", http.StatusBadRequest, "There is no parent service type with given code")
+	ErrServiceTypeAlreadyExists  = NewError("service_type_already_exists", http.StatusConflict, "Service type with given code already exists")
+	ErrServiceTypeCycle          = NewError("service_type_cycle", http.StatusBadRequest, "Service type can't be nested into itself or its subcategory")
+	ErrServiceTypeInUse          = NewError("service_type_in_use", http.StatusConflict, "Service type has subcategories or is used by tenders")
+
+	ErrTenderAttributeNotFound          = NewError("tender_attribute_not_found", http.StatusNotFound, "Organization has no tender attribute with given code")
+	ErrInvalidTenderAttributeDefinition = NewError("invalid_tender_attribute_definition", http.StatusBadRequest, "Enum attribute needs allowed values and all allowed values should match attribute type")
+	ErrUnknownTenderAttribute           = NewError("unknown_tender_attribute", http.StatusBadRequest, "Organization has no tender attribute with given code")
+	ErrRequiredTenderAttributeMissing   = NewError("required_tender_attribute_missing", http.StatusBadRequest, "Fill all required tender attributes of organization")
+	ErrInvalidTenderAttributeValue      = NewError("invalid_tender_attribute_value", http.StatusBadRequest, "Tender attribute value doesn't match its type or allowed values")
+
+	ErrReviewNotFound          = NewError("review_not_found", http.StatusNotFound, "There is no review with given id")
+	ErrUserHasNoAccessToReview = NewError("user_has_no_access_to_review", http.StatusForbidden, "You have not enough rights to perform this action on review")
+	ErrReviewEditWindowExpired = NewError("review_edit_window_expired", http.StatusConflict, "Review can be edited only within 48 hours after creation")
+	ErrReviewAlreadyReplied    = NewError("review_already_replied", http.StatusConflict, "Review already has a reply")
+	ErrReviewIsHidden          = NewError("review_is_hidden", http.StatusConflict, "Review is hidden by moderator")
+
+	ErrBidAuthorHasNoBidsOnTender = NewError("bid_author_has_no_bids_on_tender", http.StatusNotFound, "Given user has no bids on this tender")
+
+	ErrContractNotFound                   = NewError("contract_not_found", http.StatusNotFound, "There is no contract with given id")
+	ErrUserHasNoAccessToContract          = NewError("user_has_no_access_to_contract", http.StatusForbidden, "You have not enough rights to perform this action on contract")
+	ErrContractIsNotDraft                 = NewError("contract_is_not_draft", http.StatusConflict, "Contract terms can be changed and signed only while it is a draft")
+	ErrContractIsIncomplete               = NewError("contract_is_incomplete", http.StatusConflict, "Fill contract price and dates before signing")
+	ErrInvalidContractDates               = NewError("invalid_contract_dates", http.StatusBadRequest, "Contract end date can't be before start date")
+	ErrContractStatusTransitionNotAllowed = NewError("contract_status_transition_not_allowed", http.StatusConflict, "Contract can't move to given status from its current status")
+	ErrContractTerminationReasonRequired  = NewError("contract_termination_reason_required", http.StatusBadRequest, "Pass reason to terminate contract")
+	ErrContractNotCompleted               = NewError("contract_not_completed", http.StatusConflict, "Feedback can be submitted only after contract on bid is completed")
+	ErrReviewAlreadyExists                = NewError("review_already_exists", http.StatusConflict, "You have already submitted feedback on this contract")
+
+	ErrInvalidPublicKey           = NewError("invalid_public_key", http.StatusBadRequest, "Public key should be base64 encoded 32 byte ed25519 key")
+	ErrPublicKeyAlreadyRegistered = NewError("public_key_already_registered", http.StatusConflict, "Public key is already registered")
+	ErrPublicKeyNotFound          = NewError("public_key_not_found", http.StatusNotFound, "You have no active key with given id")
+	ErrBidVersionNotFound         = NewError("bid_version_not_found", http.StatusNotFound, "Bid has no version with given number")
+	ErrBidVersionAlreadySigned    = NewError("bid_version_already_signed", http.StatusConflict, "Bid version is already signed")
+	ErrInvalidSignature           = NewError("invalid_signature", http.StatusBadRequest, "Signature doesn't match bid version payload")
+	ErrBidIsNotSigned             = NewError("bid_is_not_signed", http.StatusConflict, "Tender requires signed bids, current bid version isn't signed")
+
+	ErrBidIsWithdrawn         = NewError("bid_is_withdrawn", http.StatusConflict, "Bid is withdrawn by its author")
+	ErrBidIsNotWithdrawn      = NewError("bid_is_not_withdrawn", http.StatusConflict, "Only withdrawn bid can be resubmitted")
+	ErrBidDecisionAlreadyMade = NewError("bid_decision_already_made", http.StatusConflict, "Bid can't be withdrawn or canceled after decision on it")
+	ErrBidHasVotes            = NewError("bid_has_votes", http.StatusConflict, "Bid already has votes, tender owner should consent to its withdrawal")
+	ErrTenderDeadlinePassed   = NewError("tender_deadline_passed", http.StatusConflict, "Tender deadline has passed, bid can't be resubmitted")
+	ErrTenderIsNotPublished   = NewError("tender_is_not_published", http.StatusConflict, "Tender isn't published, bid can't be resubmitted")
+
+	ErrOrganizationRelationNotFound      = NewError("organization_relation_not_found", http.StatusNotFound, "There is no organization relation with given id")
+	ErrOrganizationRelationAlreadyExists = NewError("organization_relation_already_exists", http.StatusConflict, "Organizations are already related")
+	ErrOrganizationRelatedToItself       = NewError("organization_related_to_itself", http.StatusBadRequest, "Organization can't be related to itself")
+	ErrBidConflictOfInterest             = NewError("bid_conflict_of_interest", http.StatusForbidden, "Bid author is related to the organization that owns the tender, tender doesn't accept such bids")
+
+	ErrUserCanNotManageRoles  = NewError("user_can_not_manage_roles", http.StatusForbidden, "Only organization administrators can manage its roles")
+	ErrAssigneeNotFound       = NewError("assignee_not_found", http.StatusNotFound, "There is no employee to assign role to")
+	ErrRoleAlreadyAssigned    = NewError("role_already_assigned", http.StatusConflict, "Employee already has this role in organization")
+	ErrRoleAssignmentNotFound = NewError("role_assignment_not_found", http.StatusNotFound, "There is no role assignment with given id")
+
+	ErrDelegatorNotFound          = NewError("delegator_not_found", http.StatusNotFound, "There is no employee to vote on behalf of")
+	ErrApprovalIsNotDelegated     = NewError("approval_is_not_delegated", http.StatusForbidden, "Employee hasn't delegated decision rights to you for today")
+	ErrDelegateNotFound           = NewError("delegate_not_found", http.StatusNotFound, "There is no employee to delegate decision rights to")
+	ErrDelegateIsNotMember        = NewError("delegate_is_not_member", http.StatusBadRequest, "Delegate isn't employee of organization")
+	ErrDelegationToItself         = NewError("delegation_to_itself", http.StatusBadRequest, "Decision rights can't be delegated to yourself")
+	ErrInvalidDelegationDates     = NewError("invalid_delegation_dates", http.StatusBadRequest, "Delegation ends before it starts or has already ended")
+	ErrDelegationOverlaps         = NewError("delegation_overlaps", http.StatusConflict, "Decision rights are already delegated for these dates")
+	ErrApprovalDelegationNotFound = NewError("approval_delegation_not_found", http.StatusNotFound, "There is no delegation with given id")
+	ErrUserIsNotDelegator         = NewError("user_is_not_delegator", http.StatusForbidden, "Only delegator can revoke delegation")
+
+	ErrTenantNotFound         = NewError("tenant_not_found", http.StatusNotFound, "There is no tenant with given id")
+	ErrEmployeeIsNotInTenant  = NewError("employee_is_not_in_tenant", http.StatusForbidden, "Employee belongs to another tenant")
+	ErrTenantRequiresEmployee = NewError("tenant_requires_employee", http.StatusForbidden, "Requests without an employee of the tenant run in the default tenant")
 )
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"strconv"
+	"tender-management-api/internal/common"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+	"tender-management-api/internal/repo/repo_errors"
+	"tender-management-api/pkg/pubsub"
+	"tender-management-api/pkg/tenant"
+
+	"github.com/google/uuid"
+)
+
+const (
+	eventsBacklogPageSize = 500
+	eventsBufferSize      = 64
+)
+
+type eventPublisher interface {
+	Publish(ctx context.Context, event *entity.Event)
+}
+
+// Слушатели вызываются синхронно после сохранения события, в отличие от подписчиков брокера,
+// которые при переполнении буфера отключаются и догоняют пропущенное по журналу
+type eventListener interface {
+	HandleEvent(ctx context.Context, event *entity.Event)
+}
+
+type EventService struct {
+	eventRepo       repo.Event
+	employeeRepo    repo.Employee
+	serviceTypeRepo repo.ServiceType
+	broker          *pubsub.Broker[entity.Event]
+	listeners       []eventListener
+	policy          *Policy
+}
+
+func NewEventService(repos *repo.Repositories, broker *pubsub.Broker[entity.Event], policy *Policy) *EventService {
+	return &EventService{
+		eventRepo:       repos.Event,
+		employeeRepo:    repos.Employee,
+		serviceTypeRepo: repos.ServiceType,
+		broker:          broker,
+		policy:          policy,
+	}
+}
+
+// Событие сохраняется в журнал, чтобы клиенты могли продолжить поток с Last-Event-ID.
+// Ошибка сохранения не должна откатывать уже выполненное действие, поэтому она только логируется
+func (s *EventService) Publish(ctx context.Context, event *entity.Event) {
+	id, createdAt, err := s.eventRepo.CreateEvent(ctx, event)
+	if err != nil {
+		log.Println("Failed to save event " + event.Type + ": " + err.Error())
+
+		return
+	}
+
+	// брокер общий для всех арендаторов, поэтому подписчики фильтруют события по арендатору
+	event.Id, event.CreatedAt = id, createdAt
+	event.TenantId, _ = tenant.FromContext(ctx)
+	s.broker.Publish(*event)
+
+	for _, listener := range s.listeners {
+		listener.HandleEvent(ctx, event)
+	}
+}
+
+func (s *EventService) AddListener(listener eventListener) {
+	s.listeners = append(s.listeners, listener)
+}
+
+// Канал закрывается, когда отменяется контекст запроса или брокер останавливается при shutdown сервера
+func (s *EventService) Subscribe(ctx context.Context, username string, serviceTypes []string, lastEventId int64) (<-chan entity.EventOutputModel, error) {
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrEmployeeNotFound
+		}
+
+		return nil, err
+	}
+
+	// сотрудник может не состоять ни в одной организации, тогда ему видны только его биды и публичные тендеры
+	organizationIds, err := s.policy.OrganizationsWith(ctx, employeeId, ReadTenders)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceTypes, err = expandServiceTypes(ctx, s.serviceTypeRepo, serviceTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantId, _ := tenant.FromContext(ctx)
+	filter := &eventFilter{
+		tenantId:        tenantId,
+		employeeId:      uuid.MustParse(employeeId),
+		organizationIds: organizationIds,
+		serviceTypes:    serviceTypes,
+	}
+
+	// подписываемся до чтения журнала, чтобы не потерять события, появившиеся между запросом и подпиской
+	live, unsubscribe := s.broker.Subscribe(eventsBufferSize)
+
+	// первая страница журнала читается до ответа, чтобы ошибка базы вернулась клиенту статусом, а не обрывом потока
+	backlog := make([]entity.Event, 0)
+	if lastEventId > 0 {
+		backlog, err = s.eventRepo.GetEventsAfterId(ctx, lastEventId, eventsBacklogPageSize)
+		if err != nil {
+			unsubscribe()
+
+			return nil, err
+		}
+	}
+
+	out := make(chan entity.EventOutputModel)
+	go func() {
+		defer close(out)
+		defer func() { unsubscribe() }()
+
+		lastSeenId := lastEventId
+		send := func(event *entity.Event) bool {
+			if !filter.isVisible(event) {
+				return true
+			}
+
+			select {
+			case out <- *mapEvent(event):
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			// журнал читается страницами, пока не догоним его конец: пропуск любой длины доходит до клиента целиком.
+			// Если страницу прочитать не удалось, поток закрывается, и клиент переподключится с id последнего события
+			for {
+				for i := range backlog {
+					if !send(&backlog[i]) {
+						return
+					}
+					lastSeenId = backlog[i].Id
+				}
+				if len(backlog) < eventsBacklogPageSize {
+					break
+				}
+
+				if backlog, err = s.readEventsAfter(ctx, lastSeenId); err != nil {
+					return
+				}
+			}
+
+			// id запоминается и у невидимых событий: после переполнения буфера журнал дочитывается с него
+			for connected := true; connected; {
+				select {
+				case event, ok := <-live:
+					if !ok {
+						connected = false
+
+						break
+					}
+					if event.Id <= lastSeenId {
+						continue
+					}
+					if !send(&event) {
+						return
+					}
+					lastSeenId = event.Id
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-s.broker.Done():
+				return
+			default:
+			}
+
+			// брокер отключил подписку, потому что клиент не успевал читать. Подписываемся снова до чтения журнала,
+			// и пропущенные события приходят из журнала, как при переподключении с Last-Event-ID
+			unsubscribe()
+			live, unsubscribe = s.broker.Subscribe(eventsBufferSize)
+			backlog = backlog[:0]
+			if lastSeenId > 0 {
+				if backlog, err = s.readEventsAfter(ctx, lastSeenId); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (s *EventService) readEventsAfter(ctx context.Context, lastSeenId int64) ([]entity.Event, error) {
+	events, err := s.eventRepo.GetEventsAfterId(ctx, lastSeenId, eventsBacklogPageSize)
+	if err != nil {
+		log.Println("Failed to read events after " + strconv.FormatInt(lastSeenId, 10) + ": " + err.Error())
+	}
+
+	return events, err
+}
+
+type eventFilter struct {
+	tenantId        uuid.UUID
+	employeeId      uuid.UUID
+	organizationIds []uuid.UUID
+	serviceTypes    []string
+}
+
+// Новые опубликованные тендеры видны всем подписанным на их тип услуг,
+// остальные изменения -- только читающим тендеры организации и автору бида
+func (f *eventFilter) isVisible(event *entity.Event) bool {
+	if event.TenantId != f.tenantId {
+		return false
+	}
+	if slices.Contains(f.organizationIds, event.OrganizationId) {
+		return true
+	}
+
+	switch event.Type {
+	case common.TenderPublishedEvent:
+		return len(f.serviceTypes) == 0 || slices.Contains(f.serviceTypes, event.ServiceType)
+	case common.BidStatusChangedEvent, common.BidDecisionChangedEvent, common.BidFeedbackSubmittedEvent:
+		return event.BidAuthorId.Valid && event.BidAuthorId.UUID == f.employeeId
+	}
+
+	return false
+}
+
+func newTenderEvent(eventType string, t *entity.Tender) *entity.Event {
+	return &entity.Event{
+		Type:           eventType,
+		TenderId:       t.Id,
+		OrganizationId: t.OrganizationId,
+		ServiceType:    t.ServiceType,
+		Status:         t.Status,
+	}
+}
+
+func newBidEvent(eventType string, b *entity.Bid, t *entity.Tender) *entity.Event {
+	return &entity.Event{
+		Type:           eventType,
+		TenderId:       t.Id,
+		BidId:          uuid.NullUUID{UUID: b.Id, Valid: true},
+		OrganizationId: t.OrganizationId,
+		BidAuthorId:    uuid.NullUUID{UUID: b.AuthorId, Valid: true},
+		ServiceType:    t.ServiceType,
+		Status:         b.Status,
+		Decision:       b.Decision,
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"tender-management-api/internal/common"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+	"tender-management-api/pkg/pubsub"
+	"tender-management-api/pkg/tenant"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Журнал событий в памяти: id выдаются по порядку, как последовательность в базе
+type eventJournal struct {
+	repo.Event
+	mu     sync.Mutex
+	events []entity.Event
+}
+
+func (r *eventJournal) CreateEvent(ctx context.Context, event *entity.Event) (int64, string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *event
+	stored.Id = int64(len(r.events) + 1)
+	stored.TenantId, _ = tenant.FromContext(ctx)
+	r.events = append(r.events, stored)
+
+	return stored.Id, time.Now().UTC().Format(time.RFC3339), nil
+}
+
+func (r *eventJournal) GetEventsAfterId(_ context.Context, id int64, limit int) ([]entity.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	start := min(int(id), len(r.events))
+	end := min(start+limit, len(r.events))
+
+	return append(make([]entity.Event, 0), r.events[start:end]...), nil
+}
+
+type eventEmployeeRepo struct {
+	repo.Employee
+	employeeId string
+}
+
+func (r *eventEmployeeRepo) GetEmployeeIdByUsername(_ context.Context, _ string) (string, error) {
+	return r.employeeId, nil
+}
+
+func (r *eventEmployeeRepo) GetEmployeeMemberships(_ context.Context, _ uuid.UUID) ([]entity.Membership, error) {
+	return nil, nil
+}
+
+func publishTenders(ctx context.Context, s *EventService, count int) {
+	for i := 0; i < count; i++ {
+		s.Publish(ctx, &entity.Event{Type: common.TenderPublishedEvent, TenderId: uuid.New(), ServiceType: "Delivery"})
+	}
+}
+
+func receiveEvents(t *testing.T, events <-chan entity.EventOutputModel, from int64, count int) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for want := from; want < from+int64(count); want++ {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatalf("stream closed before event %d", want)
+			}
+			if event.Id != want {
+				t.Fatalf("got event %d, want %d", event.Id, want)
+			}
+		case <-timeout:
+			t.Fatalf("no event %d", want)
+		}
+	}
+}
+
+// Клиент, который не успевает читать, не теряет события: ни пока дочитывается длинный журнал,
+// ни позже, когда новых событий больше, чем помещается в буфер подписки
+func TestSubscribeCatchesUpAfterBufferOverflow(t *testing.T) {
+	broker := pubsub.New[entity.Event]()
+	defer broker.Close()
+
+	employeeId := uuid.NewString()
+	s := NewEventService(&repo.Repositories{
+		Event:    &eventJournal{},
+		Employee: &eventEmployeeRepo{employeeId: employeeId},
+	}, broker, &Policy{employeeRepo: &eventEmployeeRepo{employeeId: employeeId}})
+
+	ctx, cancel := context.WithCancel(tenant.WithId(context.Background(), uuid.New()))
+	defer cancel()
+
+	backlog := 2*eventsBacklogPageSize + 100
+	publishTenders(ctx, s, backlog)
+
+	events, err := s.Subscribe(ctx, "reader", nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// поток стоит на первой странице журнала, пока публикуются новые события
+	live := 4 * eventsBufferSize
+	publishTenders(ctx, s, live)
+	receiveEvents(t, events, 2, backlog+live-1)
+
+	// журнал дочитан, и буфер переполняется уже при обычной доставке
+	publishTenders(ctx, s, live)
+	receiveEvents(t, events, int64(backlog+live+1), live)
+
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event %d", event.Id)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
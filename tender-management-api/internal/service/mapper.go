@@ -2,18 +2,25 @@ package service
 
 import (
 	"tender-management-api/internal/entity"
+
+	"github.com/google/uuid"
 )
 
 func mapTender(t *entity.Tender) *entity.TenderOutputModel {
 	return &entity.TenderOutputModel{
-		Id:             t.Id.String(),
-		Name:           t.Name,
-		Description:    t.Description,
-		ServiceType:    t.ServiceType,
-		Status:         t.Status,
-		OrganizationId: t.OrganizationId.String(),
-		Version:        t.Version,
-		CreatedAt:      t.CreatedAt,
+		Id:                t.Id.String(),
+		Name:              t.Name,
+		Description:       t.Description,
+		ServiceType:       t.ServiceType,
+		Status:            t.Status,
+		OrganizationId:    t.OrganizationId.String(),
+		Version:           t.Version,
+		CreatedAt:         t.CreatedAt,
+		Deadline:          t.Deadline,
+		Tags:              t.Tags,
+		Attributes:        t.Attributes,
+		RequiresSignature: t.RequiresSignature,
+		ConflictPolicy:    t.ConflictPolicy,
 	}
 }
 
@@ -28,13 +35,22 @@ func mapTenders(t []entity.Tender) []entity.TenderOutputModel {
 
 func mapBid(t *entity.Bid) *entity.BidOutputModel {
 	return &entity.BidOutputModel{
-		Id:         t.Id.String(),
-		Name:       t.Name,
-		Status:     t.Status,
-		Version:    t.Version,
-		CreatedAt:  t.CreatedAt,
-		AuthorType: t.AuthorType,
-		AuthorId:   t.AuthorId.String(),
+		Id:             t.Id.String(),
+		Name:           t.Name,
+		Description:    t.Description,
+		Status:         t.Status,
+		TenderId:       t.TenderId.String(),
+		Version:        t.Version,
+		CreatedAt:      t.CreatedAt,
+		AuthorType:     t.AuthorType,
+		AuthorId:       t.AuthorId.String(),
+		OrganizationId: optionalUuidString(t.OrganizationId),
+		Signed:         t.Signed,
+
+		WithdrawalReason: t.WithdrawalReason,
+		WithdrawnAt:      t.WithdrawnAt,
+
+		ConflictOfInterest: t.ConflictOfInterest,
 	}
 }
 
@@ -47,11 +63,62 @@ func mapBids(b []entity.Bid) []entity.BidOutputModel {
 	return s
 }
 
+func mapBidVersions(versions []entity.BidVersion) []entity.BidVersionOutputModel {
+	s := make([]entity.BidVersionOutputModel, 0)
+	for _, v := range versions {
+		s = append(s, entity.BidVersionOutputModel{
+			Version:     v.Version,
+			Name:        v.Name,
+			Description: v.Description,
+			CreatedBy:   v.CreatedByUsername,
+			Action:      v.Action,
+			CreatedAt:   v.CreatedAt,
+			Signed:      v.Signed,
+		})
+	}
+
+	return s
+}
+
+func mapBidVotes(votes []entity.BidVote) []entity.BidVoteOutputModel {
+	s := make([]entity.BidVoteOutputModel, 0)
+	for _, v := range votes {
+		vote := entity.BidVoteOutputModel{
+			EmployeeId:       v.EmployeeId.String(),
+			EmployeeUsername: v.EmployeeUsername,
+			DelegateUsername: v.DelegateUsername,
+			Decision:         v.Decision,
+			CreatedAt:        v.CreatedAt,
+		}
+		if v.DelegateId.Valid {
+			vote.DelegateId = v.DelegateId.UUID.String()
+		}
+		s = append(s, vote)
+	}
+
+	return s
+}
+
 func mapReview(t entity.Review) *entity.ReviewOutputModel {
 	return &entity.ReviewOutputModel{
-		Id:          t.Id.String(),
-		Description: t.Description,
-		CreatedAt:   t.CreatedAt,
+		Id:                       t.Id.String(),
+		BidId:                    t.BidId,
+		ContractId:               optionalUuidString(t.ContractId),
+		TenderId:                 t.TenderId.String(),
+		AuthorUsername:           t.AuthorUsername,
+		ReviewerOrganizationId:   t.ReviewerOrganizationId.String(),
+		ReviewerOrganizationName: t.ReviewerOrganizationName,
+		Description:              t.Description,
+		QualityRating:            t.QualityRating,
+		TimelinessRating:         t.TimelinessRating,
+		CommunicationRating:      t.CommunicationRating,
+		Version:                  t.Version,
+		CreatedAt:                t.CreatedAt,
+		UpdatedAt:                t.UpdatedAt,
+		Reply:                    t.Reply,
+		RepliedAt:                t.RepliedAt,
+		Hidden:                   t.HiddenAt != "",
+		HiddenReason:             t.HiddenReason,
 	}
 }
 
@@ -63,3 +130,180 @@ func mapReviews(review []entity.Review) []entity.ReviewOutputModel {
 
 	return s
 }
+
+func mapReviewVersion(v entity.ReviewVersion) *entity.ReviewVersionOutputModel {
+	return &entity.ReviewVersionOutputModel{
+		Version:             v.Version,
+		Description:         v.Description,
+		QualityRating:       v.QualityRating,
+		TimelinessRating:    v.TimelinessRating,
+		CommunicationRating: v.CommunicationRating,
+		CreatedAt:           v.CreatedAt,
+	}
+}
+
+func mapReviewVersions(versions []entity.ReviewVersion) []entity.ReviewVersionOutputModel {
+	s := make([]entity.ReviewVersionOutputModel, 0)
+	for _, v := range versions {
+		s = append(s, *mapReviewVersion(v))
+	}
+
+	return s
+}
+
+func mapEvent(e *entity.Event) *entity.EventOutputModel {
+	m := &entity.EventOutputModel{
+		Id:          e.Id,
+		Type:        e.Type,
+		TenderId:    e.TenderId.String(),
+		ServiceType: e.ServiceType,
+		Status:      e.Status,
+		Decision:    e.Decision,
+		CreatedAt:   e.CreatedAt,
+	}
+	if e.BidId.Valid {
+		m.BidId = e.BidId.UUID.String()
+	}
+
+	return m
+}
+
+func mapNotification(n *entity.Notification) *entity.NotificationOutputModel {
+	m := &entity.NotificationOutputModel{
+		Id:        n.Id.String(),
+		Type:      n.Event.Type,
+		TenderId:  n.Event.TenderId.String(),
+		Status:    n.Event.Status,
+		Decision:  n.Event.Decision,
+		IsRead:    n.IsRead,
+		CreatedAt: n.CreatedAt,
+	}
+	if n.Event.BidId.Valid {
+		m.BidId = n.Event.BidId.UUID.String()
+	}
+
+	return m
+}
+
+func mapNotifications(notifications []entity.Notification) []entity.NotificationOutputModel {
+	s := make([]entity.NotificationOutputModel, 0)
+	for _, n := range notifications {
+		s = append(s, *mapNotification(&n))
+	}
+
+	return s
+}
+
+func mapAttachment(a *entity.Attachment) *entity.AttachmentOutputModel {
+	return &entity.AttachmentOutputModel{
+		Id:          a.Id.String(),
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+func mapAttachments(attachments []entity.Attachment) []entity.AttachmentOutputModel {
+	s := make([]entity.AttachmentOutputModel, 0)
+	for _, a := range attachments {
+		s = append(s, *mapAttachment(&a))
+	}
+
+	return s
+}
+
+func mapTenderTemplate(t *entity.TenderTemplate) *entity.TenderTemplateOutputModel {
+	return &entity.TenderTemplateOutputModel{
+		Id:                t.Id.String(),
+		Name:              t.Name,
+		OrganizationId:    t.OrganizationId.String(),
+		TenderName:        t.TenderName,
+		TenderDescription: t.TenderDescription,
+		ServiceType:       t.ServiceType,
+		Placeholders:      templatePlaceholders(t.TenderName, t.TenderDescription),
+		CreatedAt:         t.CreatedAt,
+	}
+}
+
+func mapTenderTemplates(templates []entity.TenderTemplate) []entity.TenderTemplateOutputModel {
+	s := make([]entity.TenderTemplateOutputModel, 0)
+	for _, t := range templates {
+		s = append(s, *mapTenderTemplate(&t))
+	}
+
+	return s
+}
+
+func mapServiceType(t *entity.ServiceType) *entity.ServiceTypeOutputModel {
+	return &entity.ServiceTypeOutputModel{
+		Code:       t.Code,
+		Name:       t.Name,
+		ParentCode: t.ParentCode,
+		CreatedAt:  t.CreatedAt,
+	}
+}
+
+func mapServiceTypes(serviceTypes []entity.ServiceType) []entity.ServiceTypeOutputModel {
+	s := make([]entity.ServiceTypeOutputModel, 0)
+	for _, t := range serviceTypes {
+		s = append(s, *mapServiceType(&t))
+	}
+
+	return s
+}
+
+func mapTenderAttributeDefinitions(definitions []entity.TenderAttributeDefinition) []entity.TenderAttributeDefinitionOutputModel {
+	s := make([]entity.TenderAttributeDefinitionOutputModel, 0)
+	for _, d := range definitions {
+		s = append(s, entity.TenderAttributeDefinitionOutputModel{
+			Code:          d.Code,
+			Name:          d.Name,
+			Type:          d.Type,
+			Required:      d.Required,
+			AllowedValues: d.AllowedValues,
+			CreatedAt:     d.CreatedAt,
+		})
+	}
+
+	return s
+}
+
+func optionalUuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+
+	return id.String()
+}
+
+func mapContract(c *entity.Contract) *entity.ContractOutputModel {
+	return &entity.ContractOutputModel{
+		Id:                     c.Id.String(),
+		TenderId:               c.TenderId.String(),
+		BidId:                  c.BidId.String(),
+		BidVersion:             c.BidVersion,
+		CustomerOrganizationId: c.CustomerOrganizationId.String(),
+		SupplierId:             c.SupplierId.String(),
+		SupplierOrganizationId: optionalUuidString(c.SupplierOrganizationId),
+		Price:                  c.Price,
+		StartDate:              c.StartDate,
+		EndDate:                c.EndDate,
+		Status:                 c.Status,
+		CustomerSignedAt:       c.CustomerSignedAt,
+		SupplierSignedAt:       c.SupplierSignedAt,
+		TerminationReason:      c.TerminationReason,
+		CreatedAt:              c.CreatedAt,
+		UpdatedAt:              c.UpdatedAt,
+	}
+}
+
+func mapContracts(contracts []entity.Contract) []entity.ContractOutputModel {
+	s := make([]entity.ContractOutputModel, 0)
+	for _, c := range contracts {
+		s = append(s, *mapContract(&c))
+	}
+
+	return s
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"tender-management-api/internal/common"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+	"tender-management-api/internal/repo/repo_errors"
+
+	"github.com/google/uuid"
+)
+
+// Типы событий, о которых сотрудники получают уведомления. Для каждого из них можно отключить уведомления
+func notifiableEventTypes() []string {
+	return []string{
+		common.BidDecisionChangedEvent,
+		common.BidFeedbackSubmittedEvent,
+		common.BidStatusChangedEvent,
+	}
+}
+
+type NotificationService struct {
+	notificationRepo repo.Notification
+	employeeRepo     repo.Employee
+	policy           *Policy
+}
+
+func NewNotificationService(repos *repo.Repositories, policy *Policy) *NotificationService {
+	return &NotificationService{
+		notificationRepo: repos.Notification,
+		employeeRepo:     repos.Employee,
+		policy:           policy,
+	}
+}
+
+// Автор бида узнает о решении и отзывах, голосующие в организации тендера -- об изменении статуса бида
+func (s *NotificationService) recipients(ctx context.Context, event *entity.Event) ([]uuid.UUID, error) {
+	switch event.Type {
+	case common.BidDecisionChangedEvent, common.BidFeedbackSubmittedEvent:
+		if !event.BidAuthorId.Valid {
+			return nil, nil
+		}
+
+		return []uuid.UUID{event.BidAuthorId.UUID}, nil
+	case common.BidStatusChangedEvent:
+		return s.policy.EmployeesWith(ctx, event.OrganizationId, DecideBids)
+	}
+
+	return nil, nil
+}
+
+func (s *NotificationService) HandleEvent(ctx context.Context, event *entity.Event) {
+	recipients, err := s.recipients(ctx, event)
+	if err != nil {
+		log.Println("Failed to get recipients of event " + event.Type + ": " + err.Error())
+
+		return
+	}
+
+	for _, employeeId := range recipients {
+		enabled, err := s.isEnabled(ctx, employeeId.String(), event.Type)
+		if err != nil {
+			log.Println("Failed to get notification preferences: " + err.Error())
+
+			continue
+		}
+		if !enabled {
+			continue
+		}
+
+		if err = s.notificationRepo.CreateNotification(ctx, employeeId, event.Id); err != nil {
+			log.Println("Failed to create notification: " + err.Error())
+		}
+	}
+}
+
+func (s *NotificationService) isEnabled(ctx context.Context, employeeId string, eventType string) (bool, error) {
+	preferences, err := s.notificationRepo.GetNotificationPreferences(ctx, employeeId)
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range preferences {
+		if p.EventType == eventType {
+			return p.Enabled, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (s *NotificationService) getEmployeeId(ctx context.Context, username string) (string, error) {
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return "", ErrEmployeeNotFound
+		}
+
+		return "", err
+	}
+
+	return employeeId, nil
+}
+
+func (s *NotificationService) GetNotifications(ctx context.Context, username string, unreadOnly bool, pg *entity.PaginationInput) (*entity.NotificationsOutputModel, error) {
+	employeeId, err := s.getEmployeeId(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := s.notificationRepo.GetNotifications(ctx, employeeId, unreadOnly, pg)
+	if err != nil {
+		return nil, err
+	}
+
+	unreadCount, err := s.notificationRepo.CountUnreadNotifications(ctx, employeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.NotificationsOutputModel{
+		UnreadCount:   unreadCount,
+		Notifications: mapNotifications(notifications),
+	}, nil
+}
+
+func (s *NotificationService) MarkNotificationRead(ctx context.Context, notificationId string, username string) (*entity.NotificationOutputModel, error) {
+	employeeId, err := s.getEmployeeId(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	notification, err := s.notificationRepo.GetNotificationById(ctx, notificationId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrNotificationNotFound
+		}
+
+		return nil, err
+	}
+
+	// чужие уведомления не раскрываем, даже сам факт их существования
+	if notification.EmployeeId.String() != employeeId {
+		return nil, ErrNotificationNotFound
+	}
+
+	if err = s.notificationRepo.MarkNotificationsRead(ctx, employeeId, []uuid.UUID{notification.Id}); err != nil {
+		return nil, err
+	}
+	notification.IsRead = true
+
+	return mapNotification(notification), nil
+}
+
+func (s *NotificationService) MarkAllNotificationsRead(ctx context.Context, username string) (*entity.NotificationsOutputModel, error) {
+	employeeId, err := s.getEmployeeId(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.notificationRepo.MarkNotificationsRead(ctx, employeeId, nil); err != nil {
+		return nil, err
+	}
+
+	return &entity.NotificationsOutputModel{UnreadCount: 0, Notifications: make([]entity.NotificationOutputModel, 0)}, nil
+}
+
+func (s *NotificationService) GetNotificationPreferences(ctx context.Context, username string) ([]entity.NotificationPreferenceOutputModel, error) {
+	employeeId, err := s.getEmployeeId(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getPreferences(ctx, employeeId)
+}
+
+func (s *NotificationService) SetNotificationPreference(ctx context.Context, username string, eventType string, enabled bool) ([]entity.NotificationPreferenceOutputModel, error) {
+	if !slices.Contains(notifiableEventTypes(), eventType) {
+		return nil, ErrUnknownEventType
+	}
+
+	employeeId, err := s.getEmployeeId(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.notificationRepo.SetNotificationPreference(ctx, employeeId, eventType, enabled); err != nil {
+		return nil, err
+	}
+
+	return s.getPreferences(ctx, employeeId)
+}
+
+// По умолчанию уведомления включены, в БД хранятся только явно заданные настройки
+func (s *NotificationService) getPreferences(ctx context.Context, employeeId string) ([]entity.NotificationPreferenceOutputModel, error) {
+	stored, err := s.notificationRepo.GetNotificationPreferences(ctx, employeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := make([]entity.NotificationPreferenceOutputModel, 0)
+	for _, eventType := range notifiableEventTypes() {
+		preference := entity.NotificationPreferenceOutputModel{EventType: eventType, Enabled: true}
+		for _, p := range stored {
+			if p.EventType == eventType {
+				preference.Enabled = p.Enabled
+			}
+		}
+		preferences = append(preferences, preference)
+	}
+
+	return preferences, nil
+}
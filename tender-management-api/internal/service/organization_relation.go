@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+	"tender-management-api/internal/repo/repo_errors"
+
+	"github.com/google/uuid"
+)
+
+func mapOrganizationRelation(r *entity.OrganizationRelation) *entity.OrganizationRelationOutputModel {
+	return &entity.OrganizationRelationOutputModel{
+		Id:                      r.Id.String(),
+		OrganizationId:          r.OrganizationId.String(),
+		OrganizationName:        r.OrganizationName,
+		RelatedOrganizationId:   r.RelatedOrganizationId.String(),
+		RelatedOrganizationName: r.RelatedOrganizationName,
+		Type:                    r.Type,
+		CreatedAt:               r.CreatedAt,
+	}
+}
+
+func mapOrganizationRelations(relations []entity.OrganizationRelation) []entity.OrganizationRelationOutputModel {
+	s := make([]entity.OrganizationRelationOutputModel, 0)
+	for _, r := range relations {
+		s = append(s, *mapOrganizationRelation(&r))
+	}
+
+	return s
+}
+
+func mapOrganizationConflicts(conflicts []entity.OrganizationConflict) []entity.OrganizationConflictOutputModel {
+	s := make([]entity.OrganizationConflictOutputModel, 0)
+	for _, c := range conflicts {
+		s = append(s, entity.OrganizationConflictOutputModel{
+			OrganizationId:   c.OrganizationId.String(),
+			OrganizationName: c.OrganizationName,
+			Reason:           c.Reason,
+		})
+	}
+
+	return s
+}
+
+const conflictReportPageSize = 50
+
+type OrganizationRelationService struct {
+	relationRepo repo.OrganizationRelation
+	employeeRepo repo.Employee
+	tenderRepo   repo.Tender
+	bidRepo      repo.Bid
+	policy       *Policy
+}
+
+func NewOrganizationRelationService(repos *repo.Repositories, policy *Policy) *OrganizationRelationService {
+	return &OrganizationRelationService{
+		relationRepo: repos.OrganizationRelation,
+		employeeRepo: repos.Employee,
+		tenderRepo:   repos.Tender,
+		bidRepo:      repos.Bid,
+		policy:       policy,
+	}
+}
+
+func (s *OrganizationRelationService) getEmployeeId(ctx context.Context, username string) (string, error) {
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return "", ErrEmployeeNotFound
+		}
+
+		return "", err
+	}
+
+	return employeeId, nil
+}
+
+// Связи задает аудит, а не сами организации, иначе связанная сторона могла бы их скрыть
+func (s *OrganizationRelationService) checkAdmin(ctx context.Context, employeeId string) error {
+	canManage, err := s.policy.CanOnService(ctx, employeeId, ManageRelations)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return ErrUserIsNotAdmin
+	}
+
+	return nil
+}
+
+func (s *OrganizationRelationService) CreateOrganizationRelation(ctx context.Context, username string, organizationId string, relatedOrganizationId string, relationType string) (*entity.OrganizationRelationOutputModel, error) {
+	employeeId, err := s.getEmployeeId(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkAdmin(ctx, employeeId); err != nil {
+		return nil, err
+	}
+
+	organizationUuid, err := uuid.Parse(organizationId)
+	if err != nil {
+		return nil, ErrOrganizationNotFound
+	}
+	relatedOrganizationUuid, err := uuid.Parse(relatedOrganizationId)
+	if err != nil {
+		return nil, ErrOrganizationNotFound
+	}
+	if organizationUuid == relatedOrganizationUuid {
+		return nil, ErrOrganizationRelatedToItself
+	}
+
+	employeeUuid, _ := uuid.Parse(employeeId)
+	id, err := s.relationRepo.CreateOrganizationRelation(ctx, &entity.CreateOrganizationRelationInput{
+		OrganizationId:        organizationUuid,
+		RelatedOrganizationId: relatedOrganizationUuid,
+		Type:                  relationType,
+		CreatedBy:             employeeUuid,
+	})
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrAlreadyExists) {
+			return nil, ErrOrganizationRelationAlreadyExists
+		}
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+
+		return nil, err
+	}
+
+	relation, err := s.relationRepo.GetOrganizationRelationById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapOrganizationRelation(relation), nil
+}
+
+// Связи организации видят те, кто читает ее тендеры, и администраторы
+func (s *OrganizationRelationService) GetOrganizationRelations(ctx context.Context, organizationId string, username string) ([]entity.OrganizationRelationOutputModel, error) {
+	employeeId, err := s.getEmployeeId(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationExists, err := s.employeeRepo.DoesOrganizationExistById(ctx, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	if !organizationExists {
+		return nil, ErrOrganizationNotFound
+	}
+
+	organizationUuid, _ := uuid.Parse(organizationId)
+	canRead, err := s.policy.Can(ctx, employeeId, organizationUuid, ReadRelations)
+	if err != nil {
+		return nil, err
+	}
+	if !canRead {
+		return nil, ErrUserIsNotOrganizationResponsible
+	}
+
+	relations, err := s.relationRepo.GetOrganizationRelations(ctx, organizationUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapOrganizationRelations(relations), nil
+}
+
+func (s *OrganizationRelationService) DeleteOrganizationRelation(ctx context.Context, relationId string, username string) error {
+	employeeId, err := s.getEmployeeId(ctx, username)
+	if err != nil {
+		return err
+	}
+	if err = s.checkAdmin(ctx, employeeId); err != nil {
+		return err
+	}
+
+	relationUuid, err := uuid.Parse(relationId)
+	if err != nil {
+		return ErrOrganizationRelationNotFound
+	}
+
+	if err = s.relationRepo.DeleteOrganizationRelation(ctx, relationUuid); err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return ErrOrganizationRelationNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Отчет строится по текущим связям. Предложения, отмеченные при подаче, попадают в него,
+// даже если связь с тех пор удалили
+func (s *OrganizationRelationService) GetTenderConflictReport(ctx context.Context, tenderId string, username string) (*entity.TenderConflictReportOutputModel, error) {
+	employeeId, err := s.getEmployeeId(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	tender, err := s.tenderRepo.GetTenderById(ctx, tenderId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrTenderNotFound
+		}
+
+		return nil, err
+	}
+
+	canRead, err := s.policy.Can(ctx, employeeId, tender.OrganizationId, ReadRelations)
+	if err != nil {
+		return nil, err
+	}
+	if !canRead {
+		return nil, ErrUserHasNoAccessToTender
+	}
+
+	conflicts, err := s.relationRepo.GetTenderBidConflicts(ctx, tender.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	conflictsByBid := make(map[uuid.UUID][]entity.OrganizationConflict)
+	for _, c := range conflicts {
+		conflictsByBid[c.BidId] = append(conflictsByBid[c.BidId], c)
+	}
+
+	report := &entity.TenderConflictReportOutputModel{
+		TenderId:       tender.Id.String(),
+		ConflictPolicy: tender.ConflictPolicy,
+		Bids:           make([]entity.BidConflictOutputModel, 0),
+	}
+
+	pg := entity.NewPaginationInput(conflictReportPageSize, 0)
+	for {
+		bids, err := s.bidRepo.GetTenderBids(ctx, tenderId, pg)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, bid := range bids {
+			bidConflicts, ok := conflictsByBid[bid.Id]
+			if !ok && !bid.ConflictOfInterest {
+				continue
+			}
+			report.Bids = append(report.Bids, entity.BidConflictOutputModel{
+				Bid:       *mapBid(&bid),
+				Conflicts: mapOrganizationConflicts(bidConflicts),
+			})
+		}
+
+		if len(bids) < pg.Limit {
+			break
+		}
+		pg.Offset += pg.Limit
+	}
+
+	return report, nil
+}
@@ -0,0 +1,407 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"tender-management-api/internal/common"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+
+	"github.com/google/uuid"
+)
+
+// Разрешение действует в рамках одной организации, кроме разрешений сервиса в целом
+type Permission string
+
+const (
+	ReadTenders     Permission = "tenders:read"     // непубличные тендеры, предложения к ним, вложения и контракты заказчика
+	ManageTenders   Permission = "tenders:manage"   // тендеры, шаблоны, атрибуты и условия контрактов
+	DecideBids      Permission = "bids:decide"      // голосование по предложениям и согласие на их отзыв
+	ReviewBids      Permission = "bids:review"      // отзывы на исполнителей и их история
+	ModerateReviews Permission = "reviews:moderate" // скрытые отзывы и их модерация по тендерам организации
+	ReadBids        Permission = "bids:read"        // предложения, поданные от имени организации, и контракты по ним
+	ManageBids      Permission = "bids:manage"      // подача, изменение, подпись и отзыв предложений от имени организации
+	ReadRoles       Permission = "roles:read"
+	ManageRoles     Permission = "roles:manage"
+	ReadRelations   Permission = "relations:read" // связи организации и конфликты интересов по ее тендерам
+)
+
+// Разрешения сервиса в целом, а не организации. Их дает только флаг администратора сервиса
+const (
+	ManageCatalog   Permission = "catalog:manage"   // справочник типов услуг
+	ManageRelations Permission = "relations:manage" // связи организаций, по которым ищутся конфликты интересов
+)
+
+// Аудитор только читает, поэтому у него нет ни одного *:manage или *:decide разрешения
+var rolePermissions = map[string][]Permission{
+	common.OrgAdminRole:      {ReadRoles, ManageRoles, ReadTenders, ReadBids, ReadRelations, ModerateReviews},
+	common.TenderManagerRole: {ReadTenders, ManageTenders, ReviewBids, ModerateReviews, ReadRelations},
+	common.ApproverRole:      {ReadTenders, DecideBids, ReadRelations},
+	common.BidderRole:        {ReadBids, ManageBids},
+	common.AuditorRole:       {ReadRoles, ReadTenders, ReadBids, ReadRelations},
+}
+
+// Администратор сервиса получает эти разрешения в любой организации, даже не состоя в ней.
+// Действовать от имени организации (тендеры, предложения, голосование) он так не может
+var adminPermissions = []Permission{ReadRoles, ManageRoles, ReadRelations, ModerateReviews, ManageCatalog, ManageRelations}
+
+// Ответственным без назначенных ролей достаются все роли, кроме аудитора, - так права
+// организаций, заведенных до появления ролей, не меняются
+var defaultResponsibleRoles = []string{common.OrgAdminRole, common.TenderManagerRole, common.ApproverRole, common.BidderRole}
+
+// Policy - единственное место, где решается, что сотруднику можно делать в организации
+type Policy struct {
+	roleRepo     repo.Role
+	employeeRepo repo.Employee
+	tenderRepo   repo.Tender
+}
+
+func NewPolicy(repos *repo.Repositories) *Policy {
+	return &Policy{
+		roleRepo:     repos.Role,
+		employeeRepo: repos.Employee,
+		tenderRepo:   repos.Tender,
+	}
+}
+
+func (p *Policy) employeeRoles(ctx context.Context, employeeId string, organizationId uuid.UUID) ([]string, error) {
+	employeeUuid, err := uuid.Parse(employeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := p.roleRepo.GetEmployeeRoles(ctx, employeeUuid, organizationId)
+	if err != nil || len(roles) > 0 {
+		return roles, err
+	}
+
+	isResponsible, err := p.employeeRepo.IsEmployeeResponsible(ctx, employeeId, organizationId)
+	if err != nil || !isResponsible {
+		return nil, err
+	}
+
+	return defaultResponsibleRoles, nil
+}
+
+func (p *Policy) Can(ctx context.Context, employeeId string, organizationId uuid.UUID, permission Permission) (bool, error) {
+	roles, err := p.employeeRoles(ctx, employeeId, organizationId)
+	if err != nil {
+		return false, err
+	}
+
+	for _, role := range roles {
+		if slices.Contains(rolePermissions[role], permission) {
+			return true, nil
+		}
+	}
+
+	return p.CanOnService(ctx, employeeId, permission)
+}
+
+// Разрешения, которые не привязаны к организации, например, изменение справочника типов услуг
+func (p *Policy) CanOnService(ctx context.Context, employeeId string, permission Permission) (bool, error) {
+	if !slices.Contains(adminPermissions, permission) {
+		return false, nil
+	}
+
+	return p.employeeRepo.IsEmployeeAdmin(ctx, employeeId)
+}
+
+// Сотрудником организации считается любой, у кого в ней есть роль, в том числе по умолчанию
+func (p *Policy) IsMember(ctx context.Context, employeeId string, organizationId uuid.UUID) (bool, error) {
+	roles, err := p.employeeRoles(ctx, employeeId, organizationId)
+	if err != nil {
+		return false, err
+	}
+
+	return len(roles) > 0, nil
+}
+
+// Права на предложение со стороны участника: личным предложением распоряжается только автор,
+// предложением от имени организации - еще и сотрудники с нужной ролью в ней
+func (p *Policy) CanOnBid(ctx context.Context, employeeId string, bid *entity.Bid, permission Permission) (bool, error) {
+	if bid.AuthorId.String() == employeeId {
+		return true, nil
+	}
+	if bid.AuthorType != common.OrganizationAuthor || bid.OrganizationId == nil {
+		return false, nil
+	}
+
+	return p.Can(ctx, employeeId, *bid.OrganizationId, permission)
+}
+
+// Предложение видят его участники и те, кто читает тендеры организации, открывшей тендер
+func (p *Policy) CanReadBid(ctx context.Context, employeeId string, bid *entity.Bid) (bool, error) {
+	canRead, err := p.CanOnBid(ctx, employeeId, bid, ReadBids)
+	if err != nil || canRead {
+		return canRead, err
+	}
+
+	tender, err := p.tenderRepo.GetTenderById(ctx, bid.TenderId.String())
+	if err != nil {
+		return false, err
+	}
+
+	return p.Can(ctx, employeeId, tender.OrganizationId, ReadTenders)
+}
+
+// Права на предложение, собранные за один вызов: сервисам с несколькими проверками
+// не нужно обращаться к политике по каждой из них
+type BidAccess struct {
+	CanRead     bool // то же, что CanReadBid
+	CanManage   bool // то же, что CanOnBid с ManageBids
+	IsModerator bool // модератор отзывов по тендерам организации, открывшей тендер
+}
+
+func (p *Policy) BidAccess(ctx context.Context, employeeId string, bid *entity.Bid) (*BidAccess, error) {
+	tender, err := p.tenderRepo.GetTenderById(ctx, bid.TenderId.String())
+	if err != nil {
+		return nil, err
+	}
+
+	access := &BidAccess{}
+	if access.CanManage, err = p.CanOnBid(ctx, employeeId, bid, ManageBids); err != nil {
+		return nil, err
+	}
+
+	// управлять предложением без права его читать нельзя: у исполнителя есть оба разрешения
+	access.CanRead = access.CanManage
+	if !access.CanRead {
+		if access.CanRead, err = p.CanOnBid(ctx, employeeId, bid, ReadBids); err != nil {
+			return nil, err
+		}
+	}
+	if !access.CanRead {
+		if access.CanRead, err = p.Can(ctx, employeeId, tender.OrganizationId, ReadTenders); err != nil {
+			return nil, err
+		}
+	}
+
+	if access.IsModerator, err = p.Can(ctx, employeeId, tender.OrganizationId, ModerateReviews); err != nil {
+		return nil, err
+	}
+
+	return access, nil
+}
+
+type BidDecisionAccess struct {
+	IsParticipant bool // голосующий или тот, за кого он голосует, сам распоряжается предложением
+	CanDecide     bool // у того, чей голос засчитывается, есть право голосовать в организации тендера
+}
+
+// Голосует voterId, а employeeId - тот, кто отправил решение, сам или по делегированию.
+// Участник предложения не голосует по нему, даже если у него есть право голосовать
+func (p *Policy) BidDecision(ctx context.Context, employeeId string, voterId string, bid *entity.Bid, tenderOrganizationId uuid.UUID) (*BidDecisionAccess, error) {
+	access := &BidDecisionAccess{}
+	for _, id := range slices.Compact([]string{employeeId, voterId}) {
+		isParticipant, err := p.CanOnBid(ctx, id, bid, ManageBids)
+		if err != nil {
+			return nil, err
+		}
+		if isParticipant {
+			access.IsParticipant = true
+
+			return access, nil
+		}
+	}
+
+	canDecide, err := p.Can(ctx, voterId, tenderOrganizationId, DecideBids)
+	if err != nil {
+		return nil, err
+	}
+	access.CanDecide = canDecide
+
+	return access, nil
+}
+
+type BidProposalAccess struct {
+	IsTenderMember bool        // автор состоит в организации тендера и не может предлагать ей
+	Organizations  []uuid.UUID // организации, от имени которых автор подает предложения
+}
+
+func (p *Policy) BidProposal(ctx context.Context, authorId string, tenderOrganizationId uuid.UUID) (*BidProposalAccess, error) {
+	isMember, err := p.IsMember(ctx, authorId, tenderOrganizationId)
+	if err != nil {
+		return nil, err
+	}
+	if isMember {
+		return &BidProposalAccess{IsTenderMember: true}, nil
+	}
+
+	organizationIds, err := p.OrganizationsWith(ctx, authorId, ManageBids)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BidProposalAccess{Organizations: organizationIds}, nil
+}
+
+// Заказчик - те, кто управляет тендерами организации тендера, поставщик - автор предложения
+// и те, кто управляет предложениями его организации
+type ContractAccess struct {
+	IsCustomer bool
+	IsSupplier bool
+	CanRead    bool // стороны контракта и те, кто читает тендеры заказчика или предложения поставщика
+}
+
+func (p *Policy) ContractAccess(ctx context.Context, employeeId string, contract *entity.Contract) (*ContractAccess, error) {
+	access := &ContractAccess{IsSupplier: contract.SupplierId.String() == employeeId}
+
+	var err error
+	if access.IsCustomer, err = p.Can(ctx, employeeId, contract.CustomerOrganizationId, ManageTenders); err != nil {
+		return nil, err
+	}
+	if !access.IsSupplier && contract.SupplierOrganizationId != nil {
+		if access.IsSupplier, err = p.Can(ctx, employeeId, *contract.SupplierOrganizationId, ManageBids); err != nil {
+			return nil, err
+		}
+	}
+
+	access.CanRead = access.IsCustomer || access.IsSupplier
+	if !access.CanRead {
+		if access.CanRead, err = p.Can(ctx, employeeId, contract.CustomerOrganizationId, ReadTenders); err != nil {
+			return nil, err
+		}
+	}
+	if !access.CanRead && contract.SupplierOrganizationId != nil {
+		if access.CanRead, err = p.Can(ctx, employeeId, *contract.SupplierOrganizationId, ReadBids); err != nil {
+			return nil, err
+		}
+	}
+
+	return access, nil
+}
+
+// Организации, чьи контракты видит сотрудник: как заказчика и как поставщика
+func (p *Policy) ContractOrganizations(ctx context.Context, employeeId string) ([]uuid.UUID, []uuid.UUID, error) {
+	customerOrganizationIds, err := p.OrganizationsWith(ctx, employeeId, ReadTenders)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	supplierOrganizationIds, err := p.OrganizationsWith(ctx, employeeId, ReadBids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return customerOrganizationIds, supplierOrganizationIds, nil
+}
+
+type ApprovalDelegationAccess struct {
+	CanDelegate      bool // делегирует только тот, у кого есть право голосовать
+	DelegateIsMember bool
+}
+
+func (p *Policy) ApprovalDelegation(ctx context.Context, employeeId string, delegateId string, organizationId uuid.UUID) (*ApprovalDelegationAccess, error) {
+	canDecide, err := p.Can(ctx, employeeId, organizationId, DecideBids)
+	if err != nil {
+		return nil, err
+	}
+	if !canDecide {
+		return &ApprovalDelegationAccess{}, nil
+	}
+
+	isMember, err := p.IsMember(ctx, delegateId, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApprovalDelegationAccess{CanDelegate: true, DelegateIsMember: isMember}, nil
+}
+
+// Организации, в которых сотрудник состоит: ответственным или с назначенной ролью
+func (p *Policy) Organizations(ctx context.Context, employeeId string) ([]uuid.UUID, error) {
+	employeeUuid, err := uuid.Parse(employeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := p.employeeRepo.GetEmployeeMemberships(ctx, employeeUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationIds := make([]uuid.UUID, 0, len(memberships))
+	for _, membership := range memberships {
+		organizationIds = append(organizationIds, membership.OrganizationId)
+	}
+
+	return organizationIds, nil
+}
+
+// Организации сотрудника, в которых у него есть разрешение
+func (p *Policy) OrganizationsWith(ctx context.Context, employeeId string, permission Permission) ([]uuid.UUID, error) {
+	employeeUuid, err := uuid.Parse(employeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := p.employeeRepo.GetEmployeeMemberships(ctx, employeeUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationIds := make([]uuid.UUID, 0, len(memberships))
+	for _, membership := range memberships {
+		can, err := p.Can(ctx, employeeId, membership.OrganizationId, permission)
+		if err != nil {
+			return nil, err
+		}
+		if can {
+			organizationIds = append(organizationIds, membership.OrganizationId)
+		}
+	}
+
+	return organizationIds, nil
+}
+
+// Сотрудники организации, у которых в ней есть разрешение: ответственные и те, кому назначены роли.
+// Администратор сервиса сюда не попадает - он не состоит в организации
+func (p *Policy) EmployeesWith(ctx context.Context, organizationId uuid.UUID, permission Permission) ([]uuid.UUID, error) {
+	candidates, err := p.employeeRepo.GetOrganizationResponsibleIds(ctx, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := p.roleRepo.GetOrganizationRoleAssignments(ctx, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	for _, assignment := range assignments {
+		if !slices.Contains(candidates, assignment.EmployeeId) {
+			candidates = append(candidates, assignment.EmployeeId)
+		}
+	}
+
+	employeeIds := make([]uuid.UUID, 0, len(candidates))
+	for _, employeeId := range candidates {
+		roles, err := p.employeeRoles(ctx, employeeId.String(), organizationId)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range roles {
+			if slices.Contains(rolePermissions[role], permission) {
+				employeeIds = append(employeeIds, employeeId)
+
+				break
+			}
+		}
+	}
+
+	return employeeIds, nil
+}
+
+// Сужает список организаций до выбранной; пустой выбор означает все организации.
+// false - выбранной организации нет в списке
+func selectOrganization(organizationIds []uuid.UUID, organizationId string) ([]uuid.UUID, bool) {
+	if organizationId == "" {
+		return organizationIds, true
+	}
+
+	selected, err := uuid.Parse(organizationId)
+	if err != nil || !slices.Contains(organizationIds, selected) {
+		return nil, false
+	}
+
+	return []uuid.UUID{selected}, true
+}
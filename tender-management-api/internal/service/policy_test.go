@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"tender-management-api/internal/common"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type policyRoleRepo struct {
+	repo.Role
+	roles map[uuid.UUID]map[uuid.UUID][]string
+}
+
+func (r *policyRoleRepo) GetEmployeeRoles(_ context.Context, employeeId uuid.UUID, organizationId uuid.UUID) ([]string, error) {
+	return r.roles[employeeId][organizationId], nil
+}
+
+func (r *policyRoleRepo) GetOrganizationRoleAssignments(_ context.Context, organizationId uuid.UUID) ([]entity.RoleAssignment, error) {
+	assignments := make([]entity.RoleAssignment, 0)
+	for employeeId, organizations := range r.roles {
+		for _, role := range organizations[organizationId] {
+			assignments = append(assignments, entity.RoleAssignment{OrganizationId: organizationId, EmployeeId: employeeId, Role: role})
+		}
+	}
+
+	return assignments, nil
+}
+
+type policyEmployeeRepo struct {
+	repo.Employee
+	admins       map[string]bool
+	responsibles map[uuid.UUID][]uuid.UUID
+}
+
+func (r *policyEmployeeRepo) IsEmployeeResponsible(_ context.Context, employeeId string, organizationId uuid.UUID) (bool, error) {
+	return slices.Contains(r.responsibles[organizationId], uuid.MustParse(employeeId)), nil
+}
+
+func (r *policyEmployeeRepo) GetOrganizationResponsibleIds(_ context.Context, organizationId uuid.UUID) ([]uuid.UUID, error) {
+	return r.responsibles[organizationId], nil
+}
+
+func (r *policyEmployeeRepo) IsEmployeeAdmin(_ context.Context, employeeId string) (bool, error) {
+	return r.admins[employeeId], nil
+}
+
+type policyFixture struct {
+	policy       *Policy
+	organization uuid.UUID
+	admin        string
+	orgAdmin     string
+	manager      string
+	approver     string
+	bidder       string
+}
+
+func newPolicyFixture() *policyFixture {
+	f := &policyFixture{
+		organization: uuid.New(),
+		admin:        uuid.NewString(),
+		orgAdmin:     uuid.NewString(),
+		manager:      uuid.NewString(),
+		approver:     uuid.NewString(),
+		bidder:       uuid.NewString(),
+	}
+
+	roles := map[uuid.UUID]map[uuid.UUID][]string{
+		uuid.MustParse(f.orgAdmin): {f.organization: {common.OrgAdminRole}},
+		uuid.MustParse(f.manager):  {f.organization: {common.TenderManagerRole}},
+		uuid.MustParse(f.approver): {f.organization: {common.ApproverRole}},
+	}
+	f.policy = &Policy{
+		roleRepo:     &policyRoleRepo{roles: roles},
+		employeeRepo: &policyEmployeeRepo{admins: map[string]bool{f.admin: true}},
+		tenderRepo:   &emailTenderRepo{tender: &entity.Tender{Id: uuid.New(), OrganizationId: f.organization}},
+	}
+
+	return f
+}
+
+func TestPolicyGrantsAdminPermissionsOnly(t *testing.T) {
+	f := newPolicyFixture()
+	ctx := context.Background()
+
+	cases := []struct {
+		name       string
+		employeeId string
+		permission Permission
+		want       bool
+	}{
+		{"admin moderates reviews", f.admin, ModerateReviews, true},
+		{"admin reads relations", f.admin, ReadRelations, true},
+		{"admin manages roles", f.admin, ManageRoles, true},
+		{"admin does not decide bids", f.admin, DecideBids, false},
+		{"admin does not manage tenders", f.admin, ManageTenders, false},
+		{"manager moderates reviews", f.manager, ModerateReviews, true},
+		{"organization admin moderates reviews", f.orgAdmin, ModerateReviews, true},
+		{"organization admin does not decide bids", f.orgAdmin, DecideBids, false},
+		{"approver does not moderate reviews", f.approver, ModerateReviews, false},
+		{"outsider reads nothing", f.bidder, ReadRelations, false},
+	}
+	for _, c := range cases {
+		can, err := f.policy.Can(ctx, c.employeeId, f.organization, c.permission)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if can != c.want {
+			t.Errorf("%s: Can = %v, want %v", c.name, can, c.want)
+		}
+	}
+
+	for _, permission := range []Permission{ManageCatalog, ManageRelations} {
+		if can, _ := f.policy.CanOnService(ctx, f.admin, permission); !can {
+			t.Errorf("admin has no %s", permission)
+		}
+		if can, _ := f.policy.CanOnService(ctx, f.manager, permission); can {
+			t.Errorf("tender manager has %s", permission)
+		}
+	}
+	if can, _ := f.policy.CanOnService(ctx, f.admin, ReadTenders); can {
+		t.Error("organization permission is granted on the whole service")
+	}
+}
+
+func TestPolicyBidDecision(t *testing.T) {
+	f := newPolicyFixture()
+	ctx := context.Background()
+	bid := &entity.Bid{Id: uuid.New(), AuthorType: common.UserAuthor, AuthorId: uuid.MustParse(f.bidder)}
+
+	access, err := f.policy.BidDecision(ctx, f.approver, f.approver, bid, f.organization)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if access.IsParticipant || !access.CanDecide {
+		t.Errorf("approver: got %+v", access)
+	}
+
+	// голосование за автора по делегированию не дает ему голосовать по своему предложению
+	access, err = f.policy.BidDecision(ctx, f.approver, f.bidder, bid, f.organization)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !access.IsParticipant {
+		t.Errorf("vote on behalf of the author: got %+v", access)
+	}
+
+	access, err = f.policy.BidDecision(ctx, f.manager, f.manager, bid, f.organization)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if access.IsParticipant || access.CanDecide {
+		t.Errorf("tender manager: got %+v", access)
+	}
+}
+
+// Ответственным с назначенными ролями роли по умолчанию не достаются, а голосующий по роли
+// не обязан быть ответственным: кворум и рассылки строятся по тем, кто действительно голосует
+func TestPolicyEmployeesWithDecideBids(t *testing.T) {
+	f := newPolicyFixture()
+	legacy, auditor := uuid.New(), uuid.New()
+	f.policy.employeeRepo = &policyEmployeeRepo{responsibles: map[uuid.UUID][]uuid.UUID{
+		f.organization: {legacy, auditor, uuid.MustParse(f.manager)},
+	}}
+	f.policy.roleRepo.(*policyRoleRepo).roles[auditor] = map[uuid.UUID][]string{f.organization: {common.AuditorRole}}
+
+	voters, err := f.policy.EmployeesWith(context.Background(), f.organization, DecideBids)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slices.SortFunc(voters, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+	want := []uuid.UUID{legacy, uuid.MustParse(f.approver)}
+	slices.SortFunc(want, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+	if !slices.Equal(voters, want) {
+		t.Errorf("voters = %v, want %v", voters, want)
+	}
+}
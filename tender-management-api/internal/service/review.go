@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"tender-management-api/internal/common"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo/repo_errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func mapReputation(r *entity.Reputation) *entity.ReputationOutputModel {
+	round := func(v float64) float64 { return math.Round(v*100) / 100 }
+
+	return &entity.ReputationOutputModel{
+		Score:         round((r.Quality + r.Timeliness + r.Communication) / 3),
+		Quality:       round(r.Quality),
+		Timeliness:    round(r.Timeliness),
+		Communication: round(r.Communication),
+		ReviewsCount:  r.ReviewsCount,
+	}
+}
+
+func reputationsById(reputations []entity.Reputation) map[uuid.UUID]*entity.ReputationOutputModel {
+	byId := make(map[uuid.UUID]*entity.ReputationOutputModel, len(reputations))
+	for i := range reputations {
+		byId[reputations[i].SubjectId] = mapReputation(&reputations[i])
+	}
+
+	return byId
+}
+
+// Ответственным за тендер рядом с предложением показывается репутация автора,
+// а для предложений от имени организации - еще и репутация организации
+func (s *BidService) withReputations(ctx context.Context, bids []entity.Bid) ([]entity.BidOutputModel, error) {
+	authorIds := make([]uuid.UUID, 0, len(bids))
+	organizationIds := make([]uuid.UUID, 0)
+	bidOrganizations := make(map[uuid.UUID]uuid.UUID)
+	for _, bid := range bids {
+		authorIds = append(authorIds, bid.AuthorId)
+		if bid.AuthorType != common.OrganizationAuthor || bid.OrganizationId == nil {
+			continue
+		}
+
+		bidOrganizations[bid.Id] = *bid.OrganizationId
+		organizationIds = append(organizationIds, *bid.OrganizationId)
+	}
+
+	employeeReputations, err := s.reviewRepo.GetEmployeeReputations(ctx, authorIds)
+	if err != nil {
+		return nil, err
+	}
+	organizationReputations, err := s.reviewRepo.GetOrganizationReputations(ctx, organizationIds)
+	if err != nil {
+		return nil, err
+	}
+
+	byEmployee, byOrganization := reputationsById(employeeReputations), reputationsById(organizationReputations)
+	models := mapBids(bids)
+	for i, bid := range bids {
+		models[i].AuthorReputation = byEmployee[bid.AuthorId]
+		if organizationId, ok := bidOrganizations[bid.Id]; ok {
+			models[i].OrganizationReputation = byOrganization[organizationId]
+		}
+	}
+
+	return models, nil
+}
+
+// Отзыв можно править в течение двух суток после его создания
+const ReviewEditWindow = 48 * time.Hour
+
+// Модерируют отзывы те, кто пишет отзывы от имени организации тендера, и администраторы сервиса
+func (s *BidService) isReviewModerator(ctx context.Context, employeeId string, bid *entity.Bid) (bool, error) {
+	tender, err := s.tenderRepo.GetTenderById(ctx, bid.TenderId.String())
+	if err != nil {
+		return false, err
+	}
+
+	return s.policy.Can(ctx, employeeId, tender.OrganizationId, ModerateReviews)
+}
+
+type reviewAccess struct {
+	review      *entity.Review
+	employeeId  string
+	isModerator bool
+}
+
+func (a *reviewAccess) isAuthor() bool {
+	return a.review.AuthorId.String() == a.employeeId
+}
+
+func (a *reviewAccess) isReceiver() bool {
+	return a.review.ReceiverId.String() == a.employeeId
+}
+
+func (s *BidService) getReviewAccess(ctx context.Context, reviewId string, username string) (*reviewAccess, error) {
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrEmployeeNotFound
+		}
+
+		return nil, err
+	}
+
+	review, err := s.reviewRepo.GetReviewById(ctx, reviewId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrReviewNotFound
+		}
+
+		return nil, err
+	}
+
+	bid, err := s.bidRepo.GetBidById(ctx, review.BidId)
+	if err != nil {
+		return nil, err
+	}
+
+	isModerator, err := s.isReviewModerator(ctx, employeeId, bid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reviewAccess{review: review, employeeId: employeeId, isModerator: isModerator}, nil
+}
+
+// Автор предложения видит отзывы на него, кроме скрытых, модераторы видят все
+func (s *BidService) GetBidReviews(ctx context.Context, bidId string, username string) ([]entity.ReviewOutputModel, error) {
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrEmployeeNotFound
+		}
+
+		return nil, err
+	}
+
+	bid, err := s.bidRepo.GetBidById(ctx, bidId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrBidNotFound
+		}
+
+		return nil, err
+	}
+
+	access, err := s.policy.BidAccess(ctx, employeeId, bid)
+	if err != nil {
+		return nil, err
+	}
+	if !access.IsModerator && !access.CanRead {
+		return nil, ErrUserHasNoAccessToBid
+	}
+
+	reviews, err := s.reviewRepo.GetBidReviews(ctx, bid.Id, access.IsModerator)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReviews(reviews), nil
+}
+
+func (s *BidService) EditReview(ctx context.Context, reviewId string, username string, input *entity.EditReviewInput) (*entity.ReviewOutputModel, error) {
+	access, err := s.getReviewAccess(ctx, reviewId, username)
+	if err != nil {
+		return nil, err
+	}
+	if !access.isAuthor() {
+		return nil, ErrUserHasNoAccessToReview
+	}
+	if access.review.HiddenAt != "" {
+		return nil, ErrReviewIsHidden
+	}
+
+	if err = s.reviewRepo.EditReview(ctx, access.review.Id, input, ReviewEditWindow); err != nil {
+		if errors.Is(err, repo_errors.ErrExpired) {
+			return nil, ErrReviewEditWindowExpired
+		}
+
+		return nil, err
+	}
+
+	review, err := s.reviewRepo.GetReviewById(ctx, reviewId)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReview(*review), nil
+}
+
+// История включает все предыдущие версии и текущую последней
+func (s *BidService) GetReviewHistory(ctx context.Context, reviewId string, username string) ([]entity.ReviewVersionOutputModel, error) {
+	access, err := s.getReviewAccess(ctx, reviewId, username)
+	if err != nil {
+		return nil, err
+	}
+
+	hidden := access.review.HiddenAt != ""
+	if !access.isModerator && !access.isAuthor() && !(access.isReceiver() && !hidden) {
+		return nil, ErrUserHasNoAccessToReview
+	}
+
+	versions, err := s.reviewRepo.GetReviewVersions(ctx, access.review.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	current := access.review.CreatedAt
+	if access.review.UpdatedAt != "" {
+		current = access.review.UpdatedAt
+	}
+	versions = append(versions, entity.ReviewVersion{
+		Version:             access.review.Version,
+		Description:         access.review.Description,
+		QualityRating:       access.review.QualityRating,
+		TimelinessRating:    access.review.TimelinessRating,
+		CommunicationRating: access.review.CommunicationRating,
+		CreatedAt:           current,
+	})
+
+	return mapReviewVersions(versions), nil
+}
+
+// Автор предложения может один раз ответить на отзыв о нем
+func (s *BidService) ReplyToReview(ctx context.Context, reviewId string, username string, reply string) (*entity.ReviewOutputModel, error) {
+	access, err := s.getReviewAccess(ctx, reviewId, username)
+	if err != nil {
+		return nil, err
+	}
+	if !access.isReceiver() {
+		return nil, ErrUserHasNoAccessToReview
+	}
+	if access.review.HiddenAt != "" {
+		return nil, ErrReviewIsHidden
+	}
+
+	if err = s.reviewRepo.ReplyToReview(ctx, access.review.Id, reply); err != nil {
+		if errors.Is(err, repo_errors.ErrAlreadyExists) {
+			return nil, ErrReviewAlreadyReplied
+		}
+
+		return nil, err
+	}
+
+	review, err := s.reviewRepo.GetReviewById(ctx, reviewId)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReview(*review), nil
+}
+
+// Скрытый отзыв остается в базе с причиной и модератором, но пропадает из выдачи и репутации
+func (s *BidService) HideReview(ctx context.Context, reviewId string, username string, reason string) (*entity.ReviewOutputModel, error) {
+	access, err := s.getReviewAccess(ctx, reviewId, username)
+	if err != nil {
+		return nil, err
+	}
+	if !access.isModerator {
+		return nil, ErrUserHasNoAccessToReview
+	}
+
+	moderatorId, err := uuid.Parse(access.employeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.reviewRepo.HideReview(ctx, access.review.Id, moderatorId, reason); err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrReviewNotFound
+		}
+
+		return nil, err
+	}
+
+	review, err := s.reviewRepo.GetReviewById(ctx, reviewId)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapReview(*review), nil
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+	"tender-management-api/internal/repo/repo_errors"
+
+	"github.com/google/uuid"
+)
+
+func mapRoleAssignment(a *entity.RoleAssignment) *entity.RoleAssignmentOutputModel {
+	model := &entity.RoleAssignmentOutputModel{
+		Id:               a.Id.String(),
+		OrganizationId:   a.OrganizationId.String(),
+		EmployeeId:       a.EmployeeId.String(),
+		EmployeeUsername: a.EmployeeUsername,
+		Role:             a.Role,
+		CreatedAt:        a.CreatedAt,
+	}
+	if a.GrantedBy.Valid {
+		model.GrantedBy = a.GrantedBy.UUID.String()
+	}
+
+	return model
+}
+
+func mapRoleAssignments(assignments []entity.RoleAssignment) []entity.RoleAssignmentOutputModel {
+	s := make([]entity.RoleAssignmentOutputModel, 0)
+	for _, a := range assignments {
+		s = append(s, *mapRoleAssignment(&a))
+	}
+
+	return s
+}
+
+type RoleService struct {
+	roleRepo     repo.Role
+	employeeRepo repo.Employee
+	policy       *Policy
+}
+
+func NewRoleService(repos *repo.Repositories, policy *Policy) *RoleService {
+	return &RoleService{
+		roleRepo:     repos.Role,
+		employeeRepo: repos.Employee,
+		policy:       policy,
+	}
+}
+
+// Роли организации назначает ее администратор, а администратор сервиса - любой организации,
+// чтобы было кому назначить первого администратора
+func (s *RoleService) checkPermission(ctx context.Context, username string, organizationId string, permission Permission) (string, uuid.UUID, error) {
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return "", uuid.Nil, ErrEmployeeNotFound
+		}
+
+		return "", uuid.Nil, err
+	}
+
+	organizationExists, err := s.employeeRepo.DoesOrganizationExistById(ctx, organizationId)
+	if err != nil {
+		return "", uuid.Nil, err
+	}
+	if !organizationExists {
+		return "", uuid.Nil, ErrOrganizationNotFound
+	}
+
+	organizationUuid, _ := uuid.Parse(organizationId)
+	allowed, err := s.policy.Can(ctx, employeeId, organizationUuid, permission)
+	if err != nil {
+		return "", uuid.Nil, err
+	}
+	if !allowed {
+		return "", uuid.Nil, ErrUserCanNotManageRoles
+	}
+
+	return employeeId, organizationUuid, nil
+}
+
+// Возвращаются только назначенные роли. Ответственные без них действуют с ролями по умолчанию
+func (s *RoleService) GetOrganizationRoles(ctx context.Context, organizationId string, username string) ([]entity.RoleAssignmentOutputModel, error) {
+	_, organizationUuid, err := s.checkPermission(ctx, username, organizationId, ReadRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.roleRepo.GetOrganizationRoleAssignments(ctx, organizationUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapRoleAssignments(assignments), nil
+}
+
+func (s *RoleService) AssignRole(ctx context.Context, organizationId string, username string, assigneeUsername string, role string) (*entity.RoleAssignmentOutputModel, error) {
+	employeeId, organizationUuid, err := s.checkPermission(ctx, username, organizationId, ManageRoles)
+	if err != nil {
+		return nil, err
+	}
+
+	assigneeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, assigneeUsername)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrAssigneeNotFound
+		}
+
+		return nil, err
+	}
+
+	id, err := s.roleRepo.CreateRoleAssignment(ctx, &entity.CreateRoleAssignmentInput{
+		OrganizationId: organizationUuid,
+		EmployeeId:     uuid.MustParse(assigneeId),
+		Role:           role,
+		GrantedBy:      uuid.MustParse(employeeId),
+	})
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrAlreadyExists) {
+			return nil, ErrRoleAlreadyAssigned
+		}
+
+		return nil, err
+	}
+
+	assignment, err := s.roleRepo.GetRoleAssignmentById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapRoleAssignment(assignment), nil
+}
+
+func (s *RoleService) RevokeRole(ctx context.Context, organizationId string, assignmentId string, username string) error {
+	_, organizationUuid, err := s.checkPermission(ctx, username, organizationId, ManageRoles)
+	if err != nil {
+		return err
+	}
+
+	assignmentUuid, err := uuid.Parse(assignmentId)
+	if err != nil {
+		return ErrRoleAssignmentNotFound
+	}
+
+	assignment, err := s.roleRepo.GetRoleAssignmentById(ctx, assignmentUuid)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return ErrRoleAssignmentNotFound
+		}
+
+		return err
+	}
+	if assignment.OrganizationId != organizationUuid {
+		return ErrRoleAssignmentNotFound
+	}
+
+	if err = s.roleRepo.DeleteRoleAssignment(ctx, assignmentUuid); err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return ErrRoleAssignmentNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Организации сотрудника с действующими в них ролями, включая роли ответственного по умолчанию
+func (s *RoleService) GetEmployeeOrganizations(ctx context.Context, username string) ([]entity.MembershipOutputModel, error) {
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrEmployeeNotFound
+		}
+
+		return nil, err
+	}
+
+	memberships, err := s.employeeRepo.GetEmployeeMemberships(ctx, uuid.MustParse(employeeId))
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]entity.MembershipOutputModel, 0, len(memberships))
+	for _, membership := range memberships {
+		roles, err := s.policy.employeeRoles(ctx, employeeId, membership.OrganizationId)
+		if err != nil {
+			return nil, err
+		}
+
+		models = append(models, entity.MembershipOutputModel{
+			OrganizationId:   membership.OrganizationId.String(),
+			OrganizationName: membership.OrganizationName,
+			Roles:            roles,
+		})
+	}
+
+	return models, nil
+}
@@ -4,6 +4,12 @@ import (
 	"context"
 	"tender-management-api/internal/entity"
 	"tender-management-api/internal/repo"
+	"tender-management-api/pkg/blobstore"
+	"tender-management-api/pkg/mailer"
+	"tender-management-api/pkg/pubsub"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type Diagnostics interface {
@@ -12,15 +18,38 @@ type Diagnostics interface {
 
 type Tender interface {
 	CreateTender(ctx context.Context, input *entity.CreateTenderInput) (*entity.TenderOutputModel, error)
-	EditTenderById(ctx context.Context, tenderId string, username, name, description, serviceType string) (*entity.TenderOutputModel, error)
+	EditTenderById(ctx context.Context, tenderId string, username string, input *entity.EditTenderInput) (*entity.TenderOutputModel, error)
 
 	GetTenderStatusById(ctx context.Context, tenderId string, username string, usernamePassed bool) (string, error)
 	UpdateTenderStatusById(ctx context.Context, tenderId string, newStatus, username string) (*entity.TenderOutputModel, error)
 
-	GetUserTenders(ctx context.Context, username string, usernamePassed bool, pg *entity.PaginationInput) ([]entity.TenderOutputModel, error)
-	GetPublishedTenders(ctx context.Context, serviceTypes []string, pg *entity.PaginationInput) ([]entity.TenderOutputModel, error)
+	GetUserTenders(ctx context.Context, username string, usernamePassed bool, organizationId string, filter *entity.TenderFilter, pg *entity.PaginationInput) ([]entity.TenderOutputModel, error)
+	GetPublishedTenders(ctx context.Context, filter *entity.TenderFilter, pg *entity.PaginationInput) ([]entity.TenderOutputModel, error)
 
 	RollbackTenderVersion(ctx context.Context, tenderId string, version int, username string) (*entity.TenderOutputModel, error)
+
+	CloneTender(ctx context.Context, tenderId string, username string) (*entity.TenderOutputModel, error)
+}
+
+type TenderTemplates interface {
+	CreateTenderTemplate(ctx context.Context, input *entity.CreateTenderTemplateInput) (*entity.TenderTemplateOutputModel, error)
+	GetTenderTemplates(ctx context.Context, organizationId string, username string, pg *entity.PaginationInput) ([]entity.TenderTemplateOutputModel, error)
+	DeleteTenderTemplate(ctx context.Context, templateId string, username string) error
+
+	CreateTenderFromTemplate(ctx context.Context, input *entity.CreateTenderFromTemplateInput) (*entity.TenderOutputModel, error)
+}
+
+type TenderAttributes interface {
+	GetTenderAttributeDefinitions(ctx context.Context, organizationId string, username string) ([]entity.TenderAttributeDefinitionOutputModel, error)
+	SetTenderAttributeDefinition(ctx context.Context, input *entity.TenderAttributeDefinitionInput) ([]entity.TenderAttributeDefinitionOutputModel, error)
+	DeleteTenderAttributeDefinition(ctx context.Context, organizationId string, code string, username string) error
+}
+
+type ServiceTypes interface {
+	GetServiceTypes(ctx context.Context) ([]entity.ServiceTypeOutputModel, error)
+	CreateServiceType(ctx context.Context, input *entity.ServiceTypeInput) (*entity.ServiceTypeOutputModel, error)
+	EditServiceType(ctx context.Context, code string, input *entity.ServiceTypeInput) (*entity.ServiceTypeOutputModel, error)
+	DeleteServiceType(ctx context.Context, code string, username string) error
 }
 
 type Bid interface {
@@ -30,28 +59,148 @@ type Bid interface {
 	GetBidStatusById(ctx context.Context, bidId string, username string) (string, error)
 	UpdateBidStatusById(ctx context.Context, bidId string, newStatus, username string) (*entity.BidOutputModel, error)
 
-	GetUserBids(ctx context.Context, username string, pg *entity.PaginationInput) ([]entity.BidOutputModel, error)
+	GetUserBids(ctx context.Context, username string, organizationId string, pg *entity.PaginationInput) ([]entity.BidOutputModel, error)
 	GetBidsForTenderById(ctx context.Context, tenderId string, pg *entity.PaginationInput, username string) ([]entity.BidOutputModel, error)
 
-	SubmitBidDecision(ctx context.Context, bidId string, decision, username string) (*entity.BidOutputModel, error)
+	SubmitBidDecision(ctx context.Context, bidId string, decision, username string, onBehalfOf string) (*entity.BidOutputModel, error)
+	GetBidVotes(ctx context.Context, bidId string, username string) ([]entity.BidVoteOutputModel, error)
 
 	RollbackBidVersion(ctx context.Context, bidId string, version int, username string) (*entity.BidOutputModel, error)
+	GetBidVersions(ctx context.Context, bidId string, username string) ([]entity.BidVersionOutputModel, error)
+
+	WithdrawBid(ctx context.Context, bidId string, username string, reason string) (*entity.BidOutputModel, error)
+	ConsentBidWithdrawal(ctx context.Context, bidId string, username string) (*entity.BidOutputModel, error)
+	ResubmitBid(ctx context.Context, bidId string, username string, name string, description string) (*entity.BidOutputModel, error)
 
-	GetReviewsOnBidAuthorBids(ctx context.Context, tenderId string, authorUsername string, requesterUsername string, pg *entity.PaginationInput) ([]entity.ReviewOutputModel, error)
+	GetReviewsOnBidAuthorBids(ctx context.Context, tenderId string, authorUsername string, requesterUsername string, filter *entity.ReviewFilter, pg *entity.PaginationInput) ([]entity.ReviewOutputModel, error)
 
-	SubmitBidFeedback(ctx context.Context, bidId string, username string, content string) (*entity.BidOutputModel, error)
+	SubmitBidFeedback(ctx context.Context, username string, input *entity.CreateReviewInput) (*entity.BidOutputModel, error)
+
+	GetBidReviews(ctx context.Context, bidId string, username string) ([]entity.ReviewOutputModel, error)
+	EditReview(ctx context.Context, reviewId string, username string, input *entity.EditReviewInput) (*entity.ReviewOutputModel, error)
+	GetReviewHistory(ctx context.Context, reviewId string, username string) ([]entity.ReviewVersionOutputModel, error)
+	ReplyToReview(ctx context.Context, reviewId string, username string, reply string) (*entity.ReviewOutputModel, error)
+	HideReview(ctx context.Context, reviewId string, username string, reason string) (*entity.ReviewOutputModel, error)
+}
+
+type Contracts interface {
+	GetContract(ctx context.Context, contractId string, username string) (*entity.ContractOutputModel, error)
+	GetUserContracts(ctx context.Context, username string, filter *entity.ContractFilter, pg *entity.PaginationInput) ([]entity.ContractOutputModel, error)
+	EditContract(ctx context.Context, contractId string, username string, input *entity.EditContractInput) (*entity.ContractOutputModel, error)
+	SignContract(ctx context.Context, contractId string, username string) (*entity.ContractOutputModel, error)
+	UpdateContractStatus(ctx context.Context, contractId string, username string, status string, reason string) (*entity.ContractOutputModel, error)
+}
+
+type Signing interface {
+	RegisterKey(ctx context.Context, username string, publicKey string) (*entity.EmployeeKeyOutputModel, error)
+	GetKeys(ctx context.Context, username string) ([]entity.EmployeeKeyOutputModel, error)
+	RevokeKey(ctx context.Context, keyId string, username string) (*entity.EmployeeKeyOutputModel, error)
+
+	GetBidVersionPayload(ctx context.Context, bidId string, version int, username string) (*entity.BidVersionPayloadOutputModel, error)
+	SignBidVersion(ctx context.Context, bidId string, version int, username string, keyId string, signature string) (*entity.BidSignatureVerificationOutputModel, error)
+	VerifyBidVersion(ctx context.Context, bidId string, version int, username string) (*entity.BidSignatureVerificationOutputModel, error)
+}
+
+type OrganizationRelations interface {
+	CreateOrganizationRelation(ctx context.Context, username string, organizationId string, relatedOrganizationId string, relationType string) (*entity.OrganizationRelationOutputModel, error)
+	GetOrganizationRelations(ctx context.Context, organizationId string, username string) ([]entity.OrganizationRelationOutputModel, error)
+	DeleteOrganizationRelation(ctx context.Context, relationId string, username string) error
+	GetTenderConflictReport(ctx context.Context, tenderId string, username string) (*entity.TenderConflictReportOutputModel, error)
+}
+
+type Roles interface {
+	GetOrganizationRoles(ctx context.Context, organizationId string, username string) ([]entity.RoleAssignmentOutputModel, error)
+	AssignRole(ctx context.Context, organizationId string, username string, assigneeUsername string, role string) (*entity.RoleAssignmentOutputModel, error)
+	RevokeRole(ctx context.Context, organizationId string, assignmentId string, username string) error
+
+	GetEmployeeOrganizations(ctx context.Context, username string) ([]entity.MembershipOutputModel, error)
+}
+
+type Delegations interface {
+	CreateApprovalDelegation(ctx context.Context, username string, organizationId string, delegateUsername string, startsOn string, endsOn string) (*entity.ApprovalDelegationOutputModel, error)
+	GetApprovalDelegations(ctx context.Context, username string) ([]entity.ApprovalDelegationOutputModel, error)
+	DeleteApprovalDelegation(ctx context.Context, delegationId string, username string) error
+}
+
+type Tenants interface {
+	ResolveTenant(ctx context.Context, principal Principal, tenantId string) (uuid.UUID, error)
+}
+
+type Events interface {
+	Subscribe(ctx context.Context, username string, serviceTypes []string, lastEventId int64) (<-chan entity.EventOutputModel, error)
+}
+
+type Notifications interface {
+	GetNotifications(ctx context.Context, username string, unreadOnly bool, pg *entity.PaginationInput) (*entity.NotificationsOutputModel, error)
+	MarkNotificationRead(ctx context.Context, notificationId string, username string) (*entity.NotificationOutputModel, error)
+	MarkAllNotificationsRead(ctx context.Context, username string) (*entity.NotificationsOutputModel, error)
+
+	GetNotificationPreferences(ctx context.Context, username string) ([]entity.NotificationPreferenceOutputModel, error)
+	SetNotificationPreference(ctx context.Context, username string, eventType string, enabled bool) ([]entity.NotificationPreferenceOutputModel, error)
+}
+
+type Attachments interface {
+	UploadTenderAttachment(ctx context.Context, tenderId string, input *entity.UploadAttachmentInput) (*entity.AttachmentOutputModel, error)
+	GetTenderAttachments(ctx context.Context, tenderId string, username string, usernamePassed bool, version int) ([]entity.AttachmentOutputModel, error)
+	DownloadTenderAttachment(ctx context.Context, tenderId string, attachmentId string, username string, usernamePassed bool) (*entity.AttachmentContent, error)
+
+	UploadBidAttachment(ctx context.Context, bidId string, input *entity.UploadAttachmentInput) (*entity.AttachmentOutputModel, error)
+	GetBidAttachments(ctx context.Context, bidId string, username string, version int) ([]entity.AttachmentOutputModel, error)
+	DownloadBidAttachment(ctx context.Context, bidId string, attachmentId string, username string) (*entity.AttachmentContent, error)
+}
+
+type DeadlineReminder interface {
+	Run(ctx context.Context, interval time.Duration)
 }
 
 type Services struct {
-	Diagnostics Diagnostics
-	Tender      Tender
-	Bid         Bid
+	Diagnostics      Diagnostics
+	Tender           Tender
+	TenderTemplates  TenderTemplates
+	TenderAttributes TenderAttributes
+	ServiceTypes     ServiceTypes
+	Bid              Bid
+	Contracts        Contracts
+	Signing          Signing
+	Relations        OrganizationRelations
+	Roles            Roles
+	Delegations      Delegations
+	Tenants          Tenants
+	Events           Events
+	Notifications    Notifications
+	Attachments      Attachments
+	DeadlineReminder DeadlineReminder
 }
 
-func NewServices(repos *repo.Repositories) *Services {
+// Если mailQueue не передана, письма не отправляются
+func NewServices(repos *repo.Repositories, broker *pubsub.Broker[entity.Event], mailQueue *mailer.Queue, blobStore blobstore.BlobStore) *Services {
+	policy := NewPolicy(repos)
+
+	events := NewEventService(repos, broker, policy)
+	notifications := NewNotificationService(repos, policy)
+	events.AddListener(notifications)
+	if mailQueue != nil {
+		events.AddListener(NewEmailService(repos, mailQueue, policy))
+	}
+
+	tenders := NewTenderService(repos, events, policy)
+
 	return &Services{
-		Tender:      NewTenderService(repos),
-		Bid:         NewBidService(repos),
-		Diagnostics: NewDiagnosticsService(repos),
+		Tender:           tenders,
+		TenderTemplates:  NewTenderTemplateService(repos, tenders, policy),
+		TenderAttributes: NewTenderAttributeService(repos, policy),
+		ServiceTypes:     NewServiceTypeService(repos, policy),
+		Bid:              NewBidService(repos, events, policy),
+		Contracts:        NewContractService(repos, policy),
+		Signing:          NewSigningService(repos, policy),
+		Relations:        NewOrganizationRelationService(repos, policy),
+		Roles:            NewRoleService(repos, policy),
+		Delegations:      NewDelegationService(repos, policy),
+		Tenants:          NewTenantService(repos),
+		Diagnostics:      NewDiagnosticsService(repos),
+		Events:           events,
+		Notifications:    notifications,
+		Attachments:      NewAttachmentService(repos, blobStore, events, policy),
+		DeadlineReminder: NewDeadlineReminderService(repos, events),
 	}
 }
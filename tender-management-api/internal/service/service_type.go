@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+	"tender-management-api/internal/repo/repo_errors"
+)
+
+func checkServiceType(ctx context.Context, serviceTypeRepo repo.ServiceType, code string) error {
+	if _, err := serviceTypeRepo.GetServiceTypeByCode(ctx, code); err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return ErrServiceTypeNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Фильтр по родительской категории должен находить тендеры из всех ее подкатегорий
+func expandServiceTypes(ctx context.Context, serviceTypeRepo repo.ServiceType, codes []string) ([]string, error) {
+	if len(codes) == 0 {
+		return codes, nil
+	}
+
+	expanded, err := serviceTypeRepo.ExpandServiceTypes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range codes {
+		if !slices.Contains(expanded, code) {
+			return nil, ErrServiceTypeNotFound
+		}
+	}
+
+	return expanded, nil
+}
+
+type ServiceTypeService struct {
+	serviceTypeRepo repo.ServiceType
+	employeeRepo    repo.Employee
+	policy          *Policy
+}
+
+func NewServiceTypeService(repos *repo.Repositories, policy *Policy) *ServiceTypeService {
+	return &ServiceTypeService{
+		serviceTypeRepo: repos.ServiceType,
+		employeeRepo:    repos.Employee,
+		policy:          policy,
+	}
+}
+
+// Каталог общий для всех организаций, поэтому менять его могут только администраторы сервиса
+func (s *ServiceTypeService) checkAdmin(ctx context.Context, username string) error {
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return ErrEmployeeNotFound
+		}
+
+		return err
+	}
+
+	canManage, err := s.policy.CanOnService(ctx, employeeId, ManageCatalog)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return ErrUserIsNotAdmin
+	}
+
+	return nil
+}
+
+func (s *ServiceTypeService) getServiceType(ctx context.Context, code string) (*entity.ServiceTypeOutputModel, error) {
+	serviceType, err := s.serviceTypeRepo.GetServiceTypeByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapServiceType(serviceType), nil
+}
+
+func (s *ServiceTypeService) GetServiceTypes(ctx context.Context) ([]entity.ServiceTypeOutputModel, error) {
+	serviceTypes, err := s.serviceTypeRepo.GetServiceTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapServiceTypes(serviceTypes), nil
+}
+
+func (s *ServiceTypeService) CreateServiceType(ctx context.Context, input *entity.ServiceTypeInput) (*entity.ServiceTypeOutputModel, error) {
+	if err := s.checkAdmin(ctx, input.Username); err != nil {
+		return nil, err
+	}
+
+	if input.ParentCode != "" {
+		if err := checkServiceType(ctx, s.serviceTypeRepo, input.ParentCode); err != nil {
+			if errors.Is(err, ErrServiceTypeNotFound) {
+				return nil, ErrParentServiceTypeNotFound
+			}
+
+			return nil, err
+		}
+	}
+
+	err := s.serviceTypeRepo.CreateServiceType(ctx, &entity.ServiceType{
+		Code:       input.Code,
+		Name:       input.Name,
+		ParentCode: input.ParentCode,
+	})
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrAlreadyExists) {
+			return nil, ErrServiceTypeAlreadyExists
+		}
+
+		return nil, err
+	}
+
+	return s.getServiceType(ctx, input.Code)
+}
+
+// Категорию нельзя сделать подкатегорией ее же потомка, иначе в каталоге появится цикл
+func (s *ServiceTypeService) EditServiceType(ctx context.Context, code string, input *entity.ServiceTypeInput) (*entity.ServiceTypeOutputModel, error) {
+	if err := s.checkAdmin(ctx, input.Username); err != nil {
+		return nil, err
+	}
+
+	if err := checkServiceType(ctx, s.serviceTypeRepo, code); err != nil {
+		return nil, err
+	}
+
+	if input.ParentCode != "" {
+		if err := checkServiceType(ctx, s.serviceTypeRepo, input.ParentCode); err != nil {
+			if errors.Is(err, ErrServiceTypeNotFound) {
+				return nil, ErrParentServiceTypeNotFound
+			}
+
+			return nil, err
+		}
+
+		subtree, err := s.serviceTypeRepo.ExpandServiceTypes(ctx, []string{code})
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(subtree, input.ParentCode) {
+			return nil, ErrServiceTypeCycle
+		}
+	}
+
+	if err := s.serviceTypeRepo.UpdateServiceType(ctx, code, input.Name, input.ParentCode); err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrServiceTypeNotFound
+		}
+
+		return nil, err
+	}
+
+	return s.getServiceType(ctx, code)
+}
+
+func (s *ServiceTypeService) DeleteServiceType(ctx context.Context, code string, username string) error {
+	if err := s.checkAdmin(ctx, username); err != nil {
+		return err
+	}
+
+	if err := s.serviceTypeRepo.DeleteServiceType(ctx, code); err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return ErrServiceTypeNotFound
+		}
+		if errors.Is(err, repo_errors.ErrInUse) {
+			return ErrServiceTypeInUse
+		}
+
+		return err
+	}
+
+	return nil
+}
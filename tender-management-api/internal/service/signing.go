@@ -0,0 +1,339 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+	"tender-management-api/internal/repo/repo_errors"
+
+	"github.com/google/uuid"
+)
+
+// Файл вложения в подписываемых данных: имя и SHA-256 содержимого
+type canonicalAttachment struct {
+	Checksum string `json:"checksum"`
+	FileName string `json:"fileName"`
+}
+
+// Каноничное представление версии предложения: JSON без пробелов, ключи по алфавиту,
+// без экранирования HTML-символов. Номер версии входит в подпись, поэтому подпись
+// одной версии нельзя выдать за подпись другой. Вложения входят в подпись отсортированными
+// по имени и контрольной сумме, чтобы подпись покрывала и документы предложения.
+// У версии без вложений ключа attachments нет, поэтому ее подпись совпадает с прежним форматом
+func canonicalBidVersion(bid *entity.Bid, version *entity.BidVersionSignature) ([]byte, error) {
+	payload := map[string]any{
+		"authorId":    bid.AuthorId.String(),
+		"authorType":  bid.AuthorType,
+		"bidId":       bid.Id.String(),
+		"description": version.Description,
+		"name":        version.Name,
+		"tenderId":    bid.TenderId.String(),
+		"version":     version.Version,
+	}
+
+	if len(version.Attachments) > 0 {
+		attachments := make([]canonicalAttachment, 0, len(version.Attachments))
+		for _, a := range version.Attachments {
+			attachments = append(attachments, canonicalAttachment{Checksum: a.Checksum, FileName: a.FileName})
+		}
+		sort.Slice(attachments, func(i, j int) bool {
+			if attachments[i].FileName != attachments[j].FileName {
+				return attachments[i].FileName < attachments[j].FileName
+			}
+
+			return attachments[i].Checksum < attachments[j].Checksum
+		})
+		payload["attachments"] = attachments
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(payload); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func mapEmployeeKey(k *entity.EmployeeKey) *entity.EmployeeKeyOutputModel {
+	return &entity.EmployeeKeyOutputModel{
+		Id:        k.Id.String(),
+		PublicKey: base64.StdEncoding.EncodeToString(k.PublicKey),
+		CreatedAt: k.CreatedAt,
+		RevokedAt: k.RevokedAt,
+	}
+}
+
+func mapEmployeeKeys(keys []entity.EmployeeKey) []entity.EmployeeKeyOutputModel {
+	s := make([]entity.EmployeeKeyOutputModel, 0)
+	for _, k := range keys {
+		s = append(s, *mapEmployeeKey(&k))
+	}
+
+	return s
+}
+
+type SigningService struct {
+	signingRepo    repo.Signing
+	bidRepo        repo.Bid
+	tenderRepo     repo.Tender
+	employeeRepo   repo.Employee
+	attachmentRepo repo.Attachment
+	policy         *Policy
+}
+
+func NewSigningService(repos *repo.Repositories, policy *Policy) *SigningService {
+	return &SigningService{
+		signingRepo:    repos.Signing,
+		bidRepo:        repos.Bid,
+		tenderRepo:     repos.Tender,
+		employeeRepo:   repos.Employee,
+		attachmentRepo: repos.Attachment,
+		policy:         policy,
+	}
+}
+
+func (s *SigningService) getEmployeeUuid(ctx context.Context, username string) (uuid.UUID, error) {
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return uuid.Nil, ErrEmployeeNotFound
+		}
+
+		return uuid.Nil, err
+	}
+
+	return uuid.Parse(employeeId)
+}
+
+func (s *SigningService) RegisterKey(ctx context.Context, username string, publicKey string) (*entity.EmployeeKeyOutputModel, error) {
+	employeeId, err := s.getEmployeeUuid(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(decoded) != ed25519.PublicKeySize {
+		return nil, ErrInvalidPublicKey
+	}
+
+	key, err := s.signingRepo.CreateEmployeeKey(ctx, employeeId, decoded)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrAlreadyExists) {
+			return nil, ErrPublicKeyAlreadyRegistered
+		}
+
+		return nil, err
+	}
+
+	return mapEmployeeKey(key), nil
+}
+
+func (s *SigningService) GetKeys(ctx context.Context, username string) ([]entity.EmployeeKeyOutputModel, error) {
+	employeeId, err := s.getEmployeeUuid(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.signingRepo.GetEmployeeKeys(ctx, employeeId)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapEmployeeKeys(keys), nil
+}
+
+func (s *SigningService) RevokeKey(ctx context.Context, keyId string, username string) (*entity.EmployeeKeyOutputModel, error) {
+	employeeId, err := s.getEmployeeUuid(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.signingRepo.GetEmployeeKeyById(ctx, keyId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrPublicKeyNotFound
+		}
+
+		return nil, err
+	}
+	if key.EmployeeId != employeeId {
+		return nil, ErrPublicKeyNotFound
+	}
+
+	if err = s.signingRepo.RevokeEmployeeKey(ctx, key.Id); err != nil {
+		return nil, err
+	}
+
+	key, err = s.signingRepo.GetEmployeeKeyById(ctx, keyId)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapEmployeeKey(key), nil
+}
+
+// Версию предложения видят те, кто читает предложение, а с ManageBids - только те, кто им управляет
+func (s *SigningService) getBidVersion(ctx context.Context, bidId string, version int, username string, permission Permission) (*entity.Bid, *entity.BidVersionSignature, uuid.UUID, error) {
+	employeeId, err := s.getEmployeeUuid(ctx, username)
+	if err != nil {
+		return nil, nil, uuid.Nil, err
+	}
+
+	bid, err := s.bidRepo.GetBidById(ctx, bidId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, nil, uuid.Nil, ErrBidNotFound
+		}
+
+		return nil, nil, uuid.Nil, err
+	}
+
+	access, err := s.policy.BidAccess(ctx, employeeId.String(), bid)
+	if err != nil {
+		return nil, nil, uuid.Nil, err
+	}
+	if !access.CanRead || permission == ManageBids && !access.CanManage {
+		return nil, nil, uuid.Nil, ErrUserHasNoAccessToBid
+	}
+
+	signature, err := s.loadBidVersion(ctx, bid.Id, version)
+	if err != nil {
+		return nil, nil, uuid.Nil, err
+	}
+
+	return bid, signature, employeeId, nil
+}
+
+func (s *SigningService) loadBidVersion(ctx context.Context, bidId uuid.UUID, version int) (*entity.BidVersionSignature, error) {
+	signature, err := s.signingRepo.GetBidVersionSignature(ctx, bidId, version)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrBidVersionNotFound
+		}
+
+		return nil, err
+	}
+
+	signature.Attachments, err = s.attachmentRepo.GetBidAttachments(ctx, bidId, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return signature, nil
+}
+
+func (s *SigningService) GetBidVersionPayload(ctx context.Context, bidId string, version int, username string) (*entity.BidVersionPayloadOutputModel, error) {
+	bid, signature, _, err := s.getBidVersion(ctx, bidId, version, username, ReadBids)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := canonicalBidVersion(bid, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.BidVersionPayloadOutputModel{BidId: bidId, Version: version, Payload: string(payload)}, nil
+}
+
+// Подпись принимается, только если она проверяется действующим ключом того, кто управляет предложением
+func (s *SigningService) SignBidVersion(ctx context.Context, bidId string, version int, username string, keyId string, signature string) (*entity.BidSignatureVerificationOutputModel, error) {
+	bid, bidVersion, employeeId, err := s.getBidVersion(ctx, bidId, version, username, ManageBids)
+	if err != nil {
+		return nil, err
+	}
+	if bidVersion.Signature != nil {
+		return nil, ErrBidVersionAlreadySigned
+	}
+
+	key, err := s.signingRepo.GetEmployeeKeyById(ctx, keyId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrPublicKeyNotFound
+		}
+
+		return nil, err
+	}
+	if key.EmployeeId != employeeId || key.RevokedAt != "" {
+		return nil, ErrPublicKeyNotFound
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || len(decoded) != ed25519.SignatureSize {
+		return nil, ErrInvalidSignature
+	}
+
+	payload, err := canonicalBidVersion(bid, bidVersion)
+	if err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(key.PublicKey, payload, decoded) {
+		return nil, ErrInvalidSignature
+	}
+
+	if err = s.signingRepo.SignBidVersion(ctx, bid.Id, version, key.Id, decoded); err != nil {
+		if errors.Is(err, repo_errors.ErrAlreadyExists) {
+			return nil, ErrBidVersionAlreadySigned
+		}
+
+		return nil, err
+	}
+
+	bidVersion, err = s.loadBidVersion(ctx, bid.Id, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.verifyBidVersion(ctx, bid, bidVersion, version)
+}
+
+// Подпись, поставленная до отзыва ключа, остается действительной
+func (s *SigningService) VerifyBidVersion(ctx context.Context, bidId string, version int, username string) (*entity.BidSignatureVerificationOutputModel, error) {
+	bid, bidVersion, _, err := s.getBidVersion(ctx, bidId, version, username, ReadBids)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.verifyBidVersion(ctx, bid, bidVersion, version)
+}
+
+// Подпись действительна, только пока ее автор распоряжается предложением
+func (s *SigningService) verifyBidVersion(ctx context.Context, bid *entity.Bid, bidVersion *entity.BidVersionSignature, version int) (*entity.BidSignatureVerificationOutputModel, error) {
+	payload, err := canonicalBidVersion(bid, bidVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.BidSignatureVerificationOutputModel{BidId: bid.Id.String(), Version: version, Payload: string(payload)}
+	if bidVersion.Signature == nil || bidVersion.SigningKeyId == nil {
+		return result, nil
+	}
+
+	key, err := s.signingRepo.GetEmployeeKeyById(ctx, bidVersion.SigningKeyId.String())
+	if err != nil {
+		return nil, err
+	}
+
+	result.Signed = true
+	result.KeyId = key.Id.String()
+	result.PublicKey = base64.StdEncoding.EncodeToString(key.PublicKey)
+	result.KeyRevoked = key.RevokedAt != ""
+	result.Signature = base64.StdEncoding.EncodeToString(bidVersion.Signature)
+	result.SignedAt = bidVersion.SignedAt
+	signerCanManage, err := s.policy.CanOnBid(ctx, key.EmployeeId.String(), bid, ManageBids)
+	if err != nil {
+		return nil, err
+	}
+	result.SignerId = key.EmployeeId.String()
+	result.Valid = signerCanManage && ed25519.Verify(key.PublicKey, payload, bidVersion.Signature)
+
+	return result, nil
+}
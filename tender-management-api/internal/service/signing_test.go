@@ -0,0 +1,55 @@
+package service
+
+import (
+	"tender-management-api/internal/entity"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCanonicalBidVersionCoversAttachments(t *testing.T) {
+	bid := &entity.Bid{
+		Id:         uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		TenderId:   uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+		AuthorId:   uuid.MustParse("33333333-3333-3333-3333-333333333333"),
+		AuthorType: "User",
+	}
+	version := &entity.BidVersionSignature{Version: 2, Name: "Repair", Description: "Offer <b>"}
+
+	payload, err := canonicalBidVersion(bid, version)
+	if err != nil {
+		t.Fatalf("canonicalBidVersion: %v", err)
+	}
+	want := `{"authorId":"33333333-3333-3333-3333-333333333333","authorType":"User","bidId":"11111111-1111-1111-1111-111111111111",` +
+		`"description":"Offer <b>","name":"Repair","tenderId":"22222222-2222-2222-2222-222222222222","version":2}`
+	if string(payload) != want {
+		t.Errorf("payload without attachments =\n%s\nwant\n%s", payload, want)
+	}
+
+	version.Attachments = []entity.Attachment{
+		{FileName: "price.xlsx", Checksum: "bb"},
+		{FileName: "contract.pdf", Checksum: "cc"},
+		{FileName: "contract.pdf", Checksum: "aa"},
+	}
+	payload, err = canonicalBidVersion(bid, version)
+	if err != nil {
+		t.Fatalf("canonicalBidVersion: %v", err)
+	}
+	want = `{"attachments":[{"checksum":"aa","fileName":"contract.pdf"},{"checksum":"cc","fileName":"contract.pdf"},` +
+		`{"checksum":"bb","fileName":"price.xlsx"}],"authorId":"33333333-3333-3333-3333-333333333333","authorType":"User",` +
+		`"bidId":"11111111-1111-1111-1111-111111111111","description":"Offer <b>","name":"Repair",` +
+		`"tenderId":"22222222-2222-2222-2222-222222222222","version":2}`
+	if string(payload) != want {
+		t.Errorf("payload with attachments =\n%s\nwant\n%s", payload, want)
+	}
+
+	// другой файл с тем же именем меняет подписываемые данные
+	version.Attachments[0].Checksum = "dd"
+	changed, err := canonicalBidVersion(bid, version)
+	if err != nil {
+		t.Fatalf("canonicalBidVersion: %v", err)
+	}
+	if string(changed) == string(payload) {
+		t.Error("payload did not change when attachment content changed")
+	}
+}
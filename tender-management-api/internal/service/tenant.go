@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"tender-management-api/internal/repo"
+	"tender-management-api/internal/repo/repo_errors"
+
+	"github.com/google/uuid"
+)
+
+type TenantService struct {
+	tenantRepo repo.Tenant
+}
+
+func NewTenantService(repos *repo.Repositories) *TenantService {
+	return &TenantService{tenantRepo: repos.Tenant}
+}
+
+// Сотрудник, от имени которого выполняется запрос. Запросы на создание передают вместо username только id автора
+type Principal struct {
+	Username   string
+	EmployeeId string
+}
+
+func (s *TenantService) principalTenantId(ctx context.Context, principal Principal) (uuid.UUID, error) {
+	if principal.Username != "" {
+		return s.tenantRepo.GetEmployeeTenantId(ctx, principal.Username)
+	}
+	if employeeId, err := uuid.Parse(principal.EmployeeId); err == nil {
+		return s.tenantRepo.GetEmployeeTenantIdById(ctx, employeeId)
+	}
+
+	return uuid.Nil, repo_errors.ErrNotFound
+}
+
+// Арендатор запроса - арендатор сотрудника principal, а без известного сотрудника - арендатор по умолчанию.
+// Заголовок tenantId ничем не подтвержден, поэтому арендатора не выбирает, а только сверяется с выбранным
+func (s *TenantService) ResolveTenant(ctx context.Context, principal Principal, tenantId string) (uuid.UUID, error) {
+	var requestedId uuid.UUID
+	if tenantId != "" {
+		var err error
+		if requestedId, err = uuid.Parse(tenantId); err != nil {
+			return uuid.Nil, ErrTenantNotFound
+		}
+	}
+
+	employeeTenantId, err := s.principalTenantId(ctx, principal)
+	if err == nil {
+		if tenantId != "" && requestedId != employeeTenantId {
+			return uuid.Nil, ErrEmployeeIsNotInTenant
+		}
+
+		return employeeTenantId, nil
+	}
+	// неизвестный сотрудник получит ошибку уже от обработчика запроса
+	if !errors.Is(err, repo_errors.ErrNotFound) {
+		return uuid.Nil, err
+	}
+
+	defaultTenantId, err := s.tenantRepo.GetDefaultTenantId(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if tenantId != "" && requestedId != defaultTenantId {
+		return uuid.Nil, ErrTenantRequiresEmployee
+	}
+
+	return defaultTenantId, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"tender-management-api/internal/repo"
+	"tender-management-api/internal/repo/repo_errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type tenantRepoStub struct {
+	repo.Tenant
+	defaultId uuid.UUID
+	byName    map[string]uuid.UUID
+	byId      map[uuid.UUID]uuid.UUID
+}
+
+func (r *tenantRepoStub) GetEmployeeTenantId(_ context.Context, username string) (uuid.UUID, error) {
+	if tenantId, ok := r.byName[username]; ok {
+		return tenantId, nil
+	}
+
+	return uuid.Nil, repo_errors.ErrNotFound
+}
+
+func (r *tenantRepoStub) GetEmployeeTenantIdById(_ context.Context, employeeId uuid.UUID) (uuid.UUID, error) {
+	if tenantId, ok := r.byId[employeeId]; ok {
+		return tenantId, nil
+	}
+
+	return uuid.Nil, repo_errors.ErrNotFound
+}
+
+func (r *tenantRepoStub) GetDefaultTenantId(_ context.Context) (uuid.UUID, error) {
+	return r.defaultId, nil
+}
+
+func TestResolveTenantTrustsOnlyPrincipal(t *testing.T) {
+	defaultId, otherId, bidderId := uuid.New(), uuid.New(), uuid.New()
+	s := &TenantService{tenantRepo: &tenantRepoStub{
+		defaultId: defaultId,
+		byName:    map[string]uuid.UUID{"other_manager": otherId},
+		byId:      map[uuid.UUID]uuid.UUID{bidderId: otherId},
+	}}
+
+	cases := []struct {
+		name      string
+		principal Principal
+		header    string
+		want      uuid.UUID
+		wantErr   error
+	}{
+		{"anonymous", Principal{}, "", defaultId, nil},
+		{"anonymous with default tenant", Principal{}, defaultId.String(), defaultId, nil},
+		{"anonymous picks another tenant", Principal{}, otherId.String(), uuid.Nil, ErrTenantRequiresEmployee},
+		{"unknown employee picks another tenant", Principal{Username: "ghost"}, otherId.String(), uuid.Nil, ErrTenantRequiresEmployee},
+		{"employee by username", Principal{Username: "other_manager"}, "", otherId, nil},
+		{"employee by id from body", Principal{EmployeeId: bidderId.String()}, "", otherId, nil},
+		{"header matches employee", Principal{Username: "other_manager"}, otherId.String(), otherId, nil},
+		{"header contradicts employee", Principal{EmployeeId: bidderId.String()}, defaultId.String(), uuid.Nil, ErrEmployeeIsNotInTenant},
+		{"malformed header", Principal{}, "not-a-uuid", uuid.Nil, ErrTenantNotFound},
+	}
+	for _, c := range cases {
+		got, err := s.ResolveTenant(context.Background(), c.principal, c.header)
+		if !errors.Is(err, c.wantErr) {
+			t.Errorf("%s: error = %v, want %v", c.name, err, c.wantErr)
+		}
+		if got != c.want {
+			t.Errorf("%s: tenant = %s, want %s", c.name, got, c.want)
+		}
+	}
+}
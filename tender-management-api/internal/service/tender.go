@@ -12,62 +12,101 @@ import (
 )
 
 type TenderService struct {
-	tenderRepo   repo.Tender
-	bidRepo      repo.Bid
-	employeeRepo repo.Employee
+	tenderRepo      repo.Tender
+	bidRepo         repo.Bid
+	employeeRepo    repo.Employee
+	serviceTypeRepo repo.ServiceType
+	attributeRepo   repo.TenderAttribute
+	events          eventPublisher
+	policy          *Policy
 }
 
-func NewTenderService(repos *repo.Repositories) *TenderService {
+func NewTenderService(repos *repo.Repositories, events eventPublisher, policy *Policy) *TenderService {
 	return &TenderService{
-		tenderRepo:   repos.Tender,
-		bidRepo:      repos.Bid,
-		employeeRepo: repos.Employee,
+		tenderRepo:      repos.Tender,
+		bidRepo:         repos.Bid,
+		employeeRepo:    repos.Employee,
+		serviceTypeRepo: repos.ServiceType,
+		attributeRepo:   repos.TenderAttribute,
+		events:          events,
+		policy:          policy,
 	}
 }
 
 func (s *TenderService) CreateTender(ctx context.Context, input *entity.CreateTenderInput) (*entity.TenderOutputModel, error) {
+	if err := s.prepareTender(ctx, input); err != nil {
+		return nil, err
+	}
+
+	id, err := s.tenderRepo.CreateTender(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	tender, err := s.tenderRepo.GetTenderById(ctx, id.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return mapTender(tender), nil
+}
+
+// Проверяет права создателя и параметры нового тендера и дополняет input значениями по умолчанию
+func (s *TenderService) prepareTender(ctx context.Context, input *entity.CreateTenderInput) error {
 	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, input.CreatorUsername)
 	if err != nil {
 		if errors.Is(err, repo_errors.ErrNotFound) {
-			return nil, ErrEmployeeNotFound
+			return ErrEmployeeNotFound
 		}
 
-		return nil, err
+		return err
 	}
 
 	organizationExists, err := s.employeeRepo.DoesOrganizationExistById(ctx, input.OrganizationId)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if !organizationExists {
-		return nil, ErrOrganizationNotFound
+		return ErrOrganizationNotFound
 	}
 
 	organizationId, _ := uuid.Parse(input.OrganizationId)
-	isResponsible, err := s.employeeRepo.IsEmployeeResponsible(ctx, employeeId, organizationId)
+	canManage, err := s.policy.Can(ctx, employeeId, organizationId, ManageTenders)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if !isResponsible {
-		return nil, ErrUserIsNotOrganizationResponsible
+	if !canManage {
+		return ErrUserIsNotOrganizationResponsible
 	}
 
-	id, err := s.tenderRepo.CreateTender(ctx, input)
-	if err != nil {
-		return nil, err
+	if err = checkServiceType(ctx, s.serviceTypeRepo, input.ServiceType); err != nil {
+		return err
 	}
 
-	tender, err := s.tenderRepo.GetTenderById(ctx, id.String())
+	input.Tags = normalizeTenderTags(input.Tags)
+	if err = s.checkTenderAttributes(ctx, organizationId, input.Attributes); err != nil {
+		return err
+	}
+
+	if input.ConflictPolicy == "" {
+		input.ConflictPolicy = common.ConflictFlag
+	}
+
+	return nil
+}
+
+func (s *TenderService) checkTenderAttributes(ctx context.Context, organizationId uuid.UUID, attributes map[string]string) error {
+	definitions, err := s.attributeRepo.GetTenderAttributeDefinitions(ctx, organizationId)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return mapTender(tender), nil
+	return validateTenderAttributes(definitions, attributes)
 }
 
-// done, может редактировать любой ответственный за организацию
-func (s *TenderService) EditTenderById(ctx context.Context, tenderId string, username string, name string, description string, serviceType string) (*entity.TenderOutputModel, error) {
-	if name == "" && description == "" && serviceType == "" {
+// done, может редактировать любой, кто управляет тендерами организации
+func (s *TenderService) EditTenderById(ctx context.Context, tenderId string, username string, input *entity.EditTenderInput) (*entity.TenderOutputModel, error) {
+	if input.Name == "" && input.Description == "" && input.ServiceType == "" && input.Tags == nil && input.Attributes == nil {
 		return nil, ErrNoNewChanges
 	}
 
@@ -89,15 +128,28 @@ func (s *TenderService) EditTenderById(ctx context.Context, tenderId string, use
 		return nil, err
 	}
 
-	isResponsible, err := s.employeeRepo.IsEmployeeResponsible(ctx, employeeId, tender.OrganizationId)
+	canManage, err := s.policy.Can(ctx, employeeId, tender.OrganizationId, ManageTenders)
 	if err != nil {
 		return nil, err
 	}
-	if !isResponsible {
+	if !canManage {
 		return nil, ErrUserHasNoAccessToTender
 	}
 
-	err = s.tenderRepo.EditTenderById(ctx, tenderId, name, description, serviceType)
+	if input.ServiceType != "" {
+		if err = checkServiceType(ctx, s.serviceTypeRepo, input.ServiceType); err != nil {
+			return nil, err
+		}
+	}
+
+	// атрибуты проверяются целиком, чтобы у тендера не остался незаполненным обязательный атрибут
+	input.Tags = normalizeTenderTags(input.Tags)
+	input.Attributes = mergeTenderAttributes(tender.Attributes, input.Attributes)
+	if err = s.checkTenderAttributes(ctx, tender.OrganizationId, input.Attributes); err != nil {
+		return nil, err
+	}
+
+	err = s.tenderRepo.EditTenderById(ctx, tenderId, input)
 	if err != nil {
 		return nil, err
 	}
@@ -142,20 +194,20 @@ func (s *TenderService) GetTenderStatusById(ctx context.Context, tenderId string
 		return "", ErrUnauthorizedTryToAccessWithEmployeeRights
 	}
 
-	// Тендер не публичный, значит тот, кто запрашивает должен быть из ответственных
+	// Тендер не публичный, значит тот, кто запрашивает, должен читать тендеры организации
 
-	isEmployeeResponsible, err := s.employeeRepo.IsEmployeeResponsible(ctx, employeeId, tender.OrganizationId)
+	canRead, err := s.policy.Can(ctx, employeeId, tender.OrganizationId, ReadTenders)
 	if err != nil {
 		return "", err
 	}
-	if !isEmployeeResponsible {
+	if !canRead {
 		return "", ErrUserHasNoAccessToTender
 	}
 
 	return tender.Status, nil
 }
 
-// Обновлять статус тендера может любой ответстенный за организацию, открывшую тендер
+// Обновлять статус тендера может любой, кто управляет тендерами организации, открывшей тендер
 func (s *TenderService) UpdateTenderStatusById(ctx context.Context, tenderId string, newStatus string, username string) (*entity.TenderOutputModel, error) {
 	tender, err := s.tenderRepo.GetTenderById(ctx, tenderId)
 	if err != nil {
@@ -175,11 +227,11 @@ func (s *TenderService) UpdateTenderStatusById(ctx context.Context, tenderId str
 		return nil, err
 	}
 
-	isResponsible, err := s.employeeRepo.IsEmployeeResponsible(ctx, employeeId, tender.OrganizationId)
+	canManage, err := s.policy.Can(ctx, employeeId, tender.OrganizationId, ManageTenders)
 	if err != nil {
 		return nil, err
 	}
-	if !isResponsible {
+	if !canManage {
 		return nil, ErrUserHasNoAccessToTender
 	}
 
@@ -188,16 +240,44 @@ func (s *TenderService) UpdateTenderStatusById(ctx context.Context, tenderId str
 		return nil, err
 	}
 
+	oldStatus := tender.Status
 	tender, err = s.tenderRepo.GetTenderById(ctx, tenderId)
 	if err != nil {
 		return nil, err
 	}
 
+	if oldStatus != tender.Status {
+		eventType := common.TenderStatusChangedEvent
+		if tender.Status == common.Published {
+			eventType = common.TenderPublishedEvent
+		}
+		s.events.Publish(ctx, newTenderEvent(eventType, tender))
+	}
+
 	return mapTender(tender), nil
 }
 
-func (s *TenderService) GetPublishedTenders(ctx context.Context, serviceTypes []string, pg *entity.PaginationInput) ([]entity.TenderOutputModel, error) {
-	tenders, err := s.tenderRepo.GetPublishedTenders(ctx, serviceTypes, pg)
+// Фильтр по категории включает все ее подкатегории
+func (s *TenderService) expandFilter(ctx context.Context, filter *entity.TenderFilter) (*entity.TenderFilter, error) {
+	serviceTypes, err := expandServiceTypes(ctx, s.serviceTypeRepo, filter.ServiceTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.TenderFilter{
+		ServiceTypes: serviceTypes,
+		Tags:         normalizeTenderTags(filter.Tags),
+		Attributes:   filter.Attributes,
+	}, nil
+}
+
+func (s *TenderService) GetPublishedTenders(ctx context.Context, filter *entity.TenderFilter, pg *entity.PaginationInput) ([]entity.TenderOutputModel, error) {
+	filter, err := s.expandFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	tenders, err := s.tenderRepo.GetPublishedTenders(ctx, filter, pg)
 	if err != nil {
 		return nil, err
 	}
@@ -205,9 +285,10 @@ func (s *TenderService) GetPublishedTenders(ctx context.Context, serviceTypes []
 	return mapTenders(tenders), nil
 }
 
-func (s *TenderService) GetUserTenders(ctx context.Context, username string, usernamePassed bool, pg *entity.PaginationInput) ([]entity.TenderOutputModel, error) {
+// Без organizationId возвращаются тендеры всех организаций, где сотрудник читает тендеры
+func (s *TenderService) GetUserTenders(ctx context.Context, username string, usernamePassed bool, organizationId string, filter *entity.TenderFilter, pg *entity.PaginationInput) ([]entity.TenderOutputModel, error) {
 	if !usernamePassed {
-		return s.GetPublishedTenders(ctx, []string{}, pg)
+		return s.GetPublishedTenders(ctx, filter, pg)
 	}
 	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
 	if err != nil {
@@ -218,12 +299,25 @@ func (s *TenderService) GetUserTenders(ctx context.Context, username string, use
 		return nil, err
 	}
 
-	organizationId, err := s.employeeRepo.GetUserOrganizationIdByEmployeeId(ctx, employeeId)
+	organizationIds, err := s.policy.OrganizationsWith(ctx, employeeId, ReadTenders)
 	if err != nil {
 		return nil, err
 	}
 
-	tenders, err := s.tenderRepo.GetTendersByOrganizationId(ctx, organizationId, pg)
+	organizationIds, ok := selectOrganization(organizationIds, organizationId)
+	if !ok {
+		return nil, ErrUserHasNoAccessToTender
+	}
+	if len(organizationIds) == 0 {
+		return []entity.TenderOutputModel{}, nil
+	}
+
+	filter, err = s.expandFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	tenders, err := s.tenderRepo.GetTendersByOrganizationIds(ctx, organizationIds, filter, pg)
 	if err != nil {
 		return nil, err
 	}
@@ -250,11 +344,11 @@ func (s *TenderService) RollbackTenderVersion(ctx context.Context, tenderId stri
 		return nil, err
 	}
 
-	isResponsible, err := s.employeeRepo.IsEmployeeResponsible(ctx, employeeId, tender.OrganizationId)
+	canManage, err := s.policy.Can(ctx, employeeId, tender.OrganizationId, ManageTenders)
 	if err != nil {
 		return nil, err
 	}
-	if !isResponsible {
+	if !canManage {
 		return nil, ErrUserHasNoAccessToTender
 	}
 
@@ -274,3 +368,44 @@ func (s *TenderService) RollbackTenderVersion(ctx context.Context, tenderId stri
 
 	return mapTender(tender), nil
 }
+
+// Клон проходит те же проверки, что и CreateTender, поэтому клонировать тендер может только тот, кто управляет тендерами его организации.
+// Тендер и копии вложений создаются в одной транзакции.
+// Срок подачи предложений не копируется: у исходного тендера он, скорее всего, уже прошел
+func (s *TenderService) CloneTender(ctx context.Context, tenderId string, username string) (*entity.TenderOutputModel, error) {
+	tender, err := s.tenderRepo.GetTenderById(ctx, tenderId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrTenderNotFound
+		}
+
+		return nil, err
+	}
+
+	input := &entity.CreateTenderInput{
+		Name:              tender.Name,
+		Description:       tender.Description,
+		ServiceType:       tender.ServiceType,
+		OrganizationId:    tender.OrganizationId.String(),
+		CreatorUsername:   username,
+		Tags:              tender.Tags,
+		Attributes:        tender.Attributes,
+		RequiresSignature: tender.RequiresSignature,
+		ConflictPolicy:    tender.ConflictPolicy,
+	}
+	if err = s.prepareTender(ctx, input); err != nil {
+		return nil, err
+	}
+
+	cloneId, err := s.tenderRepo.CloneTender(ctx, tender.Id, tender.Version, input)
+	if err != nil {
+		return nil, err
+	}
+
+	clone, err := s.tenderRepo.GetTenderById(ctx, cloneId.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return mapTender(clone), nil
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+	"tender-management-api/internal/repo/repo_errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StringAttribute  = "string"
+	NumberAttribute  = "number"
+	BooleanAttribute = "boolean"
+	DateAttribute    = "date"
+	EnumAttribute    = "enum"
+)
+
+// Теги сравниваются без учета регистра, поэтому хранятся в нижнем регистре и без повторов
+func normalizeTenderTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+
+	return normalized
+}
+
+// Переданные значения дополняют атрибуты предыдущей версии, пустое значение удаляет атрибут
+func mergeTenderAttributes(prev map[string]string, changes map[string]string) map[string]string {
+	merged := make(map[string]string, len(prev)+len(changes))
+	for code, value := range prev {
+		merged[code] = value
+	}
+	for code, value := range changes {
+		if value == "" {
+			delete(merged, code)
+
+			continue
+		}
+		merged[code] = value
+	}
+
+	return merged
+}
+
+func isValidAttributeValue(definition *entity.TenderAttributeDefinition, value string) bool {
+	switch definition.Type {
+	case NumberAttribute:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return false
+		}
+	case BooleanAttribute:
+		if value != "true" && value != "false" {
+			return false
+		}
+	case DateAttribute:
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return false
+		}
+	}
+
+	return len(definition.AllowedValues) == 0 || slices.Contains(definition.AllowedValues, value)
+}
+
+func validateTenderAttributes(definitions []entity.TenderAttributeDefinition, attributes map[string]string) error {
+	for code := range attributes {
+		if !slices.ContainsFunc(definitions, func(d entity.TenderAttributeDefinition) bool { return d.Code == code }) {
+			return ErrUnknownTenderAttribute.WithDetails(map[string]any{"attribute": code})
+		}
+	}
+
+	for _, definition := range definitions {
+		value, ok := attributes[definition.Code]
+		if !ok {
+			if definition.Required {
+				return ErrRequiredTenderAttributeMissing.WithDetails(map[string]any{"attribute": definition.Code})
+			}
+
+			continue
+		}
+		if !isValidAttributeValue(&definition, value) {
+			return ErrInvalidTenderAttributeValue.WithDetails(map[string]any{"attribute": definition.Code})
+		}
+	}
+
+	return nil
+}
+
+type TenderAttributeService struct {
+	attributeRepo repo.TenderAttribute
+	employeeRepo  repo.Employee
+	policy        *Policy
+}
+
+func NewTenderAttributeService(repos *repo.Repositories, policy *Policy) *TenderAttributeService {
+	return &TenderAttributeService{
+		attributeRepo: repos.TenderAttribute,
+		employeeRepo:  repos.Employee,
+		policy:        policy,
+	}
+}
+
+// Схему атрибутов организации видят те, кто читает ее тендеры, а меняют те, кто ими управляет
+func (s *TenderAttributeService) checkPermission(ctx context.Context, organizationId string, username string, permission Permission) (uuid.UUID, error) {
+	organizationExists, err := s.employeeRepo.DoesOrganizationExistById(ctx, organizationId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !organizationExists {
+		return uuid.Nil, ErrOrganizationNotFound
+	}
+
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return uuid.Nil, ErrEmployeeNotFound
+		}
+
+		return uuid.Nil, err
+	}
+
+	organizationUuid, _ := uuid.Parse(organizationId)
+	allowed, err := s.policy.Can(ctx, employeeId, organizationUuid, permission)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !allowed {
+		return uuid.Nil, ErrUserIsNotOrganizationResponsible
+	}
+
+	return organizationUuid, nil
+}
+
+func (s *TenderAttributeService) GetTenderAttributeDefinitions(ctx context.Context, organizationId string, username string) ([]entity.TenderAttributeDefinitionOutputModel, error) {
+	organizationUuid, err := s.checkPermission(ctx, organizationId, username, ReadTenders)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions, err := s.attributeRepo.GetTenderAttributeDefinitions(ctx, organizationUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapTenderAttributeDefinitions(definitions), nil
+}
+
+// Для перечислимого атрибута обязательно задать список допустимых значений
+func (s *TenderAttributeService) SetTenderAttributeDefinition(ctx context.Context, input *entity.TenderAttributeDefinitionInput) ([]entity.TenderAttributeDefinitionOutputModel, error) {
+	organizationUuid, err := s.checkPermission(ctx, input.OrganizationId, input.Username, ManageTenders)
+	if err != nil {
+		return nil, err
+	}
+
+	definition := &entity.TenderAttributeDefinition{
+		OrganizationId: organizationUuid.String(),
+		Code:           input.Code,
+		Name:           input.Name,
+		Type:           input.Type,
+		Required:       input.Required,
+		AllowedValues:  input.AllowedValues,
+	}
+	if definition.Type == EnumAttribute && len(definition.AllowedValues) == 0 {
+		return nil, ErrInvalidTenderAttributeDefinition
+	}
+	for _, value := range definition.AllowedValues {
+		if !isValidAttributeValue(&entity.TenderAttributeDefinition{Type: definition.Type}, value) {
+			return nil, ErrInvalidTenderAttributeDefinition
+		}
+	}
+
+	if err = s.attributeRepo.SetTenderAttributeDefinition(ctx, definition); err != nil {
+		return nil, err
+	}
+
+	definitions, err := s.attributeRepo.GetTenderAttributeDefinitions(ctx, organizationUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapTenderAttributeDefinitions(definitions), nil
+}
+
+func (s *TenderAttributeService) DeleteTenderAttributeDefinition(ctx context.Context, organizationId string, code string, username string) error {
+	organizationUuid, err := s.checkPermission(ctx, organizationId, username, ManageTenders)
+	if err != nil {
+		return err
+	}
+
+	if err = s.attributeRepo.DeleteTenderAttributeDefinition(ctx, organizationUuid, code); err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return ErrTenderAttributeNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
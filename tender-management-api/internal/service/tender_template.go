@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/repo"
+	"tender-management-api/internal/repo/repo_errors"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Плейсхолдеры в шаблоне записываются как {{name}}
+var templatePlaceholderRegexp = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+const (
+	maxTenderNameLength        = 100
+	maxTenderDescriptionLength = 500
+)
+
+// Значения, которые подставляются автоматически, если их не передали явно
+func defaultTemplateValues(now time.Time) map[string]string {
+	return map[string]string{
+		"date":  now.Format(time.DateOnly),
+		"month": now.Format("01"),
+		"year":  now.Format("2006"),
+	}
+}
+
+func templatePlaceholders(texts ...string) []string {
+	placeholders := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, text := range texts {
+		for _, match := range templatePlaceholderRegexp.FindAllStringSubmatch(text, -1) {
+			if _, ok := seen[match[1]]; ok {
+				continue
+			}
+			seen[match[1]] = struct{}{}
+			placeholders = append(placeholders, match[1])
+		}
+	}
+
+	return placeholders
+}
+
+func fillTemplate(text string, values map[string]string) (string, error) {
+	var err error
+	filled := templatePlaceholderRegexp.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := templatePlaceholderRegexp.FindStringSubmatch(placeholder)[1]
+		value, ok := values[name]
+		if !ok {
+			err = ErrTemplatePlaceholderNotFilled
+		}
+
+		return value
+	})
+
+	return strings.TrimSpace(filled), err
+}
+
+type TenderTemplateService struct {
+	templateRepo    repo.TenderTemplate
+	employeeRepo    repo.Employee
+	serviceTypeRepo repo.ServiceType
+	tenderService   *TenderService
+	policy          *Policy
+}
+
+func NewTenderTemplateService(repos *repo.Repositories, tenderService *TenderService, policy *Policy) *TenderTemplateService {
+	return &TenderTemplateService{
+		templateRepo:    repos.TenderTemplate,
+		employeeRepo:    repos.Employee,
+		serviceTypeRepo: repos.ServiceType,
+		tenderService:   tenderService,
+		policy:          policy,
+	}
+}
+
+// Шаблоны организации видят те, кто читает ее тендеры, а создают и удаляют те, кто ими управляет
+func (s *TenderTemplateService) checkPermission(ctx context.Context, username string, organizationId uuid.UUID, permission Permission) error {
+	employeeId, err := s.employeeRepo.GetEmployeeIdByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return ErrEmployeeNotFound
+		}
+
+		return err
+	}
+
+	allowed, err := s.policy.Can(ctx, employeeId, organizationId, permission)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrUserIsNotOrganizationResponsible
+	}
+
+	return nil
+}
+
+func (s *TenderTemplateService) getTemplate(ctx context.Context, templateId string) (*entity.TenderTemplate, error) {
+	template, err := s.templateRepo.GetTenderTemplateById(ctx, templateId)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (s *TenderTemplateService) CreateTenderTemplate(ctx context.Context, input *entity.CreateTenderTemplateInput) (*entity.TenderTemplateOutputModel, error) {
+	organizationExists, err := s.employeeRepo.DoesOrganizationExistById(ctx, input.OrganizationId)
+	if err != nil {
+		return nil, err
+	}
+	if !organizationExists {
+		return nil, ErrOrganizationNotFound
+	}
+
+	organizationId, _ := uuid.Parse(input.OrganizationId)
+	if err = s.checkPermission(ctx, input.CreatorUsername, organizationId, ManageTenders); err != nil {
+		return nil, err
+	}
+
+	if err = checkServiceType(ctx, s.serviceTypeRepo, input.ServiceType); err != nil {
+		return nil, err
+	}
+
+	id, err := s.templateRepo.CreateTenderTemplate(ctx, input)
+	if err != nil {
+		if errors.Is(err, repo_errors.ErrAlreadyExists) {
+			return nil, ErrTemplateAlreadyExists
+		}
+
+		return nil, err
+	}
+
+	template, err := s.templateRepo.GetTenderTemplateById(ctx, id.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return mapTenderTemplate(template), nil
+}
+
+func (s *TenderTemplateService) GetTenderTemplates(ctx context.Context, organizationId string, username string, pg *entity.PaginationInput) ([]entity.TenderTemplateOutputModel, error) {
+	organizationExists, err := s.employeeRepo.DoesOrganizationExistById(ctx, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	if !organizationExists {
+		return nil, ErrOrganizationNotFound
+	}
+
+	organizationUuid, _ := uuid.Parse(organizationId)
+	if err = s.checkPermission(ctx, username, organizationUuid, ReadTenders); err != nil {
+		return nil, err
+	}
+
+	templates, err := s.templateRepo.GetTenderTemplatesByOrganizationId(ctx, organizationUuid, pg)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapTenderTemplates(templates), nil
+}
+
+func (s *TenderTemplateService) DeleteTenderTemplate(ctx context.Context, templateId string, username string) error {
+	template, err := s.getTemplate(ctx, templateId)
+	if err != nil {
+		return err
+	}
+
+	if err = s.checkPermission(ctx, username, template.OrganizationId, ManageTenders); err != nil {
+		return err
+	}
+
+	if err = s.templateRepo.DeleteTenderTemplateById(ctx, template.Id); err != nil {
+		if errors.Is(err, repo_errors.ErrNotFound) {
+			return ErrTemplateNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Тендер создается через CreateTender, поэтому проверки прав те же, что и при обычном создании.
+// После подстановки значений текст должен укладываться в те же ограничения, что и у обычного тендера
+func (s *TenderTemplateService) CreateTenderFromTemplate(ctx context.Context, input *entity.CreateTenderFromTemplateInput) (*entity.TenderOutputModel, error) {
+	template, err := s.getTemplate(ctx, input.TemplateId)
+	if err != nil {
+		return nil, err
+	}
+
+	values := defaultTemplateValues(time.Now())
+	for name, value := range input.Values {
+		values[name] = value
+	}
+
+	name, err := fillTemplate(template.TenderName, values)
+	if err != nil {
+		return nil, err
+	}
+	description, err := fillTemplate(template.TenderDescription, values)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" || utf8.RuneCountInString(name) > maxTenderNameLength ||
+		description == "" || utf8.RuneCountInString(description) > maxTenderDescriptionLength {
+		return nil, ErrFilledTemplateIsInvalid
+	}
+
+	return s.tenderService.CreateTender(ctx, &entity.CreateTenderInput{
+		Name:            name,
+		Description:     description,
+		ServiceType:     template.ServiceType,
+		OrganizationId:  template.OrganizationId.String(),
+		CreatorUsername: input.CreatorUsername,
+		Deadline:        input.Deadline,
+		Tags:            input.Tags,
+		Attributes:      input.Attributes,
+	})
+}
@@ -8,22 +8,64 @@ drop table if exists employee;
 
 ------------------------------------------------------
 
+drop table if exists approval_delegation;
+
+drop table if exists organization_role;
+
+drop type if exists organization_role_type;
+
+drop table if exists organization_relation;
+
+drop type if exists organization_relation_type;
+
+drop table if exists tender_attribute_definition;
+
+drop table if exists tender_template;
+
+drop table if exists attachment;
+
+drop table if exists notification_preference;
+
+drop table if exists notification;
+
+drop table if exists event;
+
+drop table if exists review_version;
+
 drop table if exists review;
 
+drop table if exists contract;
+
+drop type if exists contract_status_type;
+
+drop table if exists bid_vote;
+
 drop table if exists approves;
 
 drop table if exists  bid_version;
 
+drop table if exists employee_key;
+
 drop table if exists bid;
 
 drop type if exists bid_status_type;
 
 drop type if exists bid_author_type;
 
+drop type if exists bid_version_action_type;
+
 drop table if exists  tender_version;
 
 drop table if exists tender;
 
+drop table if exists service_type;
+
 drop type if exists tender_status_type;
 
-drop type if exists service_type_type;
\ No newline at end of file
+drop type if exists conflict_policy_type;
+
+drop type if exists service_type_type;
+
+drop function if exists current_tenant_id();
+
+drop table if exists tenant;
\ No newline at end of file
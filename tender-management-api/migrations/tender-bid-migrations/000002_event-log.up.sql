@@ -0,0 +1,12 @@
+CREATE TABLE event (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(50) NOT NULL,
+    tender_id UUID REFERENCES tender(id) ON DELETE CASCADE,
+    bid_id UUID REFERENCES bid(id) ON DELETE CASCADE,
+    organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+    bid_author_id UUID REFERENCES employee(id) ON DELETE CASCADE,
+    service_type VARCHAR(100),
+    status VARCHAR(50),
+    decision VARCHAR(50),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
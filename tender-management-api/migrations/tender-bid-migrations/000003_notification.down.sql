@@ -0,0 +1,3 @@
+drop table if exists notification_preference;
+
+drop table if exists notification;
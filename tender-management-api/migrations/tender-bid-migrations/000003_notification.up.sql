@@ -0,0 +1,16 @@
+CREATE TABLE notification (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    employee_id UUID REFERENCES employee(id) ON DELETE CASCADE,
+    event_id BIGINT REFERENCES event(id) ON DELETE CASCADE,
+    is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX notification_employee_id_is_read_idx ON notification (employee_id, is_read);
+
+CREATE TABLE notification_preference (
+    employee_id UUID REFERENCES employee(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    PRIMARY KEY (employee_id, event_type)
+);
@@ -0,0 +1,7 @@
+ALTER TABLE tender DROP COLUMN IF EXISTS deadline_reminder_sent;
+
+ALTER TABLE tender DROP COLUMN IF EXISTS deadline;
+
+ALTER TABLE employee DROP COLUMN IF EXISTS locale;
+
+ALTER TABLE employee DROP COLUMN IF EXISTS email;
@@ -0,0 +1,7 @@
+ALTER TABLE employee ADD COLUMN email VARCHAR(254);
+
+ALTER TABLE employee ADD COLUMN locale VARCHAR(10) NOT NULL DEFAULT 'ru';
+
+ALTER TABLE tender ADD COLUMN deadline TIMESTAMP;
+
+ALTER TABLE tender ADD COLUMN deadline_reminder_sent BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,17 @@
+CREATE TABLE attachment (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_version_id UUID REFERENCES tender_version(id) ON DELETE CASCADE,
+    bid_version_id UUID REFERENCES bid_version(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    checksum CHAR(64) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    author_id UUID REFERENCES employee(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((tender_version_id IS NULL) <> (bid_version_id IS NULL))
+);
+
+CREATE INDEX attachment_tender_version_id_idx ON attachment (tender_version_id);
+
+CREATE INDEX attachment_bid_version_id_idx ON attachment (bid_version_id);
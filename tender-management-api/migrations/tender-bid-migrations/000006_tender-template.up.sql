@@ -0,0 +1,10 @@
+CREATE TABLE tender_template (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    tender_name VARCHAR(100) NOT NULL,
+    tender_description VARCHAR(500) NOT NULL,
+    service_type service_type_type NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_id, name)
+);
@@ -0,0 +1,15 @@
+ALTER TABLE employee DROP COLUMN IF EXISTS is_admin;
+
+CREATE TYPE service_type_type AS ENUM (
+    'Construction',
+    'Delivery',
+    'Manufacture'
+);
+
+ALTER TABLE tender_template DROP CONSTRAINT IF EXISTS tender_template_service_type_fkey;
+ALTER TABLE tender_template ALTER COLUMN service_type TYPE service_type_type USING service_type::service_type_type;
+
+ALTER TABLE tender_version DROP CONSTRAINT IF EXISTS tender_version_service_type_fkey;
+ALTER TABLE tender_version ALTER COLUMN service_type TYPE service_type_type USING service_type::service_type_type;
+
+DROP TABLE IF EXISTS service_type;
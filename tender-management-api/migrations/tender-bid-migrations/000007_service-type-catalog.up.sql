@@ -0,0 +1,26 @@
+CREATE TABLE service_type (
+    code VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    parent_code VARCHAR(50) REFERENCES service_type(code) ON DELETE RESTRICT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (parent_code <> code)
+);
+
+CREATE INDEX service_type_parent_code_idx ON service_type (parent_code);
+
+INSERT INTO service_type (code, name) VALUES
+    ('Construction', 'Construction'),
+    ('Delivery', 'Delivery'),
+    ('Manufacture', 'Manufacture');
+
+ALTER TABLE tender_version ALTER COLUMN service_type TYPE VARCHAR(50) USING service_type::text;
+ALTER TABLE tender_version ADD CONSTRAINT tender_version_service_type_fkey
+    FOREIGN KEY (service_type) REFERENCES service_type(code) ON DELETE RESTRICT;
+
+ALTER TABLE tender_template ALTER COLUMN service_type TYPE VARCHAR(50) USING service_type::text;
+ALTER TABLE tender_template ADD CONSTRAINT tender_template_service_type_fkey
+    FOREIGN KEY (service_type) REFERENCES service_type(code) ON DELETE RESTRICT;
+
+DROP TYPE service_type_type;
+
+ALTER TABLE employee ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS tender_attribute_definition;
+
+DROP INDEX IF EXISTS tender_version_attributes_idx;
+DROP INDEX IF EXISTS tender_version_tags_idx;
+
+ALTER TABLE tender_version DROP COLUMN IF EXISTS attributes;
+ALTER TABLE tender_version DROP COLUMN IF EXISTS tags;
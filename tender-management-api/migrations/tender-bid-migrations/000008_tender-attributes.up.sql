@@ -0,0 +1,17 @@
+ALTER TABLE tender_version ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE tender_version ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX tender_version_tags_idx ON tender_version USING GIN (tags);
+
+CREATE INDEX tender_version_attributes_idx ON tender_version USING GIN (attributes jsonb_path_ops);
+
+CREATE TABLE tender_attribute_definition (
+    organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('string', 'number', 'boolean', 'date', 'enum')),
+    required BOOLEAN NOT NULL DEFAULT false,
+    allowed_values TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (organization_id, code)
+);
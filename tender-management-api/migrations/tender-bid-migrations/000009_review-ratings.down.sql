@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS review_receiver_organization_id_idx;
+DROP INDEX IF EXISTS review_receiver_id_idx;
+
+ALTER TABLE review DROP COLUMN IF EXISTS receiver_organization_id;
+ALTER TABLE review DROP COLUMN IF EXISTS communication_rating;
+ALTER TABLE review DROP COLUMN IF EXISTS timeliness_rating;
+ALTER TABLE review DROP COLUMN IF EXISTS quality_rating;
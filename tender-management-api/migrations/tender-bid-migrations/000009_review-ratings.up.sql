@@ -0,0 +1,8 @@
+ALTER TABLE review ADD COLUMN quality_rating SMALLINT CHECK (quality_rating BETWEEN 1 AND 5);
+ALTER TABLE review ADD COLUMN timeliness_rating SMALLINT CHECK (timeliness_rating BETWEEN 1 AND 5);
+ALTER TABLE review ADD COLUMN communication_rating SMALLINT CHECK (communication_rating BETWEEN 1 AND 5);
+ALTER TABLE review ADD COLUMN receiver_organization_id UUID REFERENCES organization(id) ON DELETE SET NULL;
+
+CREATE INDEX review_receiver_id_idx ON review (receiver_id, created_at);
+
+CREATE INDEX review_receiver_organization_id_idx ON review (receiver_organization_id, created_at);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS review_version;
+
+ALTER TABLE review DROP COLUMN IF EXISTS hidden_reason;
+ALTER TABLE review DROP COLUMN IF EXISTS hidden_by;
+ALTER TABLE review DROP COLUMN IF EXISTS hidden_at;
+ALTER TABLE review DROP COLUMN IF EXISTS replied_at;
+ALTER TABLE review DROP COLUMN IF EXISTS reply;
+ALTER TABLE review DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE review DROP COLUMN IF EXISTS version;
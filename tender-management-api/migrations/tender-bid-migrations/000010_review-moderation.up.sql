@@ -0,0 +1,19 @@
+ALTER TABLE review ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE review ADD COLUMN updated_at TIMESTAMP;
+ALTER TABLE review ADD COLUMN reply TEXT;
+ALTER TABLE review ADD COLUMN replied_at TIMESTAMP;
+ALTER TABLE review ADD COLUMN hidden_at TIMESTAMP;
+ALTER TABLE review ADD COLUMN hidden_by UUID REFERENCES employee(id);
+ALTER TABLE review ADD COLUMN hidden_reason TEXT;
+
+CREATE TABLE review_version (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    review_id UUID REFERENCES review(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    description TEXT,
+    quality_rating SMALLINT,
+    timeliness_rating SMALLINT,
+    communication_rating SMALLINT,
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE (review_id, version)
+);
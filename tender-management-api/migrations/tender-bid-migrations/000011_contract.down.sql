@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS review_contract_author_idx;
+
+ALTER TABLE review DROP COLUMN IF EXISTS contract_id;
+
+DROP TABLE IF EXISTS contract;
+
+DROP TYPE IF EXISTS contract_status_type;
@@ -0,0 +1,35 @@
+CREATE TYPE contract_status_type AS ENUM (
+    'Draft',
+    'Signed',
+    'InProgress',
+    'Completed',
+    'Terminated'
+);
+
+CREATE TABLE contract (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID REFERENCES tender(id) ON DELETE CASCADE,
+    bid_id UUID UNIQUE REFERENCES bid(id) ON DELETE CASCADE,
+    bid_version INT NOT NULL,
+    customer_organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+    supplier_id UUID REFERENCES employee(id),
+    supplier_organization_id UUID REFERENCES organization(id) ON DELETE SET NULL,
+    price NUMERIC(15, 2) CHECK (price > 0),
+    start_date DATE,
+    end_date DATE,
+    status contract_status_type NOT NULL DEFAULT 'Draft',
+    customer_signed_at TIMESTAMP,
+    supplier_signed_at TIMESTAMP,
+    termination_reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_date IS NULL OR start_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX contract_supplier_id_idx ON contract (supplier_id);
+
+CREATE INDEX contract_customer_organization_id_idx ON contract (customer_organization_id);
+
+ALTER TABLE review ADD COLUMN contract_id UUID REFERENCES contract(id) ON DELETE SET NULL;
+
+CREATE UNIQUE INDEX review_contract_author_idx ON review (contract_id, author_id);
@@ -0,0 +1,7 @@
+ALTER TABLE bid_version DROP COLUMN IF EXISTS signed_at;
+ALTER TABLE bid_version DROP COLUMN IF EXISTS signing_key_id;
+ALTER TABLE bid_version DROP COLUMN IF EXISTS signature;
+
+ALTER TABLE tender DROP COLUMN IF EXISTS requires_signature;
+
+DROP TABLE IF EXISTS employee_key;
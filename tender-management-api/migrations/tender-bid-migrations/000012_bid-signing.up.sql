@@ -0,0 +1,15 @@
+CREATE TABLE employee_key (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    employee_id UUID REFERENCES employee(id) ON DELETE CASCADE,
+    public_key BYTEA NOT NULL UNIQUE CHECK (octet_length(public_key) = 32),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX employee_key_employee_id_idx ON employee_key (employee_id);
+
+ALTER TABLE tender ADD COLUMN requires_signature BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE bid_version ADD COLUMN signature BYTEA CHECK (octet_length(signature) = 64);
+ALTER TABLE bid_version ADD COLUMN signing_key_id UUID REFERENCES employee_key(id);
+ALTER TABLE bid_version ADD COLUMN signed_at TIMESTAMP;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS approves_bid_id_idx;
+
+ALTER TABLE bid DROP COLUMN IF EXISTS withdrawal_consented_by;
+ALTER TABLE bid DROP COLUMN IF EXISTS withdrawn_at;
+ALTER TABLE bid DROP COLUMN IF EXISTS withdrawal_reason;
@@ -0,0 +1,5 @@
+ALTER TABLE bid ADD COLUMN withdrawal_reason TEXT;
+ALTER TABLE bid ADD COLUMN withdrawn_at TIMESTAMP;
+ALTER TABLE bid ADD COLUMN withdrawal_consented_by UUID REFERENCES employee(id) ON DELETE SET NULL;
+
+CREATE INDEX approves_bid_id_idx ON approves (bid_id);
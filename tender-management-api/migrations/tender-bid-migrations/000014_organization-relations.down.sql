@@ -0,0 +1,9 @@
+ALTER TABLE bid DROP COLUMN IF EXISTS conflict_of_interest;
+
+ALTER TABLE tender DROP COLUMN IF EXISTS conflict_policy;
+
+DROP TYPE IF EXISTS conflict_policy_type;
+
+DROP TABLE IF EXISTS organization_relation;
+
+DROP TYPE IF EXISTS organization_relation_type;
@@ -0,0 +1,27 @@
+CREATE TYPE organization_relation_type AS ENUM (
+    'Parent',
+    'Affiliate'
+);
+
+-- Parent: organization_id владеет related_organization_id, Affiliate: связь без направления
+CREATE TABLE organization_relation (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    related_organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    relation_type organization_relation_type NOT NULL,
+    created_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_id, related_organization_id),
+    CHECK (organization_id <> related_organization_id)
+);
+
+CREATE INDEX organization_relation_related_organization_id_idx ON organization_relation (related_organization_id);
+
+CREATE TYPE conflict_policy_type AS ENUM (
+    'Flag',
+    'Block'
+);
+
+ALTER TABLE tender ADD COLUMN conflict_policy conflict_policy_type NOT NULL DEFAULT 'Flag';
+
+ALTER TABLE bid ADD COLUMN conflict_of_interest BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,10 @@
+ALTER TABLE bid_version DROP COLUMN IF EXISTS created_at;
+ALTER TABLE bid_version DROP COLUMN IF EXISTS action;
+ALTER TABLE bid_version DROP COLUMN IF EXISTS created_by;
+
+DROP TYPE IF EXISTS bid_version_action_type;
+
+DROP INDEX IF EXISTS bid_organization_id_idx;
+
+ALTER TABLE bid DROP COLUMN IF EXISTS status_changed_by;
+ALTER TABLE bid DROP COLUMN IF EXISTS organization_id;
@@ -0,0 +1,22 @@
+ALTER TABLE bid ADD COLUMN organization_id UUID REFERENCES organization(id) ON DELETE SET NULL;
+ALTER TABLE bid ADD COLUMN status_changed_by UUID REFERENCES employee(id) ON DELETE SET NULL;
+
+UPDATE bid SET organization_id = (
+    SELECT organization_id FROM organization_responsible WHERE user_id = bid.author_id LIMIT 1
+) WHERE author_type = 'Organization';
+
+CREATE INDEX bid_organization_id_idx ON bid (organization_id);
+
+CREATE TYPE bid_version_action_type AS ENUM (
+    'Create',
+    'Edit',
+    'Rollback',
+    'Resubmit'
+);
+
+ALTER TABLE bid_version ADD COLUMN created_by UUID REFERENCES employee(id) ON DELETE SET NULL;
+ALTER TABLE bid_version ADD COLUMN action bid_version_action_type;
+ALTER TABLE bid_version ADD COLUMN created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+
+UPDATE bid_version SET created_by = bid.author_id, action = CASE WHEN bid_version.version = 1 THEN 'Create' ELSE 'Edit' END::bid_version_action_type
+FROM bid WHERE bid.id = bid_version.bid_id;
@@ -0,0 +1,20 @@
+CREATE TYPE organization_role_type AS ENUM (
+    'OrgAdmin',
+    'TenderManager',
+    'Approver',
+    'Bidder',
+    'Auditor'
+);
+
+-- Ответственные без назначенных ролей сохраняют прежние права, см. service.Policy
+CREATE TABLE organization_role (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    employee_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    role organization_role_type NOT NULL,
+    granted_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_id, employee_id, role)
+);
+
+CREATE INDEX organization_role_employee_id_idx ON organization_role (employee_id);
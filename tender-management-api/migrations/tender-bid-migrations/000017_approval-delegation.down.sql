@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS bid_vote;
+
+ALTER TABLE approves DROP COLUMN IF EXISTS delegate_id;
+
+DROP TABLE IF EXISTS approval_delegation;
@@ -0,0 +1,31 @@
+-- Делегат голосует вместо делегирующего в организации в течение [starts_on, ends_on]
+CREATE TABLE approval_delegation (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+    delegator_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    delegate_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    starts_on DATE NOT NULL,
+    ends_on DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (delegator_id <> delegate_id),
+    CHECK (starts_on <= ends_on)
+);
+
+CREATE INDEX approval_delegation_delegator_id_idx ON approval_delegation (delegator_id);
+
+CREATE INDEX approval_delegation_delegate_id_idx ON approval_delegation (delegate_id);
+
+-- employee_id - чей это голос, delegate_id - кто его подал, если голосовал делегат
+ALTER TABLE approves ADD COLUMN delegate_id UUID REFERENCES employee(id) ON DELETE CASCADE;
+
+-- approves очищается после решения, а журнал голосов хранится всегда
+CREATE TABLE bid_vote (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    employee_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    delegate_id UUID REFERENCES employee(id) ON DELETE SET NULL,
+    decision bid_decision_type NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX bid_vote_bid_id_idx ON bid_vote (bid_id);
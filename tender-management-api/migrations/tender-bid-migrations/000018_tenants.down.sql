@@ -0,0 +1,22 @@
+DROP POLICY IF EXISTS principal_lookup ON employee;
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'employee', 'organization', 'organization_responsible', 'organization_role', 'organization_relation',
+        'approval_delegation', 'tender', 'tender_version', 'tender_template', 'tender_attribute_definition',
+        'bid', 'bid_version', 'approves', 'bid_vote', 'review', 'review_version', 'contract', 'employee_key',
+        'attachment', 'event', 'notification', 'notification_preference'
+    ] LOOP
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+        EXECUTE format('ALTER TABLE %I NO FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I DISABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I DROP COLUMN IF EXISTS tenant_id', t);
+    END LOOP;
+END $$;
+
+DROP FUNCTION IF EXISTS current_tenant_id();
+
+DROP TABLE IF EXISTS tenant;
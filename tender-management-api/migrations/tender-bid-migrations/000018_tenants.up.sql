@@ -0,0 +1,44 @@
+-- Арендатор - независимая площадка закупок. Все существующие данные достаются арендатору по умолчанию
+CREATE TABLE tenant (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    is_default BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX tenant_is_default_idx ON tenant (is_default) WHERE is_default;
+
+INSERT INTO tenant (name, is_default) VALUES ('default', true);
+
+-- app.tenant_id задается приложением на время транзакции. После SET LOCAL в сессии остается
+-- пустая строка, поэтому она, как и отсутствие настройки, означает, что арендатор не выбран
+CREATE FUNCTION current_tenant_id() RETURNS UUID AS $$
+    SELECT NULLIF(current_setting('app.tenant_id', true), '')::uuid
+$$ LANGUAGE SQL STABLE;
+
+-- Вставка без выбранного арендатора нарушает NOT NULL, а чтение не видит ни одной строки.
+-- FORCE распространяет политики и на владельца таблиц, под которым обычно работает приложение.
+-- Справочник service_type общий для всех арендаторов и не изолируется
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'employee', 'organization', 'organization_responsible', 'organization_role', 'organization_relation',
+        'approval_delegation', 'tender', 'tender_version', 'tender_template', 'tender_attribute_definition',
+        'bid', 'bid_version', 'approves', 'bid_vote', 'review', 'review_version', 'contract', 'employee_key',
+        'attachment', 'event', 'notification', 'notification_preference'
+    ] LOOP
+        EXECUTE format('ALTER TABLE %I ADD COLUMN tenant_id UUID REFERENCES tenant(id)', t);
+        EXECUTE format('UPDATE %I SET tenant_id = (SELECT id FROM tenant WHERE is_default)', t);
+        EXECUTE format('ALTER TABLE %I ALTER COLUMN tenant_id SET NOT NULL, ALTER COLUMN tenant_id SET DEFAULT current_tenant_id()', t);
+        EXECUTE format('CREATE INDEX %I ON %I (tenant_id)', t || '_tenant_id_idx', t);
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('CREATE POLICY tenant_isolation ON %I USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id())', t);
+    END LOOP;
+END $$;
+
+-- Арендатор запроса определяется по сотруднику, поэтому до его выбора сотрудник виден по username из app.principal
+CREATE POLICY principal_lookup ON employee FOR SELECT
+    USING (current_tenant_id() IS NULL AND username = current_setting('app.principal', true));
@@ -0,0 +1,13 @@
+-- Удалить значение из перечисления нельзя, поэтому тип пересоздается без него
+ALTER TYPE bid_version_action_type RENAME TO bid_version_action_type_old;
+
+CREATE TYPE bid_version_action_type AS ENUM (
+    'Create',
+    'Edit',
+    'Rollback',
+    'Resubmit'
+);
+
+ALTER TABLE bid_version ALTER COLUMN action TYPE bid_version_action_type USING action::text::bid_version_action_type;
+
+DROP TYPE bid_version_action_type_old;
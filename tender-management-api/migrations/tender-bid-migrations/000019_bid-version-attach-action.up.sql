@@ -0,0 +1,2 @@
+-- Новое значение перечисления нельзя использовать в той же транзакции, поэтому старые версии размечаются в следующей миграции
+ALTER TYPE bid_version_action_type ADD VALUE IF NOT EXISTS 'Attach';
@@ -0,0 +1,5 @@
+ALTER TABLE bid_version NO FORCE ROW LEVEL SECURITY;
+
+UPDATE bid_version SET action = NULL, created_by = NULL WHERE action = 'Attach';
+
+ALTER TABLE bid_version FORCE ROW LEVEL SECURITY;
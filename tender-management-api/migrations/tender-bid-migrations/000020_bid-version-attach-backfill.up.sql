@@ -0,0 +1,15 @@
+-- Версии, созданные загрузкой вложения, до этого оставались без автора и действия.
+-- Автор такой версии - автор самого нового вложения в ней: скопированные вложения сохраняют дату загрузки.
+-- Разметка идет по всем арендаторам сразу, поэтому на время миграции политики RLS не применяются к владельцу таблиц
+ALTER TABLE bid_version NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE attachment NO FORCE ROW LEVEL SECURITY;
+
+UPDATE bid_version SET action = 'Attach', created_by = (
+    SELECT attachment.author_id FROM attachment
+    WHERE attachment.bid_version_id = bid_version.id
+    ORDER BY attachment.created_at DESC
+    LIMIT 1
+) WHERE action IS NULL AND EXISTS (SELECT 1 FROM attachment WHERE attachment.bid_version_id = bid_version.id);
+
+ALTER TABLE bid_version FORCE ROW LEVEL SECURITY;
+ALTER TABLE attachment FORCE ROW LEVEL SECURITY;
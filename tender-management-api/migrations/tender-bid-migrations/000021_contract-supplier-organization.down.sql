@@ -0,0 +1,2 @@
+-- Прежняя организация поставщика была выбрана произвольно, восстанавливать ее незачем
+SELECT 1;
@@ -0,0 +1,13 @@
+-- Организация поставщика раньше выбиралась как первая попавшаяся организация автора предложения.
+-- Контракты по предложениям от имени организации переносятся на организацию, указанную в предложении
+ALTER TABLE contract NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE bid NO FORCE ROW LEVEL SECURITY;
+
+UPDATE contract SET supplier_organization_id = bid.organization_id
+FROM bid
+WHERE bid.id = contract.bid_id
+  AND bid.organization_id IS NOT NULL
+  AND contract.supplier_organization_id IS DISTINCT FROM bid.organization_id;
+
+ALTER TABLE contract FORCE ROW LEVEL SECURITY;
+ALTER TABLE bid FORCE ROW LEVEL SECURITY;
@@ -0,0 +1,4 @@
+DROP POLICY principal_lookup ON employee;
+
+CREATE POLICY principal_lookup ON employee FOR SELECT
+    USING (current_tenant_id() IS NULL AND username = current_setting('app.principal', true));
@@ -0,0 +1,5 @@
+-- Запросы на создание предложения передают автора по id, поэтому арендатор ищется и по id сотрудника
+DROP POLICY principal_lookup ON employee;
+
+CREATE POLICY principal_lookup ON employee FOR SELECT
+    USING (current_tenant_id() IS NULL AND current_setting('app.principal', true) IN (username, id::text));
@@ -0,0 +1,90 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalStorePath(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocalStore(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		key  string
+		want string
+	}{
+		{"tenders/1/spec.pdf", filepath.Join(root, "tenders", "1", "spec.pdf")},
+		{"/tenders/spec.pdf", filepath.Join(root, "tenders", "spec.pdf")},
+		{"tenders//./spec.pdf", filepath.Join(root, "tenders", "spec.pdf")},
+	}
+	for _, c := range cases {
+		got, err := store.path(c.key)
+		if err != nil || got != c.want {
+			t.Errorf("path(%q) = %q, %v, want %q", c.key, got, err, c.want)
+		}
+	}
+
+	for _, key := range []string{"", "/", ".", "..", "../secret", "tenders/../../secret", "/../secret", "tenders/.."} {
+		if got, err := store.path(key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("path(%q) = %q, %v, want ErrInvalidKey", key, got, err)
+		}
+	}
+}
+
+func TestLocalStoreRoundTrip(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "blobs")
+	store, err := NewLocalStore(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if err = store.Put(ctx, "bids/1/offer.pdf", []byte("offer")); err != nil {
+		t.Fatal(err)
+	}
+	// повторная запись заменяет файл целиком, временные файлы не остаются
+	if err = store.Put(ctx, "bids/1/offer.pdf", []byte("new offer")); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(filepath.Join(root, "bids", "1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the blob", len(entries))
+	}
+
+	blob, err := store.Get(ctx, "bids/1/offer.pdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := io.ReadAll(blob)
+	blob.Close()
+	if err != nil || string(content) != "new offer" {
+		t.Errorf("Get = %q, %v, want new offer", content, err)
+	}
+
+	if err = store.Delete(ctx, "bids/1/offer.pdf"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = store.Get(ctx, "bids/1/offer.pdf"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete: %v, want ErrNotFound", err)
+	}
+	if err = store.Delete(ctx, "bids/1/offer.pdf"); err != nil {
+		t.Errorf("Delete of a missing blob: %v", err)
+	}
+
+	if err = store.Put(ctx, "../outside", []byte("x")); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Put outside the root: %v, want ErrInvalidKey", err)
+	}
+	if _, err = os.Stat(filepath.Join(filepath.Dir(root), "outside")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("blob written outside the root: %v", err)
+	}
+}
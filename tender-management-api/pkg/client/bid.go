@@ -0,0 +1,269 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"tender-management-api/internal/entity"
+)
+
+type CreateBidRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	TenderId    string `json:"tenderId"`
+	// Organization или User
+	AuthorType string `json:"authorType"`
+	AuthorId   string `json:"authorId"`
+	// Обязательна, если автор состоит в нескольких организациях
+	OrganizationId string `json:"organizationId,omitempty"`
+}
+
+// Передаются только заполненные поля, остальные остаются прежними
+type EditBidRequest struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type BidFeedback struct {
+	Description         string
+	QualityRating       int
+	TimelinessRating    int
+	CommunicationRating int
+}
+
+type ReviewFilter struct {
+	AuthorUsername    string
+	RequesterUsername string
+
+	MinRating float64
+	MaxRating float64
+	// Даты в формате 2006-01-02
+	DateFrom               string
+	DateTo                 string
+	ReviewerOrganizationId string
+	// Только отзывы на предложения к тендерам с тем же видом услуги
+	Comparable bool
+}
+
+func (c *Client) CreateBid(ctx context.Context, input *CreateBidRequest) (*entity.BidOutputModel, error) {
+	var bid entity.BidOutputModel
+	if err := c.do(ctx, http.MethodPost, "/bids/new", nil, input, &bid); err != nil {
+		return nil, err
+	}
+
+	return &bid, nil
+}
+
+// GetUserBids возвращает страницу предложений пользователя. Без organizationId - по всем его организациям
+func (c *Client) GetUserBids(ctx context.Context, username string, organizationId string, page Page) ([]entity.BidOutputModel, error) {
+	query := url.Values{}
+	setIfNotEmpty(query, "username", username)
+	setIfNotEmpty(query, "organizationId", organizationId)
+	setPage(query, page)
+
+	var bids []entity.BidOutputModel
+	if err := c.do(ctx, http.MethodGet, "/bids/my", query, nil, &bids); err != nil {
+		return nil, err
+	}
+
+	return bids, nil
+}
+
+func (c *Client) UserBids(ctx context.Context, username string, organizationId string) *Iterator[entity.BidOutputModel] {
+	return newIterator(ctx, maxPageLimit, func(ctx context.Context, page Page) ([]entity.BidOutputModel, error) {
+		return c.GetUserBids(ctx, username, organizationId, page)
+	})
+}
+
+// GetTenderBids возвращает страницу предложений по тендеру
+func (c *Client) GetTenderBids(ctx context.Context, tenderId string, username string, page Page) ([]entity.BidOutputModel, error) {
+	query := url.Values{"username": {username}}
+	setPage(query, page)
+
+	var bids []entity.BidOutputModel
+	if err := c.do(ctx, http.MethodGet, endpoint("bids", tenderId, "list"), query, nil, &bids); err != nil {
+		return nil, err
+	}
+
+	return bids, nil
+}
+
+func (c *Client) TenderBids(ctx context.Context, tenderId string, username string) *Iterator[entity.BidOutputModel] {
+	return newIterator(ctx, maxPageLimit, func(ctx context.Context, page Page) ([]entity.BidOutputModel, error) {
+		return c.GetTenderBids(ctx, tenderId, username, page)
+	})
+}
+
+func (c *Client) GetBidStatus(ctx context.Context, bidId string, username string) (string, error) {
+	query := url.Values{"username": {username}}
+
+	var status string
+	if err := c.do(ctx, http.MethodGet, endpoint("bids", bidId, "status"), query, nil, &status); err != nil {
+		return "", err
+	}
+
+	return status, nil
+}
+
+func (c *Client) UpdateBidStatus(ctx context.Context, bidId string, status string, username string) (*entity.BidOutputModel, error) {
+	query := url.Values{"status": {status}, "username": {username}}
+
+	var bid entity.BidOutputModel
+	if err := c.do(ctx, http.MethodPut, endpoint("bids", bidId, "status"), query, nil, &bid); err != nil {
+		return nil, err
+	}
+
+	return &bid, nil
+}
+
+func (c *Client) GetBidVersions(ctx context.Context, bidId string, username string) ([]entity.BidVersionOutputModel, error) {
+	query := url.Values{"username": {username}}
+
+	var versions []entity.BidVersionOutputModel
+	if err := c.do(ctx, http.MethodGet, endpoint("bids", bidId, "versions"), query, nil, &versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (c *Client) GetBidVotes(ctx context.Context, bidId string, username string) ([]entity.BidVoteOutputModel, error) {
+	query := url.Values{"username": {username}}
+
+	var votes []entity.BidVoteOutputModel
+	if err := c.do(ctx, http.MethodGet, endpoint("bids", bidId, "votes"), query, nil, &votes); err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
+
+func (c *Client) EditBid(ctx context.Context, bidId string, username string, input *EditBidRequest) (*entity.BidOutputModel, error) {
+	query := url.Values{"username": {username}}
+
+	var bid entity.BidOutputModel
+	if err := c.do(ctx, http.MethodPatch, endpoint("bids", bidId, "edit"), query, input, &bid); err != nil {
+		return nil, err
+	}
+
+	return &bid, nil
+}
+
+// SubmitBidDecision голосует за предложение. onBehalfOf - username сотрудника, передавшего право голоса, или пустая строка
+func (c *Client) SubmitBidDecision(ctx context.Context, bidId string, decision string, username string, onBehalfOf string) (*entity.BidOutputModel, error) {
+	query := url.Values{"decision": {decision}, "username": {username}}
+	setIfNotEmpty(query, "onBehalfOf", onBehalfOf)
+
+	var bid entity.BidOutputModel
+	if err := c.do(ctx, http.MethodPut, endpoint("bids", bidId, "submit_decision"), query, nil, &bid); err != nil {
+		return nil, err
+	}
+
+	return &bid, nil
+}
+
+func (c *Client) SubmitBidFeedback(ctx context.Context, bidId string, username string, feedback *BidFeedback) (*entity.BidOutputModel, error) {
+	query := url.Values{
+		"username":      {username},
+		"bidFeedback":   {feedback.Description},
+		"quality":       {strconv.Itoa(feedback.QualityRating)},
+		"timeliness":    {strconv.Itoa(feedback.TimelinessRating)},
+		"communication": {strconv.Itoa(feedback.CommunicationRating)},
+	}
+
+	var bid entity.BidOutputModel
+	if err := c.do(ctx, http.MethodPut, endpoint("bids", bidId, "feedback"), query, nil, &bid); err != nil {
+		return nil, err
+	}
+
+	return &bid, nil
+}
+
+// RollbackBid создает новую версию предложения с параметрами версии version
+func (c *Client) RollbackBid(ctx context.Context, bidId string, version int, username string) (*entity.BidOutputModel, error) {
+	query := url.Values{"username": {username}}
+
+	var bid entity.BidOutputModel
+	path := endpoint("bids", bidId, "rollback", strconv.Itoa(version))
+	if err := c.do(ctx, http.MethodPut, path, query, nil, &bid); err != nil {
+		return nil, err
+	}
+
+	return &bid, nil
+}
+
+func (c *Client) WithdrawBid(ctx context.Context, bidId string, username string, reason string) (*entity.BidOutputModel, error) {
+	query := url.Values{"username": {username}, "reason": {reason}}
+
+	var bid entity.BidOutputModel
+	if err := c.do(ctx, http.MethodPut, endpoint("bids", bidId, "withdraw"), query, nil, &bid); err != nil {
+		return nil, err
+	}
+
+	return &bid, nil
+}
+
+func (c *Client) ConsentBidWithdrawal(ctx context.Context, bidId string, username string) (*entity.BidOutputModel, error) {
+	query := url.Values{"username": {username}}
+
+	var bid entity.BidOutputModel
+	if err := c.do(ctx, http.MethodPut, endpoint("bids", bidId, "withdrawal_consent"), query, nil, &bid); err != nil {
+		return nil, err
+	}
+
+	return &bid, nil
+}
+
+// ResubmitBid возвращает отозванное предложение, input с новыми названием или описанием можно не передавать
+func (c *Client) ResubmitBid(ctx context.Context, bidId string, username string, input *EditBidRequest) (*entity.BidOutputModel, error) {
+	query := url.Values{"username": {username}}
+
+	var body any
+	if input != nil {
+		body = input
+	}
+	var bid entity.BidOutputModel
+	if err := c.do(ctx, http.MethodPut, endpoint("bids", bidId, "resubmit"), query, body, &bid); err != nil {
+		return nil, err
+	}
+
+	return &bid, nil
+}
+
+func (f *ReviewFilter) setQuery(query url.Values) {
+	query.Set("authorUsername", f.AuthorUsername)
+	query.Set("requesterUsername", f.RequesterUsername)
+	if f.MinRating > 0 {
+		query.Set("minRating", strconv.FormatFloat(f.MinRating, 'f', -1, 64))
+	}
+	if f.MaxRating > 0 {
+		query.Set("maxRating", strconv.FormatFloat(f.MaxRating, 'f', -1, 64))
+	}
+	setIfNotEmpty(query, "dateFrom", f.DateFrom)
+	setIfNotEmpty(query, "dateTo", f.DateTo)
+	setIfNotEmpty(query, "reviewerOrganizationId", f.ReviewerOrganizationId)
+	if f.Comparable {
+		query.Set("comparable", "true")
+	}
+}
+
+// GetReviewsOnBidAuthorBids возвращает страницу отзывов на предложения автора, сделавшего предложение по тендеру
+func (c *Client) GetReviewsOnBidAuthorBids(ctx context.Context, tenderId string, filter *ReviewFilter, page Page) ([]entity.ReviewOutputModel, error) {
+	query := url.Values{}
+	filter.setQuery(query)
+	setPage(query, page)
+
+	var reviews []entity.ReviewOutputModel
+	if err := c.do(ctx, http.MethodGet, endpoint("bids", tenderId, "reviews"), query, nil, &reviews); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (c *Client) ReviewsOnBidAuthorBids(ctx context.Context, tenderId string, filter *ReviewFilter) *Iterator[entity.ReviewOutputModel] {
+	return newIterator(ctx, maxPageLimit, func(ctx context.Context, page Page) ([]entity.ReviewOutputModel, error) {
+		return c.GetReviewsOnBidAuthorBids(ctx, tenderId, filter, page)
+	})
+}
@@ -0,0 +1,219 @@
+// Package client - типизированный клиент HTTP API сервиса тендеров. Ответы разбираются в те же
+// модели entity, которые отдает сервер, а ответы с ошибками - в *Error
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTimeout      = 30 * time.Second
+	defaultMaxRetries   = 2
+	defaultRetryBackoff = 200 * time.Millisecond
+	maxRetryDelay       = 10 * time.Second
+	tenantHeader        = "X-Tenant-Id"
+)
+
+type Config struct {
+	// Адрес API вместе с префиксом, например http://localhost:8080/api
+	BaseURL    string
+	HTTPClient *http.Client
+	// Повторы после первой попытки, отрицательное значение отключает их
+	MaxRetries int
+	// Пауза перед первым повтором, дальше она удваивается
+	RetryBackoff time.Duration
+	// Арендатор, которому должен принадлежать сотрудник запроса, передается в X-Tenant-Id.
+	// Сервер выбирает арендатора по сотруднику и отклоняет запрос, если они не совпадают
+	TenantId string
+	// Язык сообщений об ошибках, передается в Accept-Language
+	Language string
+}
+
+type Client struct {
+	baseURL      string
+	httpClient   *http.Client
+	maxRetries   int
+	retryBackoff time.Duration
+	tenantId     string
+	language     string
+}
+
+func New(config Config) *Client {
+	c := &Client{
+		baseURL:      strings.TrimRight(config.BaseURL, "/"),
+		httpClient:   config.HTTPClient,
+		maxRetries:   config.MaxRetries,
+		retryBackoff: config.RetryBackoff,
+		tenantId:     config.TenantId,
+		language:     config.Language,
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	if c.maxRetries == 0 {
+		c.maxRetries = defaultMaxRetries
+	}
+	if c.retryBackoff <= 0 {
+		c.retryBackoff = defaultRetryBackoff
+	}
+
+	return c
+}
+
+// Ping возвращает nil, если сервер готов обрабатывать запросы
+func (c *Client) Ping(ctx context.Context) error {
+	return c.do(ctx, http.MethodGet, "/ping", nil, nil, nil)
+}
+
+// Путь собирается из сегментов, каждый из которых экранируется: id из пользовательского ввода не сломает маршрут
+func endpoint(segments ...string) string {
+	var builder strings.Builder
+	for _, segment := range segments {
+		builder.WriteString("/")
+		builder.WriteString(url.PathEscape(segment))
+	}
+
+	return builder.String()
+}
+
+// Пустые значения не передаются, чтобы сервер применил свои значения по умолчанию
+func setIfNotEmpty(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+func setPage(query url.Values, page Page) {
+	if page.Limit > 0 {
+		query.Set("limit", strconv.Itoa(page.Limit))
+	}
+	if page.Offset > 0 {
+		query.Set("offset", strconv.Itoa(page.Offset))
+	}
+}
+
+// do отправляет запрос с body в формате JSON и разбирает ответ в out, если он не nil
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body any, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("client: encode request: %w", err)
+		}
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	for attempt := 0; ; attempt++ {
+		res, err := c.send(ctx, method, target, payload)
+		if err == nil && res.StatusCode < http.StatusBadRequest {
+			defer res.Body.Close()
+
+			return decodeResponse(res, out)
+		}
+
+		retry := attempt < c.maxRetries && shouldRetry(method, res, err)
+		if !retry {
+			if err != nil {
+				return err
+			}
+			defer res.Body.Close()
+
+			return decodeError(res)
+		}
+
+		delay := c.retryDelay(attempt, res)
+		if res != nil {
+			// тело нужно дочитать, иначе соединение не вернется в пул
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, target string, payload []byte) (*http.Response, error) {
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, fmt.Errorf("client: build request: %w", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json, application/problem+json")
+	if c.language != "" {
+		req.Header.Set("Accept-Language", c.language)
+	}
+	if c.tenantId != "" {
+		req.Header.Set(tenantHeader, c.tenantId)
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// GET повторяется при сетевых ошибках и временной недоступности сервера. Остальные запросы меняют данные,
+// поэтому повторяются только после 429: так сервер сообщает, что запрос не выполнялся
+func shouldRetry(method string, res *http.Response, err error) bool {
+	if err != nil {
+		// отмена или истекший срок контекста - решение вызывающего, а не сбой
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+
+		return method == http.MethodGet
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return method == http.MethodGet
+	}
+
+	return false
+}
+
+func (c *Client) retryDelay(attempt int, res *http.Response) time.Duration {
+	delay := c.retryBackoff << attempt
+	if res != nil {
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			delay = time.Duration(seconds) * time.Second
+		}
+	}
+
+	return min(delay, maxRetryDelay)
+}
+
+func decodeResponse(res *http.Response, out any) error {
+	if out == nil {
+		_, _ = io.Copy(io.Discard, res.Body)
+
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("client: decode response: %w", err)
+	}
+
+	return nil
+}
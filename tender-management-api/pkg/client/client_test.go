@@ -0,0 +1,382 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"tender-management-api/internal/controller"
+	"tender-management-api/internal/entity"
+	"tender-management-api/internal/service"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo"
+)
+
+type tenantServiceStub struct {
+	service.Tenants
+	tenantId uuid.UUID
+}
+
+func (s *tenantServiceStub) ResolveTenant(_ context.Context, _ service.Principal, _ string) (uuid.UUID, error) {
+	return s.tenantId, nil
+}
+
+type diagnosticServiceStub struct{}
+
+func (diagnosticServiceStub) Ping() error {
+	return nil
+}
+
+// Сервис отдает заранее созданные тендеры и запоминает запрошенные страницы
+type tenderServiceStub struct {
+	service.Tender
+	mu      sync.Mutex
+	tenders []entity.TenderOutputModel
+	pages   []Page
+}
+
+func (s *tenderServiceStub) GetPublishedTenders(_ context.Context, _ *entity.TenderFilter, pg *entity.PaginationInput) ([]entity.TenderOutputModel, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pages = append(s.pages, Page{Limit: pg.Limit, Offset: pg.Offset})
+
+	// как и репозиторий, пустую страницу отдает пустым массивом, а не null
+	start, end := min(pg.Offset, len(s.tenders)), min(pg.Offset+pg.Limit, len(s.tenders))
+
+	return append(make([]entity.TenderOutputModel, 0), s.tenders[start:end]...), nil
+}
+
+func (s *tenderServiceStub) GetTenderStatusById(_ context.Context, tenderId string, _ string, _ bool) (string, error) {
+	for _, tender := range s.tenders {
+		if tender.Id == tenderId {
+			return tender.Status, nil
+		}
+	}
+
+	return "", service.ErrTenderNotFound
+}
+
+func (s *tenderServiceStub) CreateTender(_ context.Context, input *entity.CreateTenderInput) (*entity.TenderOutputModel, error) {
+	tender := newTender(len(s.tenders))
+	tender.Name, tender.Description, tender.ServiceType = input.Name, input.Description, input.ServiceType
+	tender.OrganizationId, tender.Status = input.OrganizationId, "Created"
+
+	return &tender, nil
+}
+
+func newTender(n int) entity.TenderOutputModel {
+	return entity.TenderOutputModel{
+		Id:             uuid.NewString(),
+		Name:           "Tender " + strconv.Itoa(n),
+		Description:    "Delivery of goods",
+		ServiceType:    "Delivery",
+		Status:         "Published",
+		OrganizationId: uuid.NewString(),
+		Version:        1,
+		CreatedAt:      time.Now().UTC().Format(time.RFC3339),
+		ConflictPolicy: "Flag",
+	}
+}
+
+// fault - ответ, который прокси перед роутером отдает вместо сервера. Нулевой fault пропускает запрос к роутеру
+type fault struct {
+	status      int
+	retryAfter  string
+	contentType string
+	body        string
+}
+
+// testServer - роутер echo с заглушками сервисов за прокси, который отвечает заданными сбоями по очереди
+type testServer struct {
+	*httptest.Server
+	tenders *tenderServiceStub
+	mu      sync.Mutex
+	faults  []fault
+	calls   int
+}
+
+func newTestServer(t *testing.T, count int) *testServer {
+	t.Helper()
+	tenders := &tenderServiceStub{}
+	for i := 0; i < count; i++ {
+		tenders.tenders = append(tenders.tenders, newTender(i))
+	}
+
+	router := echo.New()
+	services := &service.Services{
+		Tender:      tenders,
+		Tenants:     &tenantServiceStub{tenantId: uuid.New()},
+		Diagnostics: diagnosticServiceStub{},
+	}
+	// ответы сверяются с контрактом, поэтому заглушки не могут разойтись с тем, что отдает сервер
+	if err := controller.SetupRoutesHandlers(router, services, true); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &testServer{tenders: tenders}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.calls++
+		var next fault
+		if len(s.faults) > 0 {
+			next, s.faults = s.faults[0], s.faults[1:]
+		}
+		s.mu.Unlock()
+
+		if next.status == 0 {
+			router.ServeHTTP(w, r)
+
+			return
+		}
+		if next.retryAfter != "" {
+			w.Header().Set("Retry-After", next.retryAfter)
+		}
+		if next.contentType != "" {
+			w.Header().Set("Content-Type", next.contentType)
+		}
+		w.WriteHeader(next.status)
+		_, _ = w.Write([]byte(next.body))
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *testServer) fail(faults ...fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, faults...)
+}
+
+func (s *testServer) requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls
+}
+
+func (s *testServer) client(config Config) *Client {
+	config.BaseURL = s.URL + "/api"
+	if config.RetryBackoff == 0 {
+		config.RetryBackoff = time.Millisecond
+	}
+
+	return New(config)
+}
+
+func newCreateTenderRequest() *CreateTenderRequest {
+	return &CreateTenderRequest{
+		Name:            "Office chairs",
+		Description:     "Twenty office chairs",
+		ServiceType:     "Delivery",
+		OrganizationId:  uuid.NewString(),
+		CreatorUsername: "user1",
+	}
+}
+
+func TestClientRetriesGetOnUnavailableServer(t *testing.T) {
+	s := newTestServer(t, 1)
+	s.fail(fault{status: http.StatusServiceUnavailable}, fault{status: http.StatusBadGateway})
+
+	status, err := s.client(Config{}).GetTenderStatus(context.Background(), s.tenders.tenders[0].Id, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != "Published" {
+		t.Errorf("status = %q, want Published", status)
+	}
+	if got := s.requests(); got != 3 {
+		t.Errorf("requests = %d, want 3", got)
+	}
+}
+
+func TestClientDoesNotRetryWriteOnUnavailableServer(t *testing.T) {
+	s := newTestServer(t, 0)
+	s.fail(fault{status: http.StatusServiceUnavailable})
+
+	_, err := s.client(Config{}).CreateTender(context.Background(), newCreateTenderRequest())
+	if !errors.Is(err, &Error{Status: http.StatusServiceUnavailable}) {
+		t.Errorf("error = %v, want 503", err)
+	}
+	// сервер мог создать тендер до сбоя, повтор создал бы второй
+	if got := s.requests(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestClientRetriesWriteOnTooManyRequests(t *testing.T) {
+	s := newTestServer(t, 0)
+	s.fail(fault{status: http.StatusTooManyRequests, retryAfter: "0"})
+
+	tender, err := s.client(Config{}).CreateTender(context.Background(), newCreateTenderRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tender.Status != "Created" {
+		t.Errorf("status = %q, want Created", tender.Status)
+	}
+	if got := s.requests(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
+
+func TestClientStopsAfterMaxRetries(t *testing.T) {
+	cases := []struct {
+		name       string
+		maxRetries int
+		want       int
+	}{
+		{"default", 0, defaultMaxRetries + 1},
+		{"configured", 3, 4},
+		{"disabled", -1, 1},
+	}
+	for _, c := range cases {
+		s := newTestServer(t, 0)
+		for i := 0; i < 10; i++ {
+			s.fail(fault{status: http.StatusServiceUnavailable})
+		}
+
+		err := s.client(Config{MaxRetries: c.maxRetries}).Ping(context.Background())
+		if !errors.Is(err, &Error{Status: http.StatusServiceUnavailable}) {
+			t.Errorf("%s: error = %v, want 503", c.name, err)
+		}
+		if got := s.requests(); got != c.want {
+			t.Errorf("%s: requests = %d, want %d", c.name, got, c.want)
+		}
+	}
+}
+
+func TestClientWaitsForRetryAfter(t *testing.T) {
+	s := newTestServer(t, 0)
+	s.fail(fault{status: http.StatusTooManyRequests, retryAfter: "1"})
+
+	started := time.Now()
+	if err := s.client(Config{}).Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(started); elapsed < time.Second {
+		t.Errorf("retried after %s, want at least 1s from Retry-After", elapsed)
+	}
+}
+
+func TestClientStopsWaitingWhenContextEnds(t *testing.T) {
+	s := newTestServer(t, 0)
+	s.fail(fault{status: http.StatusTooManyRequests, retryAfter: "5"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	err := s.client(Config{}).Ping(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(started); elapsed >= time.Second {
+		t.Errorf("waited %s after the context ended", elapsed)
+	}
+	if got := s.requests(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestClientMapsProblemsToErrors(t *testing.T) {
+	s := newTestServer(t, 0)
+	ctx := context.Background()
+
+	_, err := s.client(Config{Language: "ru"}).GetTenderStatus(ctx, uuid.NewString(), "")
+	if !errors.Is(err, ErrTenderNotFound) || !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown tender: error = %v, want ErrTenderNotFound and ErrNotFound", err)
+	}
+	if errors.Is(err, ErrBidNotFound) {
+		t.Errorf("unknown tender matches ErrBidNotFound")
+	}
+	var problem *Error
+	if !errors.As(err, &problem) || problem.Detail != "Тендер с таким id не найден" {
+		t.Errorf("unknown tender: detail is not translated: %v", err)
+	}
+
+	// сервер отдает не больше 50 тендеров, поэтому контракт отклоняет больший limit
+	_, err = s.client(Config{}).GetTenders(ctx, "", nil, Page{Limit: maxPageLimit + 1})
+	if !errors.Is(err, ErrValidationFailed) || !errors.Is(err, ErrBadRequest) {
+		t.Errorf("limit above maximum: error = %v, want ErrValidationFailed and ErrBadRequest", err)
+	}
+	if !errors.As(err, &problem) || len(problem.Errors) == 0 || problem.Errors[0].Field != "limit" {
+		t.Errorf("limit above maximum: no field error for limit: %v", err)
+	}
+
+	// страница ошибки прокси не в формате problem+json
+	s.fail(fault{status: http.StatusBadGateway, contentType: "text/html", body: "<html>" + strings.Repeat("bad gateway ", 100) + "</html>"})
+	_, err = s.client(Config{}).CreateTender(ctx, newCreateTenderRequest())
+	if !errors.As(err, &problem) || problem.Status != http.StatusBadGateway || problem.Code != "" {
+		t.Fatalf("proxy error: error = %v, want 502 without code", err)
+	}
+	if !strings.HasPrefix(problem.Detail, "<html>bad gateway") || len(problem.Detail) != maxErrorBodySnippet {
+		t.Errorf("proxy error: detail = %q, want the first %d bytes of the body", problem.Detail, maxErrorBodySnippet)
+	}
+}
+
+func TestIteratorWalksAllPages(t *testing.T) {
+	cases := []struct {
+		name  string
+		count int
+		pages []Page
+	}{
+		{"last page is partial", 120, []Page{{50, 0}, {50, 50}, {50, 100}}},
+		// по полной странице не понять, что она последняя, поэтому нужен еще один запрос
+		{"last page is full", 100, []Page{{50, 0}, {50, 50}, {50, 100}}},
+		{"empty list", 0, []Page{{50, 0}}},
+	}
+	for _, c := range cases {
+		s := newTestServer(t, c.count)
+
+		it := s.client(Config{}).Tenders(context.Background(), "", nil)
+		var ids []string
+		for it.Next() {
+			ids = append(ids, it.Value().Id)
+		}
+		if err := it.Err(); err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+
+		if len(ids) != c.count {
+			t.Errorf("%s: got %d tenders, want %d", c.name, len(ids), c.count)
+		}
+		for i, id := range ids {
+			if id != s.tenders.tenders[i].Id {
+				t.Errorf("%s: tender %d is out of order", c.name, i)
+
+				break
+			}
+		}
+		if got := s.tenders.pages; !slices.Equal(got, c.pages) {
+			t.Errorf("%s: pages = %v, want %v", c.name, got, c.pages)
+		}
+	}
+}
+
+func TestIteratorStopsOnError(t *testing.T) {
+	s := newTestServer(t, 80)
+	s.fail(fault{}, fault{status: http.StatusNotFound, contentType: "application/problem+json", body: `{"code":"tender_not_found"}`})
+
+	it := s.client(Config{}).Tenders(context.Background(), "", nil)
+	read := 0
+	for it.Next() {
+		read++
+	}
+	if read != maxPageLimit {
+		t.Errorf("read %d tenders before the error, want %d", read, maxPageLimit)
+	}
+	if !errors.Is(it.Err(), ErrTenderNotFound) {
+		t.Errorf("error = %v, want ErrTenderNotFound", it.Err())
+	}
+	if it.Next() {
+		t.Error("Next continues after an error")
+	}
+}
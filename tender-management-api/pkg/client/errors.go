@@ -0,0 +1,110 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Сколько тела ответа без problem+json (например, страницы ошибки прокси) попадет в Detail
+const maxErrorBodySnippet = 512
+
+// Error - ответ сервера с ошибкой в формате RFC 7807. Code стабилен и не зависит от языка сообщений,
+// поэтому ошибки различают по нему: errors.Is(err, client.ErrTenderNotFound)
+type Error struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail"`
+	Instance string         `json:"instance"`
+	Code     string         `json:"code"`
+	Details  map[string]any `json:"details"`
+	Errors   []FieldError   `json:"errors"`
+}
+
+// FieldError - значение запроса, не прошедшее валидацию
+type FieldError struct {
+	Field   string `json:"field"`
+	In      string `json:"in"`
+	Pointer string `json:"pointer"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	var builder strings.Builder
+	builder.WriteString(http.StatusText(e.Status))
+	if e.Code != "" {
+		builder.WriteString(" (" + e.Code + ")")
+	}
+	if e.Detail != "" {
+		builder.WriteString(": " + e.Detail)
+	}
+	for _, fe := range e.Errors {
+		builder.WriteString("; " + fe.Field + ": " + fe.Message)
+	}
+
+	return builder.String()
+}
+
+// Ошибки с кодом совпадают по коду, ошибки без кода (ErrNotFound и другие) - по HTTP-статусу
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	if t.Code != "" {
+		return t.Code == e.Code
+	}
+
+	return t.Status == e.Status
+}
+
+var (
+	ErrBadRequest           = &Error{Status: http.StatusBadRequest}
+	ErrUnauthorized         = &Error{Status: http.StatusUnauthorized}
+	ErrForbidden            = &Error{Status: http.StatusForbidden}
+	ErrNotFound             = &Error{Status: http.StatusNotFound}
+	ErrConflict             = &Error{Status: http.StatusConflict}
+	ErrTooManyRequests      = &Error{Status: http.StatusTooManyRequests}
+	ErrInternalServerError  = &Error{Status: http.StatusInternalServerError}
+	ErrValidationFailed     = &Error{Code: "validation_failed"}
+	ErrMalformedInput       = &Error{Code: "malformed_input"}
+	ErrUsernameRequired     = &Error{Code: "username_required"}
+	ErrBidUpdatesRequired   = &Error{Code: "bid_updates_required"}
+	ErrTenderNotFound       = &Error{Code: "tender_not_found"}
+	ErrBidNotFound          = &Error{Code: "bid_not_found"}
+	ErrUserNotFound         = &Error{Code: "user_not_found"}
+	ErrEmployeeNotFound     = &Error{Code: "employee_not_found"}
+	ErrOrganizationNotFound = &Error{Code: "organization_not_found"}
+	ErrNoAccessToTender     = &Error{Code: "user_has_no_access_to_tender"}
+	ErrNoAccessToBid        = &Error{Code: "user_has_no_access_to_bid"}
+	ErrNoNewChanges         = &Error{Code: "no_new_changes"}
+	ErrNoSuchVersion        = &Error{Code: "no_such_version"}
+)
+
+func decodeError(res *http.Response) error {
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return &Error{Status: res.StatusCode, Title: http.StatusText(res.StatusCode)}
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	if mediaType == "application/problem+json" || mediaType == "application/json" {
+		problem := &Error{}
+		if json.Unmarshal(body, problem) == nil {
+			// статус ответа важнее, чем поле в теле, которое мог не заполнить промежуточный прокси
+			problem.Status = res.StatusCode
+
+			return problem
+		}
+	}
+
+	detail := strings.TrimSpace(string(body))
+	if len(detail) > maxErrorBodySnippet {
+		detail = detail[:maxErrorBodySnippet]
+	}
+
+	return &Error{Status: res.StatusCode, Title: http.StatusText(res.StatusCode), Detail: detail}
+}
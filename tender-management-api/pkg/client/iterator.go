@@ -0,0 +1,86 @@
+package client
+
+import "context"
+
+// Сервер отдает не больше 50 объектов за запрос
+const maxPageLimit = 50
+
+// Page - параметры пагинации. Нулевые значения не передаются, и сервер применяет свои (limit=5, offset=0)
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+type pageFetcher[T any] func(ctx context.Context, page Page) ([]T, error)
+
+// Iterator обходит все страницы списка, запрашивая следующую, когда текущая закончилась:
+//
+//	it := c.Tenders(ctx, "", nil)
+//	for it.Next() {
+//		tender := it.Value()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch pageFetcher[T]
+	page  Page
+	items []T
+	index int
+	done  bool
+	err   error
+}
+
+// pageSize больше 50 или не больше 0 заменяется на 50
+func newIterator[T any](ctx context.Context, pageSize int, fetch pageFetcher[T]) *Iterator[T] {
+	if pageSize <= 0 || pageSize > maxPageLimit {
+		pageSize = maxPageLimit
+	}
+
+	return &Iterator[T]{ctx: ctx, fetch: fetch, page: Page{Limit: pageSize}, index: -1}
+}
+
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.index+1 < len(it.items) {
+		it.index++
+
+		return true
+	}
+	if it.done {
+		return false
+	}
+
+	items, err := it.fetch(it.ctx, it.page)
+	if err != nil {
+		it.err = err
+
+		return false
+	}
+	// неполная страница - последняя, лишний пустой запрос не нужен
+	it.done = len(items) < it.page.Limit
+	it.page.Offset += len(items)
+	it.items, it.index = items, 0
+
+	return len(items) > 0
+}
+
+// Value возвращает объект, на котором остановился Next
+func (it *Iterator[T]) Value() T {
+	return it.items[it.index]
+}
+
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All собирает оставшиеся объекты в срез
+func (it *Iterator[T]) All() ([]T, error) {
+	all := make([]T, 0)
+	for it.Next() {
+		all = append(all, it.Value())
+	}
+
+	return all, it.Err()
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"tender-management-api/internal/entity"
+	"time"
+)
+
+type TenderFilter struct {
+	ServiceTypes []string
+	Tags         []string
+	// Каждое условие code -> value должно выполняться
+	Attributes map[string]string
+}
+
+type UserTenderFilter struct {
+	TenderFilter
+	// Без организации возвращаются тендеры всех организаций сотрудника
+	OrganizationId string
+}
+
+type CreateTenderRequest struct {
+	Name              string            `json:"name"`
+	Description       string            `json:"description"`
+	ServiceType       string            `json:"serviceType"`
+	OrganizationId    string            `json:"organizationId"`
+	CreatorUsername   string            `json:"creatorUsername"`
+	Deadline          *time.Time        `json:"deadline,omitempty"`
+	Tags              []string          `json:"tags,omitempty"`
+	Attributes        map[string]string `json:"attributes,omitempty"`
+	RequiresSignature bool              `json:"requiresSignature,omitempty"`
+	// Flag или Block, по умолчанию Flag
+	ConflictPolicy string `json:"conflictPolicy,omitempty"`
+}
+
+// Передаются только заполненные поля, остальные остаются прежними
+type EditTenderRequest struct {
+	Name        string            `json:"name,omitempty"`
+	Description string            `json:"description,omitempty"`
+	ServiceType string            `json:"serviceType,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+}
+
+func (f *TenderFilter) setQuery(query url.Values) {
+	if f == nil {
+		return
+	}
+	for _, serviceType := range f.ServiceTypes {
+		query.Add("service_type", serviceType)
+	}
+	for _, tag := range f.Tags {
+		query.Add("tag", tag)
+	}
+	// порядок условий не важен серверу, но стабильный запрос проще искать в логах
+	codes := make([]string, 0, len(f.Attributes))
+	for code := range f.Attributes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		query.Add("attribute", code+":"+f.Attributes[code])
+	}
+}
+
+// GetTenders возвращает страницу опубликованных тендеров арендатора сотрудника username
+func (c *Client) GetTenders(ctx context.Context, username string, filter *TenderFilter, page Page) ([]entity.TenderOutputModel, error) {
+	query := url.Values{}
+	setIfNotEmpty(query, "username", username)
+	filter.setQuery(query)
+	setPage(query, page)
+
+	var tenders []entity.TenderOutputModel
+	if err := c.do(ctx, http.MethodGet, "/tenders", query, nil, &tenders); err != nil {
+		return nil, err
+	}
+
+	return tenders, nil
+}
+
+// Tenders обходит все опубликованные тендеры
+func (c *Client) Tenders(ctx context.Context, username string, filter *TenderFilter) *Iterator[entity.TenderOutputModel] {
+	return newIterator(ctx, maxPageLimit, func(ctx context.Context, page Page) ([]entity.TenderOutputModel, error) {
+		return c.GetTenders(ctx, username, filter, page)
+	})
+}
+
+func (c *Client) CreateTender(ctx context.Context, input *CreateTenderRequest) (*entity.TenderOutputModel, error) {
+	var tender entity.TenderOutputModel
+	if err := c.do(ctx, http.MethodPost, "/tenders/new", nil, input, &tender); err != nil {
+		return nil, err
+	}
+
+	return &tender, nil
+}
+
+// GetUserTenders возвращает страницу тендеров организаций сотрудника
+func (c *Client) GetUserTenders(ctx context.Context, username string, filter *UserTenderFilter, page Page) ([]entity.TenderOutputModel, error) {
+	query := url.Values{}
+	setIfNotEmpty(query, "username", username)
+	if filter != nil {
+		filter.TenderFilter.setQuery(query)
+		setIfNotEmpty(query, "organizationId", filter.OrganizationId)
+	}
+	setPage(query, page)
+
+	var tenders []entity.TenderOutputModel
+	if err := c.do(ctx, http.MethodGet, "/tenders/my", query, nil, &tenders); err != nil {
+		return nil, err
+	}
+
+	return tenders, nil
+}
+
+// UserTenders обходит все тендеры организаций сотрудника
+func (c *Client) UserTenders(ctx context.Context, username string, filter *UserTenderFilter) *Iterator[entity.TenderOutputModel] {
+	return newIterator(ctx, maxPageLimit, func(ctx context.Context, page Page) ([]entity.TenderOutputModel, error) {
+		return c.GetUserTenders(ctx, username, filter, page)
+	})
+}
+
+// Без username доступен статус только опубликованного тендера
+func (c *Client) GetTenderStatus(ctx context.Context, tenderId string, username string) (string, error) {
+	query := url.Values{}
+	setIfNotEmpty(query, "username", username)
+
+	var status string
+	if err := c.do(ctx, http.MethodGet, endpoint("tenders", tenderId, "status"), query, nil, &status); err != nil {
+		return "", err
+	}
+
+	return status, nil
+}
+
+func (c *Client) UpdateTenderStatus(ctx context.Context, tenderId string, status string, username string) (*entity.TenderOutputModel, error) {
+	query := url.Values{"status": {status}, "username": {username}}
+
+	var tender entity.TenderOutputModel
+	if err := c.do(ctx, http.MethodPut, endpoint("tenders", tenderId, "status"), query, nil, &tender); err != nil {
+		return nil, err
+	}
+
+	return &tender, nil
+}
+
+func (c *Client) EditTender(ctx context.Context, tenderId string, username string, input *EditTenderRequest) (*entity.TenderOutputModel, error) {
+	query := url.Values{"username": {username}}
+
+	var tender entity.TenderOutputModel
+	if err := c.do(ctx, http.MethodPatch, endpoint("tenders", tenderId, "edit"), query, input, &tender); err != nil {
+		return nil, err
+	}
+
+	return &tender, nil
+}
+
+// RollbackTender создает новую версию тендера с параметрами версии version
+func (c *Client) RollbackTender(ctx context.Context, tenderId string, version int, username string) (*entity.TenderOutputModel, error) {
+	query := url.Values{"username": {username}}
+
+	var tender entity.TenderOutputModel
+	path := endpoint("tenders", tenderId, "rollback", strconv.Itoa(version))
+	if err := c.do(ctx, http.MethodPut, path, query, nil, &tender); err != nil {
+		return nil, err
+	}
+
+	return &tender, nil
+}
+
+func (c *Client) CloneTender(ctx context.Context, tenderId string, username string) (*entity.TenderOutputModel, error) {
+	query := url.Values{"username": {username}}
+
+	var tender entity.TenderOutputModel
+	if err := c.do(ctx, http.MethodPost, endpoint("tenders", tenderId, "clone"), query, nil, &tender); err != nil {
+		return nil, err
+	}
+
+	return &tender, nil
+}
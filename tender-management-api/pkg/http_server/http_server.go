@@ -38,6 +38,12 @@ func (s *Server) Notify() <-chan error {
 	return s.notify
 }
 
+// Shutdown не прерывает активные соединения, поэтому долгоживущие обработчики (например, SSE)
+// должны завершаться сами по сигналу, зарегистрированному здесь
+func (s *Server) RegisterOnShutdown(f func()) {
+	s.server.RegisterOnShutdown(f)
+}
+
 func (s *Server) Shutdown() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -0,0 +1,113 @@
+package mailer
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+var ErrQueueIsFull = errors.New("mail queue is full")
+
+type QueueConfig struct {
+	Workers     int
+	Capacity    int
+	MaxAttempts int
+	BaseDelay   time.Duration
+}
+
+func DefaultQueueConfig() QueueConfig {
+	return QueueConfig{
+		Workers:     2,
+		Capacity:    256,
+		MaxAttempts: 5,
+		BaseDelay:   time.Second,
+	}
+}
+
+// Queue отправляет письма в фоне. Неудачная отправка повторяется с экспоненциальной задержкой
+type Queue struct {
+	sender   Sender
+	config   QueueConfig
+	messages chan *Message
+	done     chan struct{}
+	wg       sync.WaitGroup
+	once     sync.Once
+}
+
+func NewQueue(sender Sender, config QueueConfig) *Queue {
+	q := &Queue{
+		sender:   sender,
+		config:   config,
+		messages: make(chan *Message, config.Capacity),
+		done:     make(chan struct{}),
+	}
+
+	for range config.Workers {
+		q.wg.Add(1)
+		go q.work()
+	}
+
+	return q
+}
+
+func (q *Queue) Enqueue(message *Message) error {
+	select {
+	case <-q.done:
+		return ErrQueueIsFull
+	default:
+	}
+
+	select {
+	case q.messages <- message:
+		return nil
+	default:
+		return ErrQueueIsFull
+	}
+}
+
+func (q *Queue) work() {
+	defer q.wg.Done()
+
+	for {
+		select {
+		case message := <-q.messages:
+			q.send(message)
+		case <-q.done:
+			return
+		}
+	}
+}
+
+func (q *Queue) send(message *Message) {
+	delay := q.config.BaseDelay
+	for attempt := 1; ; attempt++ {
+		err := q.sender.Send(message)
+		if err == nil {
+			return
+		}
+
+		if attempt >= q.config.MaxAttempts {
+			log.Printf("Failed to send email to %s after %d attempts: %s", message.To, attempt, err.Error())
+
+			return
+		}
+
+		select {
+		case <-time.After(delay):
+			delay *= 2
+		case <-q.done:
+			log.Printf("Email to %s dropped on shutdown: %s", message.To, err.Error())
+
+			return
+		}
+	}
+}
+
+// Close останавливает воркеров. Письма, ещё не взятые из очереди, теряются
+func (q *Queue) Close() {
+	q.once.Do(func() {
+		close(q.done)
+	})
+	q.wg.Wait()
+}
@@ -0,0 +1,187 @@
+package mailer
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testFrom = "noreply@tenders.local"
+
+func newTestStub(t *testing.T) *StubServer {
+	t.Helper()
+
+	stub, err := NewStubServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("start stub SMTP server: %v", err)
+	}
+	t.Cleanup(func() { _ = stub.Close() })
+
+	return stub
+}
+
+func newTestSender(t *testing.T, address string) *SMTPSender {
+	t.Helper()
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		t.Fatalf("split address %s: %v", address, err)
+	}
+
+	return NewSMTPSender(host, port, "", "", testFrom)
+}
+
+func newTestQueue(t *testing.T, sender Sender, maxAttempts int) *Queue {
+	t.Helper()
+
+	queue := NewQueue(sender, QueueConfig{Workers: 2, Capacity: 16, MaxAttempts: maxAttempts, BaseDelay: time.Millisecond})
+	t.Cleanup(queue.Close)
+
+	return queue
+}
+
+// Очередь отправляет письма в фоне, поэтому результат ждем с таймаутом
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// countingSender считает попытки и возвращает ошибку первые failures раз, не обращаясь к SMTP
+type countingSender struct {
+	Sender
+	mu       sync.Mutex
+	attempts int
+	failures int
+}
+
+func (s *countingSender) Send(message *Message) error {
+	s.mu.Lock()
+	s.attempts++
+	fail := s.attempts <= s.failures
+	s.mu.Unlock()
+
+	if fail {
+		return errors.New("temporary failure")
+	}
+
+	return s.Sender.Send(message)
+}
+
+func (s *countingSender) Attempts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.attempts
+}
+
+func TestQueueDeliversToEveryRecipient(t *testing.T) {
+	stub := newTestStub(t)
+	queue := newTestQueue(t, newTestSender(t, stub.Addr()), 1)
+
+	messages := []*Message{
+		{To: "alice@example.com", Subject: "Your bid was approved", Text: "Hello, alice!\n", HTML: "<p>Hello, alice!</p>"},
+		{To: "boris@example.com", Subject: "Ваше предложение одобрено", Text: "Здравствуйте, boris!\n", HTML: "<p>Здравствуйте, boris!</p>"},
+	}
+	for _, message := range messages {
+		if err := queue.Enqueue(message); err != nil {
+			t.Fatalf("enqueue to %s: %v", message.To, err)
+		}
+	}
+	waitFor(t, "two delivered emails", func() bool { return len(stub.Messages()) == 2 })
+
+	received := make(map[string]*Message)
+	for _, stubMessage := range stub.Messages() {
+		if stubMessage.From != testFrom {
+			t.Errorf("MAIL FROM = %q, want %q", stubMessage.From, testFrom)
+		}
+		if len(stubMessage.To) != 1 {
+			t.Fatalf("RCPT TO = %v, want one recipient", stubMessage.To)
+		}
+
+		message, err := stubMessage.Message()
+		if err != nil {
+			t.Fatalf("parse email to %v: %v", stubMessage.To, err)
+		}
+		if message.To != stubMessage.To[0] {
+			t.Errorf("To header = %q, RCPT TO = %q", message.To, stubMessage.To[0])
+		}
+		received[message.To] = message
+	}
+
+	for _, want := range messages {
+		got, ok := received[want.To]
+		if !ok {
+			t.Errorf("no email delivered to %s", want.To)
+
+			continue
+		}
+		if got.Subject != want.Subject {
+			t.Errorf("subject to %s = %q, want %q", want.To, got.Subject, want.Subject)
+		}
+		// quoted-printable кодирует переводы строк как CRLF
+		if text := strings.ReplaceAll(got.Text, "\r\n", "\n"); text != want.Text {
+			t.Errorf("text to %s = %q, want %q", want.To, text, want.Text)
+		}
+		if got.HTML != want.HTML {
+			t.Errorf("html to %s = %q, want %q", want.To, got.HTML, want.HTML)
+		}
+	}
+}
+
+func TestQueueRetriesFailedSend(t *testing.T) {
+	stub := newTestStub(t)
+	sender := &countingSender{Sender: newTestSender(t, stub.Addr()), failures: 2}
+	queue := newTestQueue(t, sender, 3)
+
+	if err := queue.Enqueue(&Message{To: "alice@example.com", Subject: "Retry", Text: "body"}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	waitFor(t, "delivered email", func() bool { return len(stub.Messages()) == 1 })
+
+	if attempts := sender.Attempts(); attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestQueueGivesUpAfterMaxAttempts(t *testing.T) {
+	// адрес, на котором никто не слушает: каждая попытка заканчивается ошибкой соединения
+	stub := newTestStub(t)
+	address := stub.Addr()
+	if err := stub.Close(); err != nil {
+		t.Fatalf("close stub: %v", err)
+	}
+
+	sender := &countingSender{Sender: newTestSender(t, address)}
+	queue := newTestQueue(t, sender, 3)
+
+	if err := queue.Enqueue(&Message{To: "alice@example.com", Subject: "Lost", Text: "body"}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	waitFor(t, "three attempts", func() bool { return sender.Attempts() == 3 })
+
+	// после последней попытки письмо отбрасывается, новых попыток нет
+	time.Sleep(50 * time.Millisecond)
+	if attempts := sender.Attempts(); attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestQueueRejectsMessagesAfterClose(t *testing.T) {
+	stub := newTestStub(t)
+	queue := NewQueue(newTestSender(t, stub.Addr()), QueueConfig{Workers: 1, Capacity: 1, MaxAttempts: 1})
+	queue.Close()
+
+	if err := queue.Enqueue(&Message{To: "alice@example.com"}); !errors.Is(err, ErrQueueIsFull) {
+		t.Errorf("Enqueue after Close = %v, want ErrQueueIsFull", err)
+	}
+}
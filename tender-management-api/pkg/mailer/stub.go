@@ -0,0 +1,204 @@
+package mailer
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+)
+
+// StubServer -- минимальный SMTP-сервер в том же процессе, который принимает все письма и хранит их в памяти.
+// Используется вместо настоящего SMTP-сервера при локальной разработке и проверках
+type StubServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	messages []StubMessage
+	wg       sync.WaitGroup
+}
+
+type StubMessage struct {
+	From string
+	To   []string
+	Data string
+}
+
+// Message разбирает принятое письмо обратно: тема и части multipart/alternative возвращаются раскодированными
+func (m StubMessage) Message() (*Message, error) {
+	parsed, err := mail.ReadMessage(strings.NewReader(m.Data))
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		return nil, err
+	}
+	_, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+
+	message := &Message{To: parsed.Header.Get("To"), Subject: subject}
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		// quoted-printable части раскодирует сам multipart.Reader
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return message, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		mediaType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if err != nil {
+			return nil, err
+		}
+		switch mediaType {
+		case "text/plain":
+			message.Text = string(content)
+		case "text/html":
+			message.HTML = string(content)
+		}
+	}
+}
+
+func NewStubServer(address string) (*StubServer, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &StubServer{listener: listener}
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+func (s *StubServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *StubServer) Messages() []StubMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages := make([]StubMessage, len(s.messages))
+	copy(messages, s.messages)
+
+	return messages
+}
+
+func (s *StubServer) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+
+	return err
+}
+
+func (s *StubServer) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *StubServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) bool {
+		_, err := conn.Write([]byte(line + "\r\n"))
+
+		return err == nil
+	}
+
+	if !reply("220 localhost stub SMTP ready") {
+		return
+	}
+
+	var message StubMessage
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			message = StubMessage{From: extractAddress(line)}
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			message.To = append(message.To, extractAddress(line))
+			reply("250 OK")
+		case command == "DATA":
+			if !reply("354 End data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			data, err := readData(reader)
+			if err != nil {
+				return
+			}
+			message.Data = data
+			s.mu.Lock()
+			s.messages = append(s.messages, message)
+			s.mu.Unlock()
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 Bye")
+
+			return
+		case command == "RSET", command == "NOOP":
+			reply("250 OK")
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func readData(reader *bufio.Reader) (string, error) {
+	var builder strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		if line == ".\r\n" || line == ".\n" {
+			return builder.String(), nil
+		}
+		builder.WriteString(strings.TrimPrefix(line, "."))
+	}
+}
+
+func extractAddress(line string) string {
+	start, end := strings.Index(line, "<"), strings.LastIndex(line, ">")
+	if start == -1 || end <= start {
+		return ""
+	}
+
+	return line[start+1 : end]
+}
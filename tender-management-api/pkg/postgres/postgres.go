@@ -8,7 +8,7 @@ import (
 )
 
 type Postgres struct {
-	Database   *sql.DB
+	Database   *DB
 	SqlBuilder squirrel.StatementBuilderType
 }
 
@@ -20,14 +20,14 @@ func NewDB(url string) (*Postgres, error) {
 	}
 
 	return &Postgres{
-		Database:   db,
+		Database:   &DB{pool: db},
 		SqlBuilder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
 	}, nil
 }
 
 func (p *Postgres) Close() error {
 	if p.Database != nil {
-		err := p.Database.Close()
+		err := p.Database.pool.Close()
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"tender-management-api/pkg/tenant"
+)
+
+// DB выполняет каждый запрос в отдельной транзакции, в которой app.tenant_id равен арендатору
+// из контекста. На этой настройке построены политики RLS, поэтому без арендатора в контексте
+// запрос не видит ни одной строки изолированных таблиц
+type DB struct {
+	pool *sql.DB
+}
+
+// Pool - соединения без арендатора, только для миграций и служебных запросов к каталогу базы
+func (db *DB) Pool() *sql.DB {
+	return db.pool
+}
+
+// Суперпользователь и роли с BYPASSRLS не подчиняются политикам RLS, и данные арендаторов для них не изолированы
+func (db *DB) RowSecurityBypassed(ctx context.Context) (bool, error) {
+	var bypassed bool
+	err := db.pool.QueryRowContext(ctx, "select rolsuper or rolbypassrls from pg_roles where rolname = current_user").Scan(&bypassed)
+
+	return bypassed, err
+}
+
+func (db *DB) Ping() error {
+	return db.pool.Ping()
+}
+
+func (db *DB) PingContext(ctx context.Context) error {
+	return db.pool.PingContext(ctx)
+}
+
+// Настройка задается с is_local = true и сбрасывается вместе с транзакцией,
+// поэтому соединение возвращается в пул без арендатора
+func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	tx, err := db.pool.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantId, ok := tenant.FromContext(ctx)
+	if !ok {
+		return tx, nil
+	}
+
+	if _, err = tx.ExecContext(ctx, "SELECT set_config('app.tenant_id', $1, true)", tenantId.String()); err != nil {
+		if e := tx.Rollback(); e != nil {
+			return nil, e
+		}
+
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+func (db *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return nil, e
+		}
+
+		return nil, err
+	}
+
+	return result, tx.Commit()
+}
+
+// Транзакция запроса остается открытой, пока строки не закрыты
+func (db *DB) QueryContext(ctx context.Context, query string, args ...any) (*Rows, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		if e := tx.Rollback(); e != nil {
+			return nil, e
+		}
+
+		return nil, err
+	}
+
+	return &Rows{Rows: rows, tx: tx}, nil
+}
+
+// Транзакция запроса завершается в Scan, поэтому результат нужно прочитать
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...any) *Row {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return &Row{err: err}
+	}
+
+	return &Row{row: tx.QueryRowContext(ctx, query, args...), tx: tx}
+}
+
+type Rows struct {
+	*sql.Rows
+	tx *sql.Tx
+}
+
+// Close фиксирует транзакцию, если строки прочитаны без ошибок: запрос мог быть INSERT ... RETURNING
+func (r *Rows) Close() error {
+	if err := r.Rows.Close(); err != nil {
+		_ = r.tx.Rollback()
+
+		return err
+	}
+	if r.Rows.Err() != nil {
+		return r.tx.Rollback()
+	}
+
+	return r.tx.Commit()
+}
+
+type Row struct {
+	row *sql.Row
+	tx  *sql.Tx
+	err error
+}
+
+func (r *Row) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	// ошибку отката не возвращаем, чтобы вызывающий код мог проверить sql.ErrNoRows
+	if err := r.row.Scan(dest...); err != nil {
+		_ = r.tx.Rollback()
+
+		return err
+	}
+
+	return r.tx.Commit()
+}
@@ -0,0 +1,89 @@
+package pubsub
+
+import (
+	"sync"
+)
+
+type Broker[T any] struct {
+	mu          sync.Mutex
+	subscribers map[chan T]struct{}
+	done        chan struct{}
+	closed      bool
+}
+
+func New[T any]() *Broker[T] {
+	return &Broker[T]{
+		subscribers: make(map[chan T]struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// Publish не блокируется: подписчик, который не успевает читать, отключается. Его канал закрывается
+// после уже накопленных сообщений, так что подписчик видит, на чем остановился, и узнает о пропуске:
+// канал закрыт, а Done еще нет
+func (b *Broker[T]) Publish(message T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- message:
+		default:
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (b *Broker[T]) Subscribe(buffer int) (<-chan T, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan T, buffer)
+	if b.closed {
+		close(ch)
+
+		return ch, func() {}
+	}
+	b.subscribers[ch] = struct{}{}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			if _, ok := b.subscribers[ch]; ok {
+				delete(b.subscribers, ch)
+				close(ch)
+			}
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+func (b *Broker[T]) Done() <-chan struct{} {
+	return b.done
+}
+
+// Close закрывает каналы всех подписчиков, после чего новые подписки сразу получают закрытый канал
+func (b *Broker[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+	close(b.done)
+}
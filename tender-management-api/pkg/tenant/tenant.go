@@ -0,0 +1,20 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type contextKey struct{}
+
+// WithId возвращает контекст, все запросы к базе в котором выполняются от имени арендатора
+func WithId(ctx context.Context, tenantId uuid.UUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenantId)
+}
+
+func FromContext(ctx context.Context) (uuid.UUID, bool) {
+	tenantId, ok := ctx.Value(contextKey{}).(uuid.UUID)
+
+	return tenantId, ok
+}